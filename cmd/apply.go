@@ -0,0 +1,151 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Ewout Prangsma
+//
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+	"google.golang.org/grpc"
+
+	crypto "github.com/arangodb-managed/apis/crypto/v1"
+	data "github.com/arangodb-managed/apis/data/v1"
+	iam "github.com/arangodb-managed/apis/iam/v1"
+	rm "github.com/arangodb-managed/apis/resourcemanager/v1"
+	security "github.com/arangodb-managed/apis/security/v1"
+
+	"github.com/arangodb-managed/oasisctl/pkg/manifest"
+)
+
+func init() {
+	InitCommand(
+		RootCmd,
+		&cobra.Command{
+			Use:   "apply",
+			Short: "Create or update resources as described in manifest files",
+			Long: `Create or update groups, roles, policies, projects, CA certificates, IP whitelists
+and deployments as described in manifest files (YAML or JSON).
+
+Resources are identified by name. Resources that do not exist are created,
+existing resources are updated for all fields that are set in the manifest.
+Resources, group members and role bindings that are not in the manifest are left untouched.
+Use 'oasisctl export' to create manifests of existing resources.
+
+Example manifest:
+
+	organization: my-organization
+	groups:
+	- name: operators
+	  members: ["alice@example.com"]
+	projects:
+	- name: production
+	  policy:
+	    bindings:
+	    - role: Deployment Viewer
+	      group: operators
+	  ipwhitelists:
+	  - name: office
+	    cidr-ranges: ["1.2.3.0/24"]
+	  deployments:
+	  - name: main
+	    region: gcp-europe-west4
+	    version: 3.6.4
+	    ipwhitelist: office
+	    model: oneshard
+	    node-size-id: c4-a4
+	    node-disk-size: 20
+`,
+		},
+		func(c *cobra.Command, f *flag.FlagSet) {
+			cargs := &struct {
+				files          []string
+				organizationID string
+				dryRun         bool
+			}{}
+			f.StringSliceVarP(&cargs.files, "file", "f", nil, "Manifest file(s) to apply (use - for stdin)")
+			f.StringVarP(&cargs.organizationID, "organization-id", "o", DefaultOrganization(), "Identifier of the organization (if not specified in the manifest)")
+			f.BoolVar(&cargs.dryRun, "dry-run", false, "Only show what would be changed (see also 'oasisctl diff')")
+
+			c.RunE = func(c *cobra.Command, args []string) error {
+				// Validate arguments
+				log := CLILog
+				if err := CheckNumberOfArgs(args, 0); err != nil {
+					return err
+				}
+				if len(cargs.files) == 0 {
+					return UsageError("--file missing")
+				}
+				m, err := manifest.LoadAll(cargs.files)
+				if err != nil {
+					return WrapError(err, "Failed to load manifest")
+				}
+
+				// Connect
+				conn, err := DialAPI()
+				if err != nil {
+					return err
+				}
+				clients := newManifestClients(conn)
+				ctx, err := ContextWithToken()
+				if err != nil {
+					return err
+				}
+
+				// Compute changes
+				plan, err := manifest.NewPlan(ctx, log, m, cargs.organizationID, clients)
+				if err != nil {
+					return WrapError(err, "Failed to compute changes")
+				}
+				if !plan.HasChanges() {
+					fmt.Println("No changes")
+					return nil
+				}
+				fmt.Print(plan.Diff(UseColor()))
+				if cargs.dryRun {
+					return nil
+				}
+
+				// Apply changes
+				if err := plan.Apply(ctx, log); err != nil {
+					return WrapError(err, "Failed to apply manifest")
+				}
+
+				// Show result
+				fmt.Printf("Applied %d change(s)!\n", len(plan.Actions))
+				return nil
+			}
+		},
+	)
+}
+
+// newManifestClients returns the API clients used to plan, apply & export manifests.
+func newManifestClients(conn *grpc.ClientConn) manifest.Clients {
+	return manifest.Clients{
+		ResourceManager: rm.NewResourceManagerServiceClient(conn),
+		Crypto:          crypto.NewCryptoServiceClient(conn),
+		Security:        security.NewSecurityServiceClient(conn),
+		Data:            data.NewDataServiceClient(conn),
+		IAM:             iam.NewIAMServiceClient(conn),
+	}
+}
@@ -0,0 +1,145 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Ewout Prangsma
+//
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/arangodb-managed/oasisctl/pkg/config"
+)
+
+var (
+	// ConfigCmd is root for various `config ...` commands
+	ConfigCmd = &cobra.Command{
+		Use:   "config",
+		Short: "Manage the oasisctl configuration",
+		Long: `Manage the oasisctl configuration.
+
+The configuration file (default ~/.config/oasisctl/config.yaml, override using OASIS_CONFIG)
+contains named profiles with default values for the endpoint, token, format,
+organization, project, deployment, region, provider & connection settings.
+Values given as flag or OASIS_* environment variable take precedence over profile values.`,
+		Run: ShowUsage,
+	}
+)
+
+// profileDefaults lists the profile keys that provide defaults for flags,
+// with the environment variable that takes precedence over them.
+var profileDefaults = []struct {
+	key          string
+	flag         string
+	envKeySuffix string
+}{
+	{config.KeyEndpoint, "endpoint", "ENDPOINT"},
+	{config.KeyFormat, "format", "FORMAT"},
+	{config.KeyOrganization, "organization-id", "ORGANIZATION"},
+	{config.KeyProject, "project-id", "PROJECT"},
+	{config.KeyDeployment, "deployment-id", "DEPLOYMENT"},
+	{config.KeyRegion, "region-id", "REGION"},
+	{config.KeyProvider, "provider-id", "PROVIDER"},
+	{config.KeyCACert, "ca-cert", "CA_CERT"},
+	{config.KeyClientCert, "client-cert", "CLIENT_CERT"},
+	{config.KeyClientKey, "client-key", "CLIENT_KEY"},
+	{config.KeyInsecureSkipVerify, "insecure-skip-verify", "INSECURE_SKIP_VERIFY"},
+	{config.KeyPlaintext, "plaintext", "PLAINTEXT"},
+	{config.KeyProxy, "proxy", "PROXY"},
+}
+
+func init() {
+	RootCmd.AddCommand(ConfigCmd)
+}
+
+// ConfigPath returns the path of the configuration file.
+func ConfigPath() (string, error) {
+	if p := envOrDefault("CONFIG", ""); p != "" {
+		return p, nil
+	}
+	p, err := config.DefaultPath()
+	if err != nil {
+		return "", WrapError(err, "Failed to determine configuration path")
+	}
+	return p, nil
+}
+
+// LoadConfig loads the configuration file.
+func LoadConfig() (*config.Config, error) {
+	path, err := ConfigPath()
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := config.Load(path)
+	if err != nil {
+		return nil, WrapError(err, "Failed to load configuration")
+	}
+	return cfg, nil
+}
+
+// SaveConfig saves the given configuration.
+func SaveConfig(cfg *config.Config) error {
+	path, err := ConfigPath()
+	if err != nil {
+		return err
+	}
+	if err := cfg.Save(path); err != nil {
+		return WrapError(err, "Failed to save configuration")
+	}
+	return nil
+}
+
+// CurrentProfileName returns the name of the selected profile.
+// This is the --profile value, the current profile of the given configuration
+// or the default profile, in that order.
+func CurrentProfileName(cfg *config.Config) string {
+	if RootArgs.Profile != "" {
+		return RootArgs.Profile
+	}
+	if current := cfg.CurrentProfile; current != "" {
+		return current
+	}
+	return config.DefaultProfileName
+}
+
+// applyProfile sets the flags of the given command, that have not been
+// set explicitly or through an environment variable, to the values of the
+// given profile.
+func applyProfile(cmd *cobra.Command, profile *config.Profile) error {
+	if profile == nil {
+		return nil
+	}
+	f := cmd.Flags()
+	for _, pd := range profileDefaults {
+		flag := f.Lookup(pd.flag)
+		if flag == nil || flag.Changed || envOrDefault(pd.envKeySuffix, "") != "" {
+			continue
+		}
+		value, _ := profile.Get(pd.key)
+		if value == "" {
+			continue
+		}
+		// Set the value directly so the flag is not marked as changed.
+		if err := flag.Value.Set(value); err != nil {
+			return WrapError(err, "Invalid value for --%s in profile", pd.flag)
+		}
+	}
+	return nil
+}
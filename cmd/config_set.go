@@ -0,0 +1,87 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Ewout Prangsma
+//
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+
+	"github.com/arangodb-managed/oasisctl/pkg/config"
+)
+
+func init() {
+	InitCommand(
+		ConfigCmd,
+		&cobra.Command{
+			Use:   "set",
+			Short: "Set a value in a profile",
+			Long: `Set a value in the profile selected by --profile (or the current profile).
+The profile is created when it does not exist yet. Use an empty value to remove a key.
+
+Supported keys: ` + strings.Join(config.Keys(), ", "),
+		},
+		func(c *cobra.Command, f *flag.FlagSet) {
+			cargs := &struct {
+				key   string
+				value string
+			}{}
+			f.StringVarP(&cargs.key, "key", "k", "", "Key of the value to set")
+			f.StringVarP(&cargs.value, "value", "v", "", "Value to set")
+
+			c.RunE = func(c *cobra.Command, args []string) error {
+				// Validate arguments
+				key, argsUsed, err := ReqOption("key", cargs.key, args, 0)
+				if err != nil {
+					return err
+				}
+				value, argsUsed := OptOption("value", cargs.value, args, argsUsed)
+				if err := CheckNumberOfArgs(args, argsUsed); err != nil {
+					return err
+				}
+
+				// Update configuration
+				cfg, err := LoadConfig()
+				if err != nil {
+					return err
+				}
+				name := CurrentProfileName(cfg)
+				if err := cfg.EnsureProfile(name).Set(key, value); err != nil {
+					return WrapError(err, "Failed to set value")
+				}
+				if cfg.CurrentProfile == "" {
+					cfg.CurrentProfile = name
+				}
+				if err := SaveConfig(cfg); err != nil {
+					return err
+				}
+
+				// Show result
+				fmt.Printf("Updated profile '%s'\n", name)
+				return nil
+			}
+		},
+	)
+}
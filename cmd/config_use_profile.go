@@ -0,0 +1,74 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Ewout Prangsma
+//
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+)
+
+func init() {
+	InitCommand(
+		ConfigCmd,
+		&cobra.Command{
+			Use:   "use-profile",
+			Short: "Select the profile that is used when no --profile is given",
+		},
+		func(c *cobra.Command, f *flag.FlagSet) {
+			cargs := &struct {
+				name string
+			}{}
+			f.StringVarP(&cargs.name, "name", "n", "", "Name of the profile")
+
+			c.RunE = func(c *cobra.Command, args []string) error {
+				// Validate arguments
+				name, argsUsed, err := ReqOption("name", cargs.name, args, 0)
+				if err != nil {
+					return err
+				}
+				if err := CheckNumberOfArgs(args, argsUsed); err != nil {
+					return err
+				}
+
+				// Update configuration
+				cfg, err := LoadConfig()
+				if err != nil {
+					return err
+				}
+				if cfg.Profile(name) == nil {
+					return UsageError("Profile '%s' not found", name)
+				}
+				cfg.CurrentProfile = name
+				if err := SaveConfig(cfg); err != nil {
+					return err
+				}
+
+				// Show result
+				fmt.Printf("Switched to profile '%s'\n", name)
+				return nil
+			}
+		},
+	)
+}
@@ -0,0 +1,80 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Ewout Prangsma
+//
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+	"gopkg.in/yaml.v2"
+)
+
+func init() {
+	InitCommand(
+		ConfigCmd,
+		&cobra.Command{
+			Use:   "view",
+			Short: "Show the configuration",
+		},
+		func(c *cobra.Command, f *flag.FlagSet) {
+			cargs := &struct {
+				showTokens bool
+			}{}
+			f.BoolVar(&cargs.showTokens, "show-tokens", false, "Show tokens instead of masking them")
+
+			c.RunE = func(c *cobra.Command, args []string) error {
+				// Validate arguments
+				if err := CheckNumberOfArgs(args, 0); err != nil {
+					return err
+				}
+
+				// Load configuration
+				cfg, err := LoadConfig()
+				if err != nil {
+					return err
+				}
+				if !cargs.showTokens {
+					for _, p := range cfg.Profiles {
+						if p.Token != "" {
+							p.Token = "***"
+						}
+					}
+				}
+				encoded, err := yaml.Marshal(cfg)
+				if err != nil {
+					return WrapError(err, "Failed to encode configuration")
+				}
+
+				// Show result
+				path, err := ConfigPath()
+				if err != nil {
+					return err
+				}
+				fmt.Printf("# %s\n", path)
+				fmt.Print(string(encoded))
+				return nil
+			}
+		},
+	)
+}
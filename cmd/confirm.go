@@ -0,0 +1,59 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Ewout Prangsma
+//
+
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// Confirm asks the user to confirm the given question on the terminal.
+// If yes is set (e.g. using a --yes flag), no question is asked.
+// An error is returned when the user does not confirm, or when there is
+// no terminal to ask the question on.
+func Confirm(question string, yes bool) error {
+	if yes {
+		return nil
+	}
+	nonInteractive := UsageError("%s\nUse --yes to confirm in non-interactive mode", question)
+	if !StdinIsTerminal() {
+		return nonInteractive
+	}
+	fmt.Fprintf(os.Stderr, "%s [y/N] ", question)
+	answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err == io.EOF && answer == "" {
+		// E.g. /dev/null
+		fmt.Fprintln(os.Stderr)
+		return nonInteractive
+	} else if err != nil && err != io.EOF {
+		return WrapError(err, "Failed to read confirmation")
+	}
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return nil
+	}
+	return &commandError{msg: "Aborted", exitCode: ExitCodeGeneral}
+}
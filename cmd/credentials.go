@@ -0,0 +1,135 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Ewout Prangsma
+//
+
+package cmd
+
+import (
+	"context"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+
+	iam "github.com/arangodb-managed/apis/iam/v1"
+
+	"github.com/arangodb-managed/oasisctl/pkg/config"
+)
+
+const (
+	// Stored tokens are refreshed when they expire within this margin.
+	tokenRefreshMargin = time.Minute * 5
+)
+
+// CredentialsPath returns the path of the credentials file.
+func CredentialsPath() (string, error) {
+	if p := envOrDefault("CREDENTIALS", ""); p != "" {
+		return p, nil
+	}
+	p, err := config.DefaultCredentialsPath()
+	if err != nil {
+		return "", WrapError(err, "Failed to determine credentials path")
+	}
+	return p, nil
+}
+
+// LoadCredentials loads the credentials file.
+func LoadCredentials() (*config.Credentials, error) {
+	path, err := CredentialsPath()
+	if err != nil {
+		return nil, err
+	}
+	creds, err := config.LoadCredentials(path)
+	if err != nil {
+		return nil, WrapError(err, "Failed to load credentials")
+	}
+	return creds, nil
+}
+
+// SaveCredentials saves the given credentials.
+func SaveCredentials(creds *config.Credentials) error {
+	path, err := CredentialsPath()
+	if err != nil {
+		return err
+	}
+	if err := creds.Save(path); err != nil {
+		return WrapError(err, "Failed to save credentials")
+	}
+	return nil
+}
+
+// authenticateAPIKey authenticates using the given API key and returns
+// the resulting token with its expiration time.
+func authenticateAPIKey(ctx context.Context, keyID, keySecret string) (string, time.Time, error) {
+	conn, err := DialAPI()
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	iamc := iam.NewIAMServiceClient(conn)
+	resp, err := iamc.AuthenticateAPIKey(ctx, &iam.AuthenticateAPIKeyRequest{
+		Id:     keyID,
+		Secret: keySecret,
+	})
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	var expiresAt time.Time
+	if ttl := resp.GetTimeToLive(); ttl != nil {
+		d, err := types.DurationFromProto(ttl)
+		if err != nil {
+			return "", time.Time{}, err
+		}
+		expiresAt = time.Now().Add(d)
+	}
+	return resp.GetToken(), expiresAt, nil
+}
+
+// tokenFromCredentials returns the token stored for the current profile.
+// When that token is (nearly) expired and an API key is stored,
+// a new token is obtained and stored.
+func tokenFromCredentials() (string, error) {
+	log := CLILog
+	cfg, err := LoadConfig()
+	if err != nil {
+		return "", err
+	}
+	profileName := CurrentProfileName(cfg)
+	creds, err := LoadCredentials()
+	if err != nil {
+		return "", err
+	}
+	pc := creds.Profile(profileName)
+	if pc == nil {
+		return "", nil
+	}
+	if pc.NeedsRefresh(tokenRefreshMargin) && pc.CanRefresh() {
+		log.Debug().Str("profile", profileName).Msg("Refreshing stored token")
+		token, expiresAt, err := authenticateAPIKey(context.Background(), pc.KeyID, pc.KeySecret)
+		if err != nil {
+			return "", WrapError(err, "Failed to refresh token, use 'oasisctl login' to login again")
+		}
+		pc.Token = token
+		pc.ExpiresAt = expiresAt
+		if err := SaveCredentials(creds); err != nil {
+			return "", err
+		}
+	}
+	return pc.Token, nil
+}
@@ -23,7 +23,6 @@
 package crypto
 
 import (
-	"fmt"
 	"time"
 
 	types "github.com/gogo/protobuf/types"
@@ -57,23 +56,37 @@ func init() {
 			f.StringVar(&cargs.description, "description", "", "Description of the CA certificate")
 			f.StringVarP(&cargs.organizationID, "organization-id", "o", cmd.DefaultOrganization(), "Identifier of the organization to create the CA certificate in")
 			f.StringVarP(&cargs.projectID, "project-id", "p", cmd.DefaultProject(), "Identifier of the project to create the CA certificate in")
-			f.DurationVar(&cargs.lifetime, "lifetime", 0, "Lifetime of the CA certificate.")
+			cmd.DurationVar(f, &cargs.lifetime, "lifetime", 0, "Lifetime of the CA certificate.")
 
-			c.Run = func(c *cobra.Command, args []string) {
+			c.RunE = func(c *cobra.Command, args []string) error {
 				// Validate arguments
 				log := cmd.CLILog
-				name, argsUsed := cmd.ReqOption("name", cargs.name, args, 0)
+				name, argsUsed, err := cmd.ReqOption("name", cargs.name, args, 0)
+				if err != nil {
+					return err
+				}
 				description := cargs.description
-				cmd.MustCheckNumberOfArgs(args, argsUsed)
+				if err := cmd.CheckNumberOfArgs(args, argsUsed); err != nil {
+					return err
+				}
 
 				// Connect
-				conn := cmd.MustDialAPI()
+				conn, err := cmd.DialAPI()
+				if err != nil {
+					return err
+				}
 				cryptoc := crypto.NewCryptoServiceClient(conn)
 				rmc := rm.NewResourceManagerServiceClient(conn)
-				ctx := cmd.ContextWithToken()
+				ctx, err := cmd.ContextWithToken()
+				if err != nil {
+					return err
+				}
 
 				// Fetch project
-				project := selection.MustSelectProject(ctx, log, cargs.projectID, cargs.organizationID, rmc)
+				project, err := selection.SelectProject(ctx, log, cargs.projectID, cargs.organizationID, rmc)
+				if err != nil {
+					return cmd.WrapError(err, "Failed to get project")
+				}
 
 				// Create ca certificate
 				var lifetime *types.Duration
@@ -87,12 +100,12 @@ func init() {
 					Lifetime:    lifetime,
 				})
 				if err != nil {
-					log.Fatal().Err(err).Msg("Failed to create CA certificate")
+					return cmd.WrapError(err, "Failed to create CA certificate")
 				}
 
 				// Show result
 				format.DisplaySuccess(cmd.RootArgs.Format)
-				fmt.Println(format.CACertificate(result, cmd.RootArgs.Format))
+				return cmd.ShowResult(format.CACertificate(result, cmd.RootArgs.Format))
 			}
 		},
 	)
@@ -53,28 +53,40 @@ func init() {
 			f.StringVarP(&cargs.organizationID, "organization-id", "o", cmd.DefaultOrganization(), "Identifier of the organization")
 			f.StringVarP(&cargs.projectID, "project-id", "p", cmd.DefaultProject(), "Identifier of the project")
 
-			c.Run = func(c *cobra.Command, args []string) {
+			c.RunE = func(c *cobra.Command, args []string) error {
 				// Validate arguments
 				log := cmd.CLILog
 				cacertID, argsUsed := cmd.OptOption("cacertificate-id", cargs.cacertID, args, 0)
-				cmd.MustCheckNumberOfArgs(args, argsUsed)
+				if err := cmd.CheckNumberOfArgs(args, argsUsed); err != nil {
+					return err
+				}
 
 				// Connect
-				conn := cmd.MustDialAPI()
+				conn, err := cmd.DialAPI()
+				if err != nil {
+					return err
+				}
 				cryptoc := crypto.NewCryptoServiceClient(conn)
 				rmc := rm.NewResourceManagerServiceClient(conn)
-				ctx := cmd.ContextWithToken()
+				ctx, err := cmd.ContextWithToken()
+				if err != nil {
+					return err
+				}
 
 				// Fetch CA certificate
-				item := selection.MustSelectCACertificate(ctx, log, cacertID, cargs.projectID, cargs.organizationID, cryptoc, rmc)
+				item, err := selection.SelectCACertificate(ctx, log, cacertID, cargs.projectID, cargs.organizationID, cryptoc, rmc)
+				if err != nil {
+					return cmd.WrapError(err, "Failed to get CA certificate")
+				}
 
 				// Delete CA certificate
 				if _, err := cryptoc.DeleteCACertificate(ctx, &common.IDOptions{Id: item.GetId()}); err != nil {
-					log.Fatal().Err(err).Msg("Failed to delete CA certificate")
+					return cmd.WrapError(err, "Failed to delete CA certificate")
 				}
 
 				// Show result
 				fmt.Println("Deleted CA certificate!")
+				return nil
 			}
 		},
 	)
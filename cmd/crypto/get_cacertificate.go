@@ -23,8 +23,6 @@
 package crypto
 
 import (
-	"fmt"
-
 	"github.com/spf13/cobra"
 	flag "github.com/spf13/pflag"
 
@@ -53,23 +51,34 @@ func init() {
 			f.StringVarP(&cargs.organizationID, "organization-id", "o", cmd.DefaultOrganization(), "Identifier of the organization")
 			f.StringVarP(&cargs.projectID, "project-id", "p", cmd.DefaultProject(), "Identifier of the project")
 
-			c.Run = func(c *cobra.Command, args []string) {
+			c.RunE = func(c *cobra.Command, args []string) error {
 				// Validate arguments
 				log := cmd.CLILog
 				cacertID, argsUsed := cmd.OptOption("cacertificate-id", cargs.cacertID, args, 0)
-				cmd.MustCheckNumberOfArgs(args, argsUsed)
+				if err := cmd.CheckNumberOfArgs(args, argsUsed); err != nil {
+					return err
+				}
 
 				// Connect
-				conn := cmd.MustDialAPI()
+				conn, err := cmd.DialAPI()
+				if err != nil {
+					return err
+				}
 				cryptoc := crypto.NewCryptoServiceClient(conn)
 				rmc := rm.NewResourceManagerServiceClient(conn)
-				ctx := cmd.ContextWithToken()
+				ctx, err := cmd.ContextWithToken()
+				if err != nil {
+					return err
+				}
 
 				// Fetch CA certificate
-				item := selection.MustSelectCACertificate(ctx, log, cacertID, cargs.projectID, cargs.organizationID, cryptoc, rmc)
+				item, err := selection.SelectCACertificate(ctx, log, cacertID, cargs.projectID, cargs.organizationID, cryptoc, rmc)
+				if err != nil {
+					return cmd.WrapError(err, "Failed to get CA certificate")
+				}
 
 				// Show result
-				fmt.Println(format.CACertificate(item, cmd.RootArgs.Format))
+				return cmd.ShowResult(format.CACertificate(item, cmd.RootArgs.Format))
 			}
 
 		},
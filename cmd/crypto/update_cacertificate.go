@@ -59,20 +59,31 @@ func init() {
 			f.StringVar(&cargs.description, "description", "", "Description of the CA certificate")
 			f.BoolVar(&cargs.useWellKnownCertificate, "use-well-known-certificate", false, "Sets the usage of a well known certificate ie. Let's Encrypt")
 
-			c.Run = func(c *cobra.Command, args []string) {
+			c.RunE = func(c *cobra.Command, args []string) error {
 				// Validate arguments
 				log := cmd.CLILog
 				cacertID, argsUsed := cmd.OptOption("cacertificate-id", cargs.cacertID, args, 0)
-				cmd.MustCheckNumberOfArgs(args, argsUsed)
+				if err := cmd.CheckNumberOfArgs(args, argsUsed); err != nil {
+					return err
+				}
 
 				// Connect
-				conn := cmd.MustDialAPI()
+				conn, err := cmd.DialAPI()
+				if err != nil {
+					return err
+				}
 				cryptoc := crypto.NewCryptoServiceClient(conn)
 				rmc := rm.NewResourceManagerServiceClient(conn)
-				ctx := cmd.ContextWithToken()
+				ctx, err := cmd.ContextWithToken()
+				if err != nil {
+					return err
+				}
 
 				// Fetch CA certificate
-				item := selection.MustSelectCACertificate(ctx, log, cacertID, cargs.projectID, cargs.organizationID, cryptoc, rmc)
+				item, err := selection.SelectCACertificate(ctx, log, cacertID, cargs.projectID, cargs.organizationID, cryptoc, rmc)
+				if err != nil {
+					return cmd.WrapError(err, "Failed to get CA certificate")
+				}
 
 				// Set changes
 				f := c.Flags()
@@ -95,13 +106,16 @@ func init() {
 					// Update CA certificate
 					updated, err := cryptoc.UpdateCACertificate(ctx, item)
 					if err != nil {
-						log.Fatal().Err(err).Msg("Failed to update CA certificate")
+						return cmd.WrapError(err, "Failed to update CA certificate")
 					}
 
 					// Show result
 					fmt.Println("Updated CA certificate!")
-					fmt.Println(format.CACertificate(updated, cmd.RootArgs.Format))
+					if err := cmd.ShowResult(format.CACertificate(updated, cmd.RootArgs.Format)); err != nil {
+						return err
+					}
 				}
+				return nil
 			}
 		},
 	)
@@ -0,0 +1,245 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Ewout Prangsma
+//
+
+package data
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+	flag "github.com/spf13/pflag"
+
+	backup "github.com/arangodb-managed/apis/backup/v1"
+
+	"github.com/arangodb-managed/oasisctl/cmd"
+)
+
+var (
+	// backupPolicyScheduleTypes maps the accepted --schedule-type values to API values.
+	backupPolicyScheduleTypes = map[string]string{
+		"hourly":  "Hourly",
+		"daily":   "Daily",
+		"monthly": "Monthly",
+	}
+	// backupPolicyEmailNotifications maps the accepted --email-notification values to API values.
+	backupPolicyEmailNotifications = map[string]string{
+		"never":        "Never",
+		"failure-only": "FailureOnly",
+		"always":       "Always",
+	}
+	// backupPolicyWeekdays lists the accepted --days values in order.
+	backupPolicyWeekdays = []string{"monday", "tuesday", "wednesday", "thursday", "friday", "saturday", "sunday"}
+)
+
+// backupPolicyArgs holds the flags shared by create & update backup policy.
+type backupPolicyArgs struct {
+	description        string
+	scheduleType       string
+	everyIntervalHours int
+	days               []string
+	timeOfDay          string
+	timeZone           string
+	dayOfMonth         int
+	upload             bool
+	retentionPeriod    time.Duration
+	emailNotification  string
+	paused             bool
+}
+
+// addFlags registers the backup policy flags on the given flag set.
+func (a *backupPolicyArgs) addFlags(f *flag.FlagSet) {
+	f.StringVar(&a.description, "description", "", "Description of the backup policy")
+	f.StringVar(&a.scheduleType, "schedule-type", "", "Schedule of the backup policy (hourly|daily|monthly)")
+	f.IntVar(&a.everyIntervalHours, "every-interval-hours", 0, "Create a backup every this many hours (1-23, hourly schedule only)")
+	f.StringSliceVar(&a.days, "days", nil, "Days of the week to create a backup on (monday..sunday or mon..sun, daily schedule only, default all days)")
+	f.StringVar(&a.timeOfDay, "time", "", "Time of day (HH:MM) to create a backup at (daily & monthly schedule only)")
+	f.StringVar(&a.timeZone, "time-zone", "", "Time zone of --time, as defined in RFC-822 (default UTC)")
+	f.IntVar(&a.dayOfMonth, "day-of-month", 0, "Day of the month (1-31) to create a backup on (monthly schedule only)")
+	f.BoolVar(&a.upload, "upload", false, "Upload the backups created by this policy")
+	cmd.DurationVar(f, &a.retentionPeriod, "retention-period", 0, "Automatically delete backups created by this policy after this period (0 means never)")
+	f.StringVar(&a.emailNotification, "email-notification", "", "Notify the organization owners by email (never|failure-only|always)")
+	f.BoolVar(&a.paused, "paused", false, "Pause the backup policy, so it does not create new backups")
+}
+
+// apply sets all changed flags on the given backup policy.
+// Returns true if anything has changed.
+func (a *backupPolicyArgs) apply(f *flag.FlagSet, p *backup.BackupPolicy) (bool, error) {
+	hasChanges := false
+	if f.Changed("description") {
+		p.Description = a.description
+		hasChanges = true
+	}
+	if f.Changed("upload") {
+		p.Upload = a.upload
+		hasChanges = true
+	}
+	if f.Changed("retention-period") {
+		if a.retentionPeriod < 0 {
+			return false, cmd.UsageError("Invalid --retention-period: must not be negative")
+		}
+		p.RetentionPeriod = types.DurationProto(a.retentionPeriod)
+		hasChanges = true
+	}
+	if f.Changed("email-notification") {
+		value, found := backupPolicyEmailNotifications[strings.ToLower(a.emailNotification)]
+		if !found {
+			return false, cmd.UsageError("Invalid --email-notification '%s': expected never, failure-only or always", a.emailNotification)
+		}
+		p.EmailNotification = value
+		hasChanges = true
+	}
+	if f.Changed("paused") {
+		p.IsPaused = a.paused
+		hasChanges = true
+	}
+	scheduleChanged, err := a.applySchedule(f, p)
+	if err != nil {
+		return false, err
+	}
+	return hasChanges || scheduleChanged, nil
+}
+
+// applySchedule updates the schedule of the given backup policy from the changed flags.
+// Settings of the existing schedule are kept unless overridden by a flag.
+func (a *backupPolicyArgs) applySchedule(f *flag.FlagSet, p *backup.BackupPolicy) (bool, error) {
+	changed := false
+	for _, name := range []string{"schedule-type", "every-interval-hours", "days", "time", "time-zone", "day-of-month"} {
+		changed = changed || f.Changed(name)
+	}
+	if !changed {
+		return false, nil
+	}
+
+	existing := p.GetSchedule()
+	scheduleType := existing.GetScheduleType()
+	if f.Changed("schedule-type") {
+		var found bool
+		scheduleType, found = backupPolicyScheduleTypes[strings.ToLower(a.scheduleType)]
+		if !found {
+			return false, cmd.UsageError("Invalid --schedule-type '%s': expected hourly, daily or monthly", a.scheduleType)
+		}
+	}
+	if scheduleType == "" {
+		return false, cmd.UsageError("--schedule-type missing")
+	}
+
+	// Time of day (daily & monthly)
+	var at *backup.TimeOfDay
+	if existingAt := existing.GetDailySchedule().GetScheduleAt(); existingAt != nil {
+		at = existingAt
+	} else if existingAt := existing.GetMonthlySchedule().GetScheduleAt(); existingAt != nil {
+		at = existingAt
+	} else {
+		at = &backup.TimeOfDay{}
+	}
+	if f.Changed("time") {
+		hours, minutes, err := parseTimeOfDay(a.timeOfDay)
+		if err != nil {
+			return false, cmd.UsageError("Invalid --time: %s", err)
+		}
+		at.Hours, at.Minutes = hours, minutes
+	}
+	if f.Changed("time-zone") {
+		at.TimeZone = a.timeZone
+	}
+
+	s := &backup.BackupPolicy_Schedule{ScheduleType: scheduleType}
+	switch scheduleType {
+	case "Hourly":
+		interval := existing.GetHourlySchedule().GetScheduleEveryIntervalHours()
+		if f.Changed("every-interval-hours") {
+			interval = int32(a.everyIntervalHours)
+		}
+		if interval < 1 || interval > 23 {
+			return false, cmd.UsageError("Hourly schedule requires --every-interval-hours between 1 and 23")
+		}
+		s.HourlySchedule = &backup.BackupPolicy_HourlySchedule{ScheduleEveryIntervalHours: interval}
+	case "Daily":
+		daily := existing.GetDailySchedule()
+		if daily == nil || f.Changed("days") {
+			days := a.days
+			if len(days) == 0 {
+				days = backupPolicyWeekdays
+			}
+			var err error
+			daily, err = parseWeekdays(days)
+			if err != nil {
+				return false, cmd.UsageError("Invalid --days: %s", err)
+			}
+		}
+		daily.ScheduleAt = at
+		s.DailySchedule = daily
+	case "Monthly":
+		day := existing.GetMonthlySchedule().GetDayOfMonth()
+		if f.Changed("day-of-month") {
+			day = int32(a.dayOfMonth)
+		}
+		if day < 1 || day > 31 {
+			return false, cmd.UsageError("Monthly schedule requires --day-of-month between 1 and 31")
+		}
+		s.MonthlySchedule = &backup.BackupPolicy_MonthlySchedule{DayOfMonth: day, ScheduleAt: at}
+	}
+	p.Schedule = s
+	return true, nil
+}
+
+// parseTimeOfDay parses a time of day in HH:MM format.
+func parseTimeOfDay(value string) (int32, int32, error) {
+	parts := strings.Split(value, ":")
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("expected HH:MM, got '%s'", value)
+	}
+	hours, err := strconv.Atoi(parts[0])
+	if err != nil || hours < 0 || hours > 23 {
+		return 0, 0, fmt.Errorf("invalid hours in '%s'", value)
+	}
+	minutes, err := strconv.Atoi(parts[1])
+	if err != nil || minutes < 0 || minutes > 59 {
+		return 0, 0, fmt.Errorf("invalid minutes in '%s'", value)
+	}
+	return int32(hours), int32(minutes), nil
+}
+
+// parseWeekdays returns a daily schedule with the given days of the week enabled.
+// Days can be given by their full name or their first 3 letters.
+func parseWeekdays(days []string) (*backup.BackupPolicy_DailySchedule, error) {
+	result := &backup.BackupPolicy_DailySchedule{}
+	enabled := []*bool{&result.Monday, &result.Tuesday, &result.Wednesday, &result.Thursday, &result.Friday, &result.Saturday, &result.Sunday}
+	for _, day := range days {
+		d := strings.ToLower(strings.TrimSpace(day))
+		found := false
+		for i, name := range backupPolicyWeekdays {
+			if d == name || (len(d) == 3 && strings.HasPrefix(name, d)) {
+				*enabled[i] = true
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown day '%s'", day)
+		}
+	}
+	return result, nil
+}
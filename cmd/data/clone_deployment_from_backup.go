@@ -23,8 +23,6 @@
 package data
 
 import (
-	"fmt"
-
 	"github.com/spf13/cobra"
 	flag "github.com/spf13/pflag"
 
@@ -49,15 +47,22 @@ func init() {
 			f.StringVarP(&cargs.backupID, "backup-id", "b", "", "Clone a deployment from a backup using the backup's ID.")
 			f.StringVarP(&cargs.regionID, "region-id", "r", "", "An optionally defined region in which the new deployment should be created in.")
 
-			c.Run = func(c *cobra.Command, args []string) {
+			c.RunE = func(c *cobra.Command, args []string) error {
 				// Validate arguments
-				log := cmd.CLILog
 				backupID, argsUsed := cmd.OptOption("backup-id", cargs.backupID, args, 0)
-				cmd.MustCheckNumberOfArgs(args, argsUsed)
+				if err := cmd.CheckNumberOfArgs(args, argsUsed); err != nil {
+					return err
+				}
 
 				// Connect
-				conn := cmd.MustDialAPI()
-				ctx := cmd.ContextWithToken()
+				conn, err := cmd.DialAPI()
+				if err != nil {
+					return err
+				}
+				ctx, err := cmd.ContextWithToken()
+				if err != nil {
+					return err
+				}
 				repl := replication.NewReplicationServiceClient(conn)
 
 				req := &replication.CloneDeploymentFromBackupRequest{
@@ -66,12 +71,12 @@ func init() {
 				// Clone deployment
 				created, err := repl.CloneDeploymentFromBackup(ctx, req)
 				if err != nil {
-					log.Fatal().Err(err).Msg("Failed to clone deployment")
+					return cmd.WrapError(err, "Failed to clone deployment")
 				}
 
 				// Show result
 				format.DisplaySuccess(cmd.RootArgs.Format)
-				fmt.Println(format.Deployment(created, nil, cmd.RootArgs.Format, false))
+				return cmd.ShowResult(format.Deployment(created, nil, cmd.RootArgs.Format, false))
 			}
 		},
 	)
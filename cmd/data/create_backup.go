@@ -23,7 +23,6 @@
 package data
 
 import (
-	"fmt"
 	"time"
 
 	"github.com/gogo/protobuf/types"
@@ -35,78 +34,99 @@ import (
 	"github.com/arangodb-managed/oasisctl/pkg/format"
 )
 
-func init() {
-	cmd.InitCommand(
-		cmd.CreateCmd,
-		&cobra.Command{
-			Use:   "backup",
-			Short: "Create a new backup",
-		},
-		func(c *cobra.Command, f *flag.FlagSet) {
-			cargs := &struct {
-				name          string
-				deploymentID  string
-				policyID      string
-				description   string
-				autoDeletedAt int
-				upload        bool
-			}{}
-			f.StringVar(&cargs.name, "name", "", "Name of the deployment")
-			f.StringVar(&cargs.deploymentID, "deployment-id", "", "ID of the deployment")
-			f.StringVar(&cargs.description, "description", "", "Description of the backup")
-			f.BoolVar(&cargs.upload, "upload", false, "The backup should be uploaded")
-			f.IntVar(&cargs.autoDeletedAt, "auto-deleted-at", 0, "Time (h) until auto delete of the backup")
+const (
+	// Default time until automatic deletion of backups that are not uploaded
+	defaultBackupAutoDeleteAfter = time.Hour * 6
+)
 
-			c.Run = func(c *cobra.Command, args []string) {
-				// Validate arguments
-				log := cmd.CLILog
-				name, argsUsed := cmd.ReqOption("name", cargs.name, args, 0)
-				deploymentID, argsUsed := cmd.ReqOption("deployment-id", cargs.deploymentID, args, 0)
-				cmd.MustCheckNumberOfArgs(args, argsUsed)
+var createBackupCmd = cmd.InitCommand(
+	cmd.CreateCmd,
+	&cobra.Command{
+		Use:   "backup",
+		Short: "Create a new backup",
+	},
+	func(c *cobra.Command, f *flag.FlagSet) {
+		cargs := &struct {
+			name          string
+			deploymentID  string
+			policyID      string
+			description   string
+			autoDeletedAt time.Time
+			upload        bool
+			wait          bool
+			waitTimeout   time.Duration
+		}{}
+		f.StringVar(&cargs.name, "name", "", "Name of the deployment")
+		f.StringVar(&cargs.deploymentID, "deployment-id", "", "ID of the deployment")
+		f.StringVar(&cargs.description, "description", "", "Description of the backup")
+		f.BoolVar(&cargs.upload, "upload", false, "The backup should be uploaded")
+		cmd.FutureTimeVar(f, &cargs.autoDeletedAt, "auto-deleted-at", "Time of automatic deletion of the backup (default 6h for backups that are not uploaded)")
+		f.BoolVar(&cargs.wait, "wait", false, "Wait until the backup is ready (and uploaded if --upload is set)")
+		cmd.DurationVar(f, &cargs.waitTimeout, "wait-timeout", defaultWaitBackupTimeout, "How long to wait for the backup to be ready (0 for no limit)")
 
-				// Connect
-				conn := cmd.MustDialAPI()
-				backupc := backup.NewBackupServiceClient(conn)
-				ctx := cmd.ContextWithToken()
+		c.RunE = func(c *cobra.Command, args []string) error {
+			// Validate arguments
+			name, argsUsed, err := cmd.ReqOption("name", cargs.name, args, 0)
+			if err != nil {
+				return err
+			}
+			deploymentID, argsUsed, err := cmd.ReqOption("deployment-id", cargs.deploymentID, args, 0)
+			if err != nil {
+				return err
+			}
+			if err := cmd.CheckNumberOfArgs(args, argsUsed); err != nil {
+				return err
+			}
 
-				b := &backup.Backup{
-					Name:         name,
-					DeploymentId: deploymentID,
-					Description:  cargs.description,
-				}
+			// Connect
+			conn, err := cmd.DialAPI()
+			if err != nil {
+				return err
+			}
+			backupc := backup.NewBackupServiceClient(conn)
+			ctx, err := cmd.ContextWithToken()
+			if err != nil {
+				return err
+			}
+
+			b := &backup.Backup{
+				Name:         name,
+				DeploymentId: deploymentID,
+				Description:  cargs.description,
+			}
 
-				if cargs.upload {
-					b.Upload = true
-					if cargs.autoDeletedAt != 0 {
-						t := time.Now().Add(time.Duration(cargs.autoDeletedAt) * time.Hour)
-						tp, err := types.TimestampProto(t)
-						if err != nil {
-							log.Fatal().Err(err).Msg("Failed to convert from time to proto time")
-						}
-						b.AutoDeletedAt = tp
-					}
-				} else {
-					if cargs.autoDeletedAt == 0 {
-						cargs.autoDeletedAt = 6
-					}
-					t := time.Now().Add(time.Duration(cargs.autoDeletedAt) * time.Hour)
-					tp, err := types.TimestampProto(t)
-					if err != nil {
-						log.Fatal().Err(err).Msg("Failed to convert from time to proto time")
-					}
-					b.AutoDeletedAt = tp
+			b.Upload = cargs.upload
+			if !cargs.upload && cargs.autoDeletedAt.IsZero() {
+				cargs.autoDeletedAt = time.Now().Add(defaultBackupAutoDeleteAfter)
+			}
+			if !cargs.autoDeletedAt.IsZero() {
+				if !cargs.autoDeletedAt.After(time.Now()) {
+					return cmd.UsageError("--auto-deleted-at must be in the future")
+				}
+				tp, err := types.TimestampProto(cargs.autoDeletedAt)
+				if err != nil {
+					return cmd.WrapError(err, "Failed to convert from time to proto time")
 				}
+				b.AutoDeletedAt = tp
+			}
 
-				result, err := backupc.CreateBackup(ctx, b)
+			result, err := backupc.CreateBackup(ctx, b)
 
+			if err != nil {
+				return cmd.WrapError(err, "Failed to create backup")
+			}
+
+			// Wait for the backup
+			if cargs.wait {
+				result, err = waitForBackup(ctx, cmd.CLILog, backupc, result.GetId(), cargs.waitTimeout)
 				if err != nil {
-					log.Fatal().Err(err).Msg("Failed to create backup")
+					return err
 				}
-
-				// Show result
-				format.DisplaySuccess(cmd.RootArgs.Format)
-				fmt.Println(format.Backup(result, cmd.RootArgs.Format))
 			}
-		},
-	)
-}
+
+			// Show result
+			format.DisplaySuccess(cmd.RootArgs.Format)
+			return cmd.ShowResult(format.Backup(result, cmd.RootArgs.Format))
+		}
+	},
+)
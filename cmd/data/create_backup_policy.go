@@ -0,0 +1,102 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Ewout Prangsma
+//
+
+package data
+
+import (
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+
+	backup "github.com/arangodb-managed/apis/backup/v1"
+
+	"github.com/arangodb-managed/oasisctl/cmd"
+	"github.com/arangodb-managed/oasisctl/pkg/format"
+)
+
+func init() {
+	cmd.InitCommand(
+		createBackupCmd,
+		&cobra.Command{
+			Use:   "policy",
+			Short: "Create a new backup policy",
+			Example: `  oasisctl create backup policy --name nightly --deployment-id <id> --schedule-type daily --time 02:30
+  oasisctl create backup policy --name frequent --deployment-id <id> --schedule-type hourly --every-interval-hours 4 --retention-period 48h
+  oasisctl create backup policy --name monthly --deployment-id <id> --schedule-type monthly --day-of-month 1 --time 23:00 --upload`,
+		},
+		func(c *cobra.Command, f *flag.FlagSet) {
+			cargs := &struct {
+				name         string
+				deploymentID string
+				policy       backupPolicyArgs
+			}{}
+			f.StringVar(&cargs.name, "name", "", "Name of the backup policy")
+			f.StringVar(&cargs.deploymentID, "deployment-id", "", "ID of the deployment")
+			cargs.policy.addFlags(f)
+
+			c.RunE = func(c *cobra.Command, args []string) error {
+				// Validate arguments
+				name, argsUsed, err := cmd.ReqOption("name", cargs.name, args, 0)
+				if err != nil {
+					return err
+				}
+				deploymentID, _, err := cmd.ReqOption("deployment-id", cargs.deploymentID, nil, 0)
+				if err != nil {
+					return err
+				}
+				if err := cmd.CheckNumberOfArgs(args, argsUsed); err != nil {
+					return err
+				}
+				p := &backup.BackupPolicy{
+					Name:         name,
+					DeploymentId: deploymentID,
+				}
+				if _, err := cargs.policy.apply(c.Flags(), p); err != nil {
+					return err
+				}
+				if p.GetSchedule() == nil {
+					return cmd.UsageError("--schedule-type missing")
+				}
+
+				// Connect
+				conn, err := cmd.DialAPI()
+				if err != nil {
+					return err
+				}
+				backupc := backup.NewBackupServiceClient(conn)
+				ctx, err := cmd.ContextWithToken()
+				if err != nil {
+					return err
+				}
+
+				// Create backup policy
+				result, err := backupc.CreateBackupPolicy(ctx, p)
+				if err != nil {
+					return cmd.WrapError(err, "Failed to create backup policy")
+				}
+
+				// Show result
+				format.DisplaySuccess(cmd.RootArgs.Format)
+				return cmd.ShowResult(format.BackupPolicy(result, cmd.RootArgs.Format))
+			}
+		},
+	)
+}
@@ -84,25 +84,45 @@ func init() {
 			f.Int32Var(&cargs.dbserverMemorySize, "dbserver-memory-size", 4, "Set memory size of dbservers for flexible deployments (GB)")
 			f.Int32Var(&cargs.dbserverDiskSize, "dbserver-disk-size", 32, "Set disk size of dbservers for flexible deployments (GB)")
 
-			c.Run = func(c *cobra.Command, args []string) {
+			c.RunE = func(c *cobra.Command, args []string) error {
 				// Validate arguments
 				log := cmd.CLILog
-				name, argsUsed := cmd.ReqOption("name", cargs.name, args, 0)
-				regionID, _ := cmd.ReqOption("region-id", cargs.regionID, nil, 0)
-				cmd.MustCheckNumberOfArgs(args, argsUsed)
+				name, argsUsed, err := cmd.ReqOption("name", cargs.name, args, 0)
+				if err != nil {
+					return err
+				}
+				regionID, _, err := cmd.ReqOption("region-id", cargs.regionID, nil, 0)
+				if err != nil {
+					return err
+				}
+				if err := cmd.CheckNumberOfArgs(args, argsUsed); err != nil {
+					return err
+				}
 
 				// Connect
-				conn := cmd.MustDialAPI()
+				conn, err := cmd.DialAPI()
+				if err != nil {
+					return err
+				}
 				cryptoc := crypto.NewCryptoServiceClient(conn)
 				datac := data.NewDataServiceClient(conn)
 				rmc := rm.NewResourceManagerServiceClient(conn)
-				ctx := cmd.ContextWithToken()
+				ctx, err := cmd.ContextWithToken()
+				if err != nil {
+					return err
+				}
 
 				// Fetch project
-				project := selection.MustSelectProject(ctx, log, cargs.projectID, cargs.organizationID, rmc)
+				project, err := selection.SelectProject(ctx, log, cargs.projectID, cargs.organizationID, rmc)
+				if err != nil {
+					return cmd.WrapError(err, "Failed to get project")
+				}
 
 				// Select cacertificate (to use in deployment)
-				cacert := selection.MustSelectCACertificate(ctx, log, cargs.cacertificateID, project.GetId(), project.GetOrganizationId(), cryptoc, rmc)
+				cacert, err := selection.SelectCACertificate(ctx, log, cargs.cacertificateID, project.GetId(), project.GetOrganizationId(), cryptoc, rmc)
+				if err != nil {
+					return cmd.WrapError(err, "Failed to get CA certificate")
+				}
 
 				// Select servers for flexible deployments
 				var servers *data.Deployment_ServersSpec
@@ -123,10 +143,10 @@ func init() {
 						RegionId:  cargs.regionID,
 					})
 					if err != nil {
-						log.Fatal().Err(err).Msg("Failed to fetch node size list.")
+						return cmd.WrapError(err, "Failed to fetch node size list.")
 					}
 					if len(list.Items) < 1 {
-						log.Fatal().Msg("No available node sizes found.")
+						return fmt.Errorf("No available node sizes found.")
 					}
 					sort.SliceStable(list.Items, func(i, j int) bool {
 						return list.Items[i].MemorySize < list.Items[j].MemorySize
@@ -157,12 +177,12 @@ func init() {
 					},
 				})
 				if err != nil {
-					log.Fatal().Err(err).Msg("Failed to create deployment")
+					return cmd.WrapError(err, "Failed to create deployment")
 				}
 
 				// Show result
 				format.DisplaySuccess(cmd.RootArgs.Format)
-				fmt.Println(format.Deployment(result, nil, cmd.RootArgs.Format, false))
+				return cmd.ShowResult(format.Deployment(result, nil, cmd.RootArgs.Format, false))
 			}
 		},
 	)
@@ -34,38 +34,44 @@ import (
 	"github.com/arangodb-managed/oasisctl/cmd"
 )
 
-func init() {
-	cmd.InitCommand(
-		cmd.DeleteCmd,
-		&cobra.Command{
-			Use:   "backup",
-			Short: "Delete a backup for a given ID.",
-		},
-		func(c *cobra.Command, f *flag.FlagSet) {
-			cargs := &struct {
-				backupID string
-			}{}
-			f.StringVarP(&cargs.backupID, "id", "i", "", "Identifier of the backup")
+var deleteBackupCmd = cmd.InitCommand(
+	cmd.DeleteCmd,
+	&cobra.Command{
+		Use:   "backup",
+		Short: "Delete a backup for a given ID.",
+	},
+	func(c *cobra.Command, f *flag.FlagSet) {
+		cargs := &struct {
+			backupID string
+		}{}
+		f.StringVarP(&cargs.backupID, "id", "i", "", "Identifier of the backup")
 
-			c.Run = func(c *cobra.Command, args []string) {
-				// Validate arguments
-				log := cmd.CLILog
-				backupID, argsUsed := cmd.OptOption("id", cargs.backupID, args, 0)
-				cmd.MustCheckNumberOfArgs(args, argsUsed)
-
-				// Connect
-				conn := cmd.MustDialAPI()
-				backupc := backup.NewBackupServiceClient(conn)
-				ctx := cmd.ContextWithToken()
+		c.RunE = func(c *cobra.Command, args []string) error {
+			// Validate arguments
+			backupID, argsUsed := cmd.OptOption("id", cargs.backupID, args, 0)
+			if err := cmd.CheckNumberOfArgs(args, argsUsed); err != nil {
+				return err
+			}
 
-				// Delete backup
-				if _, err := backupc.DeleteBackup(ctx, &common.IDOptions{Id: backupID}); err != nil {
-					log.Fatal().Err(err).Msg("Failed to delete deployment")
-				}
+			// Connect
+			conn, err := cmd.DialAPI()
+			if err != nil {
+				return err
+			}
+			backupc := backup.NewBackupServiceClient(conn)
+			ctx, err := cmd.ContextWithToken()
+			if err != nil {
+				return err
+			}
 
-				// Show result
-				fmt.Println("Deleted backup!")
+			// Delete backup
+			if _, err := backupc.DeleteBackup(ctx, &common.IDOptions{Id: backupID}); err != nil {
+				return cmd.WrapError(err, "Failed to delete deployment")
 			}
-		},
-	)
-}
+
+			// Show result
+			fmt.Println("Deleted backup!")
+			return nil
+		}
+	},
+)
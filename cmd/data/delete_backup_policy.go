@@ -0,0 +1,92 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Ewout Prangsma
+//
+
+package data
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+
+	backup "github.com/arangodb-managed/apis/backup/v1"
+	common "github.com/arangodb-managed/apis/common/v1"
+
+	"github.com/arangodb-managed/oasisctl/cmd"
+	"github.com/arangodb-managed/oasisctl/pkg/selection"
+)
+
+func init() {
+	cmd.InitCommand(
+		deleteBackupCmd,
+		&cobra.Command{
+			Use:   "policy",
+			Short: "Delete a backup policy for a given ID.",
+		},
+		func(c *cobra.Command, f *flag.FlagSet) {
+			cargs := &struct {
+				ID           string
+				deploymentID string
+			}{}
+			f.StringVarP(&cargs.ID, "id", "i", "", "Identifier or name of the backup policy")
+			f.StringVar(&cargs.deploymentID, "deployment-id", "", "ID of the deployment (required to select a backup policy by name)")
+
+			c.RunE = func(c *cobra.Command, args []string) error {
+				// Validate arguments
+				log := cmd.CLILog
+				id, argsUsed, err := cmd.ReqOption("id", cargs.ID, args, 0)
+				if err != nil {
+					return err
+				}
+				if err := cmd.CheckNumberOfArgs(args, argsUsed); err != nil {
+					return err
+				}
+
+				// Connect
+				conn, err := cmd.DialAPI()
+				if err != nil {
+					return err
+				}
+				backupc := backup.NewBackupServiceClient(conn)
+				ctx, err := cmd.ContextWithToken()
+				if err != nil {
+					return err
+				}
+
+				// Select backup policy
+				item, err := selection.SelectBackupPolicy(ctx, log, id, cargs.deploymentID, backupc)
+				if err != nil {
+					return cmd.WrapError(err, "Failed to get backup policy")
+				}
+
+				// Delete backup policy
+				if _, err := backupc.DeleteBackupPolicy(ctx, &common.IDOptions{Id: item.GetId()}); err != nil {
+					return cmd.WrapError(err, "Failed to delete backup policy")
+				}
+
+				// Show result
+				fmt.Println("Deleted backup policy!")
+				return nil
+			}
+		},
+	)
+}
@@ -53,28 +53,40 @@ func init() {
 			f.StringVarP(&cargs.organizationID, "organization-id", "o", cmd.DefaultOrganization(), "Identifier of the organization")
 			f.StringVarP(&cargs.projectID, "project-id", "p", cmd.DefaultProject(), "Identifier of the project")
 
-			c.Run = func(c *cobra.Command, args []string) {
+			c.RunE = func(c *cobra.Command, args []string) error {
 				// Validate arguments
 				log := cmd.CLILog
 				deploymentID, argsUsed := cmd.OptOption("deployment-id", cargs.deploymentID, args, 0)
-				cmd.MustCheckNumberOfArgs(args, argsUsed)
+				if err := cmd.CheckNumberOfArgs(args, argsUsed); err != nil {
+					return err
+				}
 
 				// Connect
-				conn := cmd.MustDialAPI()
+				conn, err := cmd.DialAPI()
+				if err != nil {
+					return err
+				}
 				datac := data.NewDataServiceClient(conn)
 				rmc := rm.NewResourceManagerServiceClient(conn)
-				ctx := cmd.ContextWithToken()
+				ctx, err := cmd.ContextWithToken()
+				if err != nil {
+					return err
+				}
 
 				// Fetch deployment
-				item := selection.MustSelectDeployment(ctx, log, deploymentID, cargs.projectID, cargs.organizationID, datac, rmc)
+				item, err := selection.SelectDeployment(ctx, log, deploymentID, cargs.projectID, cargs.organizationID, datac, rmc)
+				if err != nil {
+					return cmd.WrapError(err, "Failed to get deployment")
+				}
 
 				// Delete deployment
 				if _, err := datac.DeleteDeployment(ctx, &common.IDOptions{Id: item.GetId()}); err != nil {
-					log.Fatal().Err(err).Msg("Failed to delete deployment")
+					return cmd.WrapError(err, "Failed to delete deployment")
 				}
 
 				// Show result
 				fmt.Println("Deleted deployment!")
+				return nil
 			}
 		},
 	)
@@ -23,15 +23,20 @@
 package data
 
 import (
+	"context"
 	"fmt"
+	"os"
+	"time"
 
+	"github.com/rs/zerolog"
 	"github.com/spf13/cobra"
 	flag "github.com/spf13/pflag"
 
 	backup "github.com/arangodb-managed/apis/backup/v1"
-	v1 "github.com/arangodb-managed/apis/common/v1"
+	common "github.com/arangodb-managed/apis/common/v1"
 
 	"github.com/arangodb-managed/oasisctl/cmd"
+	"github.com/arangodb-managed/oasisctl/pkg/format"
 )
 
 func init() {
@@ -40,33 +45,127 @@ func init() {
 		&cobra.Command{
 			Use:   "download",
 			Short: "Download a backup",
+			Long: `Download an uploaded backup into its deployment, so it can be restored.
+With --wait, the progress is shown until the download has completed.`,
 		},
 		func(c *cobra.Command, f *flag.FlagSet) {
 			cargs := &struct {
-				ID string
+				ID          string
+				wait        bool
+				waitTimeout time.Duration
 			}{}
 			f.StringVarP(&cargs.ID, "id", "i", "", "Identifier of the backup")
+			f.BoolVar(&cargs.wait, "wait", false, "Wait until the backup has been downloaded")
+			cmd.DurationVar(f, &cargs.waitTimeout, "wait-timeout", defaultWaitBackupTimeout, "How long to wait for the download to complete (0 for no limit)")
 
-			c.Run = func(c *cobra.Command, args []string) {
+			c.RunE = func(c *cobra.Command, args []string) error {
 				// Validate arguments
 				log := cmd.CLILog
 				id, argsUsed := cmd.OptOption("id", cargs.ID, args, 0)
-				cmd.MustCheckNumberOfArgs(args, argsUsed)
+				if err := cmd.CheckNumberOfArgs(args, argsUsed); err != nil {
+					return err
+				}
 
 				// Connect
-				conn := cmd.MustDialAPI()
+				conn, err := cmd.DialAPI()
+				if err != nil {
+					return err
+				}
 				backupc := backup.NewBackupServiceClient(conn)
-				ctx := cmd.ContextWithToken()
+				ctx, err := cmd.ContextWithToken()
+				if err != nil {
+					return err
+				}
 
 				// Fetch backup
-				_, err := backupc.DownloadBackup(ctx, &v1.IDOptions{Id: id})
+				b, err := backupc.GetBackup(ctx, &common.IDOptions{Id: id})
 				if err != nil {
-					log.Fatal().Err(err).Msg("Failed to fetch backup")
+					return cmd.WrapError(err, "Failed to fetch backup")
+				}
+				revision := b.GetDownload().GetRevision()
+
+				// Start download
+				if _, err := backupc.DownloadBackup(ctx, &common.IDOptions{Id: b.GetId()}); err != nil {
+					return cmd.WrapError(err, "Failed to download backup")
+				}
+
+				if cargs.wait {
+					// Wait for the download to complete
+					b, err = waitForBackupDownload(ctx, log, backupc, b.GetId(), revision, cargs.waitTimeout)
+					if err != nil {
+						return err
+					}
+				} else {
+					b, err = backupc.GetBackup(ctx, &common.IDOptions{Id: b.GetId()})
+					if err != nil {
+						return cmd.WrapError(err, "Failed to fetch backup")
+					}
 				}
 
 				// Show result
-				fmt.Println("Backup download started successfully!")
+				format.DisplaySuccess(cmd.RootArgs.Format)
+				return cmd.ShowResult(format.BackupDownload(b, cmd.RootArgs.Format))
 			}
 		},
 	)
 }
+
+// isBackupDownloaded returns true if a download of the given backup, requested
+// after the given (previous) download revision, has completed.
+func isBackupDownloaded(b *backup.Backup, previousRevision int32) bool {
+	revision := b.GetDownload().GetRevision()
+	downloadStatus := b.GetStatus().GetDownloadStatus()
+	return revision > previousRevision && downloadStatus.GetDownloaded() && downloadStatus.GetRevision() >= revision
+}
+
+// waitForBackupDownload waits until the backup with given ID has been downloaded
+// into its deployment, showing the progress on stderr.
+func waitForBackupDownload(ctx context.Context, log zerolog.Logger, backupc backup.BackupServiceClient, id string, previousRevision int32, timeout time.Duration) (*backup.Backup, error) {
+	start := time.Now()
+	terminal := cmd.StderrIsTerminal()
+	lastProgress := ""
+	defer func() {
+		if terminal {
+			fmt.Fprint(os.Stderr, "\r\x1b[K")
+		}
+	}()
+	var b *backup.Backup
+	err := pollUntil(ctx, timeout, func() (bool, error) {
+		var err error
+		b, err = backupc.GetBackup(ctx, &common.IDOptions{Id: id})
+		if err != nil {
+			return false, cmd.WrapError(err, "Failed to get backup")
+		}
+		status := b.GetStatus()
+		switch state := status.GetState(); {
+		case isBackupDownloaded(b, previousRevision):
+			return true, nil
+		case status.GetIsFailed() || state == "DownloadError" || state == "Failed":
+			return false, fmt.Errorf("Download of backup failed (%s): %s", state, status.GetMessage())
+		case b.GetIsDeleted() || state == "Deleted":
+			return false, fmt.Errorf("Backup has been deleted")
+		}
+
+		// Show progress
+		progress := status.GetState()
+		if p := status.GetProgress(); p != "" {
+			progress += " " + p
+		}
+		if terminal {
+			fmt.Fprintf(os.Stderr, "\r\x1b[KDownloading backup %s: %s (%s)", b.GetName(), progress, time.Since(start).Round(time.Second))
+		} else if progress != lastProgress {
+			log.Info().
+				Str("state", status.GetState()).
+				Str("progress", status.GetProgress()).
+				Msg("Downloading backup")
+		}
+		lastProgress = progress
+		return false, nil
+	})
+	if err == errPollTimeout {
+		return nil, fmt.Errorf("Backup not downloaded after %s (state %s)", timeout, b.GetStatus().GetState())
+	} else if err != nil {
+		return nil, err
+	}
+	return b, nil
+}
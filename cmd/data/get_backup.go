@@ -23,8 +23,6 @@
 package data
 
 import (
-	"fmt"
-
 	"github.com/spf13/cobra"
 	flag "github.com/spf13/pflag"
 
@@ -34,39 +32,44 @@ import (
 	"github.com/arangodb-managed/oasisctl/pkg/format"
 )
 
-func init() {
-	cmd.InitCommand(
-		cmd.GetCmd,
-		&cobra.Command{
-			Use:   "backup",
-			Short: "Get a backup",
-		},
-		func(c *cobra.Command, f *flag.FlagSet) {
-			cargs := &struct {
-				ID string
-			}{}
-			f.StringVarP(&cargs.ID, "id", "i", "", "Identifier of the backup")
-
-			c.Run = func(c *cobra.Command, args []string) {
-				// Validate arguments
-				log := cmd.CLILog
-				id, argsUsed := cmd.OptOption("id", cargs.ID, args, 0)
-				cmd.MustCheckNumberOfArgs(args, argsUsed)
+var getBackupCmd = cmd.InitCommand(
+	cmd.GetCmd,
+	&cobra.Command{
+		Use:   "backup",
+		Short: "Get a backup",
+	},
+	func(c *cobra.Command, f *flag.FlagSet) {
+		cargs := &struct {
+			ID string
+		}{}
+		f.StringVarP(&cargs.ID, "id", "i", "", "Identifier of the backup")
 
-				// Connect
-				conn := cmd.MustDialAPI()
-				backupc := backup.NewBackupServiceClient(conn)
-				ctx := cmd.ContextWithToken()
+		c.RunE = func(c *cobra.Command, args []string) error {
+			// Validate arguments
+			id, argsUsed := cmd.OptOption("id", cargs.ID, args, 0)
+			if err := cmd.CheckNumberOfArgs(args, argsUsed); err != nil {
+				return err
+			}
 
-				// Fetch backup
-				b, err := backupc.GetBackup(ctx, &v1.IDOptions{Id: id})
-				if err != nil {
-					log.Fatal().Err(err).Msg("Failed to fetch backup")
-				}
+			// Connect
+			conn, err := cmd.DialAPI()
+			if err != nil {
+				return err
+			}
+			backupc := backup.NewBackupServiceClient(conn)
+			ctx, err := cmd.ContextWithToken()
+			if err != nil {
+				return err
+			}
 
-				// Show result
-				fmt.Println(format.Backup(b, cmd.RootArgs.Format))
+			// Fetch backup
+			b, err := backupc.GetBackup(ctx, &v1.IDOptions{Id: id})
+			if err != nil {
+				return cmd.WrapError(err, "Failed to fetch backup")
 			}
-		},
-	)
-}
+
+			// Show result
+			return cmd.ShowResult(format.Backup(b, cmd.RootArgs.Format))
+		}
+	},
+)
@@ -23,8 +23,6 @@
 package data
 
 import (
-	"fmt"
-
 	"github.com/spf13/cobra"
 	flag "github.com/spf13/pflag"
 
@@ -55,20 +53,31 @@ func init() {
 			f.StringVarP(&cargs.projectID, "project-id", "p", cmd.DefaultProject(), "Identifier of the project")
 			f.BoolVarP(&cargs.showRootPassword, "show-root-password", "", false, "show the root password of the database")
 
-			c.Run = func(c *cobra.Command, args []string) {
+			c.RunE = func(c *cobra.Command, args []string) error {
 				// Validate arguments
 				log := cmd.CLILog
 				deploymentID, argsUsed := cmd.OptOption("deployment-id", cargs.deploymentID, args, 0)
-				cmd.MustCheckNumberOfArgs(args, argsUsed)
+				if err := cmd.CheckNumberOfArgs(args, argsUsed); err != nil {
+					return err
+				}
 
 				// Connect
-				conn := cmd.MustDialAPI()
+				conn, err := cmd.DialAPI()
+				if err != nil {
+					return err
+				}
 				datac := data.NewDataServiceClient(conn)
 				rmc := rm.NewResourceManagerServiceClient(conn)
-				ctx := cmd.ContextWithToken()
+				ctx, err := cmd.ContextWithToken()
+				if err != nil {
+					return err
+				}
 
 				// Fetch deployment
-				item := selection.MustSelectDeployment(ctx, log, deploymentID, cargs.projectID, cargs.organizationID, datac, rmc)
+				item, err := selection.SelectDeployment(ctx, log, deploymentID, cargs.projectID, cargs.organizationID, datac, rmc)
+				if err != nil {
+					return cmd.WrapError(err, "Failed to get deployment")
+				}
 
 				// Fetch credentials if needed
 				var creds *data.DeploymentCredentials
@@ -76,12 +85,12 @@ func init() {
 					var err error
 					creds, err = datac.GetDeploymentCredentials(ctx, &data.DeploymentCredentialsRequest{DeploymentId: deploymentID})
 					if err != nil {
-						log.Fatal().Err(err).Msg("Failed to fetch deployment credentials")
+						return cmd.WrapError(err, "Failed to fetch deployment credentials")
 					}
 				}
 
 				// Show result
-				fmt.Println(format.Deployment(item, creds, cmd.RootArgs.Format, cargs.showRootPassword))
+				return cmd.ShowResult(format.Deployment(item, creds, cmd.RootArgs.Format, cargs.showRootPassword))
 			}
 		},
 	)
@@ -23,8 +23,6 @@
 package data
 
 import (
-	"fmt"
-
 	"github.com/spf13/cobra"
 	flag "github.com/spf13/pflag"
 
@@ -49,38 +47,49 @@ func init() {
 				organizationID string
 			}{}
 			f.StringVarP(&cargs.organizationID, "organization-id", "o", cmd.DefaultOrganization(), "Optional Identifier of the organization")
-			c.Run = func(c *cobra.Command, args []string) {
+			c.RunE = func(c *cobra.Command, args []string) error {
 				// Validate arguments
 				log := cmd.CLILog
-				cmd.MustCheckNumberOfArgs(args, 0)
+				if err := cmd.CheckNumberOfArgs(args, 0); err != nil {
+					return err
+				}
 
 				// Connect
-				conn := cmd.MustDialAPI()
+				conn, err := cmd.DialAPI()
+				if err != nil {
+					return err
+				}
 				datac := data.NewDataServiceClient(conn)
 				rmc := rm.NewResourceManagerServiceClient(conn)
-				ctx := cmd.ContextWithToken()
+				ctx, err := cmd.ContextWithToken()
+				if err != nil {
+					return err
+				}
 
 				var orgID string
 				// Fetch organization
 				if cargs.organizationID != "" {
-					org := selection.MustSelectOrganization(ctx, log, cargs.organizationID, rmc)
+					org, err := selection.SelectOrganization(ctx, log, cargs.organizationID, rmc)
+					if err != nil {
+						return cmd.WrapError(err, "Failed to get organization")
+					}
 					orgID = org.GetId()
 				}
 
 				// Fetch versions
 				list, err := datac.ListVersions(ctx, &data.ListVersionsRequest{OrganizationId: orgID, Options: &common.ListOptions{}})
 				if err != nil {
-					log.Fatal().Err(err).Msg("Failed to list versions")
+					return cmd.WrapError(err, "Failed to list versions")
 				}
 
 				// Fetch default version
 				defaultVersion, err := datac.GetDefaultVersion(ctx, &common.Empty{})
 				if err != nil {
-					log.Fatal().Err(err).Msg("Failed to get default version")
+					return cmd.WrapError(err, "Failed to get default version")
 				}
 
 				// Show result
-				fmt.Println(format.VersionList(list.Items, defaultVersion, cmd.RootArgs.Format))
+				return cmd.ShowResult(format.VersionList(list.Items, defaultVersion, cmd.RootArgs.Format))
 			}
 		},
 	)
@@ -0,0 +1,42 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Ewout Prangsma
+//
+
+package data
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/arangodb-managed/oasisctl/cmd"
+)
+
+var (
+	// listBackupCmd is the parent for `list backup ...` commands
+	listBackupCmd = &cobra.Command{
+		Use:   "backup",
+		Short: "List backup resources",
+		Run:   cmd.ShowUsage,
+	}
+)
+
+func init() {
+	cmd.ListCmd.AddCommand(listBackupCmd)
+}
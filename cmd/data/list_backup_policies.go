@@ -0,0 +1,85 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Ewout Prangsma
+//
+
+package data
+
+import (
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+
+	backup "github.com/arangodb-managed/apis/backup/v1"
+
+	"github.com/arangodb-managed/oasisctl/cmd"
+	"github.com/arangodb-managed/oasisctl/pkg/format"
+)
+
+func init() {
+	cmd.InitCommand(
+		listBackupCmd,
+		&cobra.Command{
+			Use:   "policies",
+			Short: "List backup policies",
+		},
+		func(c *cobra.Command, f *flag.FlagSet) {
+			cargs := &struct {
+				deploymentID   string
+				includeDeleted bool
+			}{}
+			f.StringVar(&cargs.deploymentID, "deployment-id", "", "The ID of the deployment to list backup policies for")
+			f.BoolVar(&cargs.includeDeleted, "include-deleted", false, "Include backup policies that are marked as deleted")
+
+			c.RunE = func(c *cobra.Command, args []string) error {
+				// Validate arguments
+				deploymentID, argsUsed, err := cmd.ReqOption("deployment-id", cargs.deploymentID, args, 0)
+				if err != nil {
+					return err
+				}
+				if err := cmd.CheckNumberOfArgs(args, argsUsed); err != nil {
+					return err
+				}
+
+				// Connect
+				conn, err := cmd.DialAPI()
+				if err != nil {
+					return err
+				}
+				backupc := backup.NewBackupServiceClient(conn)
+				ctx, err := cmd.ContextWithToken()
+				if err != nil {
+					return err
+				}
+
+				// Fetch backup policies
+				list, err := backupc.ListBackupPolicies(ctx, &backup.ListBackupPoliciesRequest{
+					DeploymentId:   deploymentID,
+					IncludeDeleted: cargs.includeDeleted,
+				})
+				if err != nil {
+					return cmd.WrapError(err, "Failed to list backup policies")
+				}
+
+				// Show result
+				return cmd.ShowResult(format.BackupPolicyList(list.Items, cmd.RootArgs.Format))
+			}
+		},
+	)
+}
@@ -23,7 +23,6 @@
 package data
 
 import (
-	"fmt"
 	"time"
 
 	"github.com/gogo/protobuf/types"
@@ -46,64 +45,53 @@ func init() {
 		func(c *cobra.Command, f *flag.FlagSet) {
 			cargs := &struct {
 				deploymentID string
-				from         string
-				to           string
+				from         time.Time
+				to           time.Time
 			}{}
 			f.StringVar(&cargs.deploymentID, "deployment-id", "", "The ID of the deployment to list backups for")
-			f.StringVar(&cargs.from, "from", "", "Request backups that are created at or after this timestamp")
-			f.StringVar(&cargs.to, "to", "", "Request backups that are created before this timestamp")
-			c.Run = func(c *cobra.Command, args []string) {
+			cmd.TimeVar(f, &cargs.from, "from", "Request backups that are created at or after this timestamp")
+			cmd.TimeVar(f, &cargs.to, "to", "Request backups that are created before this timestamp")
+			c.RunE = func(c *cobra.Command, args []string) error {
 				// Validate arguments
-				log := cmd.CLILog
 				deploymentID, argsUsed := cmd.OptOption("deployment-id", cargs.deploymentID, args, 0)
-				cmd.MustCheckNumberOfArgs(args, argsUsed)
+				if err := cmd.CheckNumberOfArgs(args, argsUsed); err != nil {
+					return err
+				}
 
 				// Connect
-				conn := cmd.MustDialAPI()
+				conn, err := cmd.DialAPI()
+				if err != nil {
+					return err
+				}
 				backupc := backup.NewBackupServiceClient(conn)
-				ctx := cmd.ContextWithToken()
+				ctx, err := cmd.ContextWithToken()
+				if err != nil {
+					return err
+				}
 
 				req := backup.ListBackupsRequest{
 					DeploymentId: deploymentID,
 				}
 
-				if len(cargs.from) > 0 {
-					var err error
-					req.From, err = parseTime(cargs.from)
-					if err != nil {
-						log.Fatal().Err(err)
-					}
+				if !cargs.from.IsZero() {
+					req.From, _ = types.TimestampProto(cargs.from)
 				}
-
-				if len(cargs.to) > 0 {
-					var err error
-					req.To, err = parseTime(cargs.to)
-					if err != nil {
-						log.Fatal().Err(err)
-					}
+				if !cargs.to.IsZero() {
+					req.To, _ = types.TimestampProto(cargs.to)
+				}
+				if req.From != nil && req.To != nil && !cargs.from.Before(cargs.to) {
+					return cmd.UsageError("--from must be before --to")
 				}
 
 				// Fetch backups
 				list, err := backupc.ListBackups(ctx, &req)
 				if err != nil {
-					log.Fatal().Err(err).Msg("Failed to list backups")
+					return cmd.WrapError(err, "Failed to list backups")
 				}
 
 				// Show result
-				fmt.Println(format.BackupList(list.Items, cmd.RootArgs.Format))
+				return cmd.ShowResult(format.BackupList(list.Items, cmd.RootArgs.Format))
 			}
 		},
 	)
 }
-
-func parseTime(date string) (*types.Timestamp, error) {
-	d, err := time.Parse(time.RFC3339, date)
-	if err != nil {
-		return nil, err
-	}
-	stamp, err := types.TimestampProto(d)
-	if err != nil {
-		return nil, err
-	}
-	return stamp, nil
-}
@@ -23,8 +23,6 @@
 package data
 
 import (
-	"fmt"
-
 	"github.com/spf13/cobra"
 	flag "github.com/spf13/pflag"
 
@@ -53,31 +51,42 @@ func init() {
 			f.StringVarP(&cargs.organizationID, "organization-id", "o", cmd.DefaultOrganization(), "Identifier of the organization")
 			f.StringVar(&cargs.providerID, "provider-id", cmd.DefaultProvider(), "Identifier of the provider")
 
-			c.Run = func(c *cobra.Command, args []string) {
+			c.RunE = func(c *cobra.Command, args []string) error {
 				// Validate arguments
 				log := cmd.CLILog
 				projectID, argsUsed := cmd.OptOption("project-id", cargs.projectID, args, 1)
-				cmd.MustCheckNumberOfArgs(args, argsUsed)
+				if err := cmd.CheckNumberOfArgs(args, argsUsed); err != nil {
+					return err
+				}
 
 				// Connect
-				conn := cmd.MustDialAPI()
+				conn, err := cmd.DialAPI()
+				if err != nil {
+					return err
+				}
 				datac := data.NewDataServiceClient(conn)
 				rmc := rm.NewResourceManagerServiceClient(conn)
-				ctx := cmd.ContextWithToken()
+				ctx, err := cmd.ContextWithToken()
+				if err != nil {
+					return err
+				}
 
 				// Select project
-				project := selection.MustSelectProject(ctx, log, projectID, cargs.organizationID, rmc)
+				project, err := selection.SelectProject(ctx, log, projectID, cargs.organizationID, rmc)
+				if err != nil {
+					return cmd.WrapError(err, "Failed to get project")
+				}
 
 				// Fetch CPU sizes
 				list, err := datac.ListCPUSizes(ctx, &data.ListCPUSizesRequest{
 					ProjectId: project.GetId(),
 				})
 				if err != nil {
-					log.Fatal().Err(err).Msg("Failed to list CPU sizes")
+					return cmd.WrapError(err, "Failed to list CPU sizes")
 				}
 
 				// Show result
-				fmt.Println(format.CPUSizeList(list.Items, cmd.RootArgs.Format))
+				return cmd.ShowResult(format.CPUSizeList(list.Items, cmd.RootArgs.Format))
 			}
 		},
 	)
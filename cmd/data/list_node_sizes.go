@@ -24,7 +24,6 @@
 package data
 
 import (
-	"fmt"
 	"sort"
 
 	"github.com/spf13/cobra"
@@ -58,25 +57,39 @@ func init() {
 			f.StringVarP(&cargs.organizationID, "organization-id", "o", cmd.DefaultOrganization(), "Identifier of the organization")
 			f.StringVar(&cargs.providerID, "provider-id", cmd.DefaultProvider(), "Identifier of the provider")
 
-			c.Run = func(c *cobra.Command, args []string) {
+			c.RunE = func(c *cobra.Command, args []string) error {
 				// Validate arguments
 				log := cmd.CLILog
 				regionID, argsUsed := cmd.OptOption("region-id", cargs.regionID, args, 0)
 				projectID, argsUsed := cmd.OptOption("project-id", cargs.projectID, args, 1)
-				cmd.MustCheckNumberOfArgs(args, argsUsed)
+				if err := cmd.CheckNumberOfArgs(args, argsUsed); err != nil {
+					return err
+				}
 
 				// Connect
-				conn := cmd.MustDialAPI()
+				conn, err := cmd.DialAPI()
+				if err != nil {
+					return err
+				}
 				datac := data.NewDataServiceClient(conn)
 				platformc := platform.NewPlatformServiceClient(conn)
 				rmc := rm.NewResourceManagerServiceClient(conn)
-				ctx := cmd.ContextWithToken()
+				ctx, err := cmd.ContextWithToken()
+				if err != nil {
+					return err
+				}
 
 				// Select project
-				project := selection.MustSelectProject(ctx, log, projectID, cargs.organizationID, rmc)
+				project, err := selection.SelectProject(ctx, log, projectID, cargs.organizationID, rmc)
+				if err != nil {
+					return cmd.WrapError(err, "Failed to get project")
+				}
 
 				// Selection region
-				region := selection.MustSelectRegion(ctx, log, regionID, cargs.providerID, project.OrganizationId, platformc)
+				region, err := selection.SelectRegion(ctx, log, regionID, cargs.providerID, project.OrganizationId, platformc)
+				if err != nil {
+					return cmd.WrapError(err, "Failed to get region")
+				}
 
 				// Fetch node sizes
 				list, err := datac.ListNodeSizes(ctx, &data.NodeSizesRequest{
@@ -84,7 +97,7 @@ func init() {
 					RegionId:  region.GetId(),
 				})
 				if err != nil {
-					log.Fatal().Err(err).Msg("Failed to list node sizes")
+					return cmd.WrapError(err, "Failed to list node sizes")
 				}
 
 				// Fetch CPU sizes
@@ -92,7 +105,7 @@ func init() {
 					ProjectId: project.GetId(),
 				})
 				if err != nil {
-					log.Fatal().Err(err).Msg("Failed to list CPU sizes")
+					return cmd.WrapError(err, "Failed to list CPU sizes")
 				}
 
 				// Show result
@@ -100,7 +113,7 @@ func init() {
 				sort.Slice(items, func(i, j int) bool {
 					return items[i].GetMemorySize() < items[j].GetMemorySize()
 				})
-				fmt.Println(format.NodeSizeList(items, cpuList.GetItems(), cmd.RootArgs.Format))
+				return cmd.ShowResult(format.NodeSizeList(items, cpuList.GetItems(), cmd.RootArgs.Format))
 			}
 		},
 	)
@@ -23,10 +23,20 @@
 package data
 
 import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
 	"fmt"
 	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
 
 	types "github.com/gogo/protobuf/types"
+	"github.com/rs/zerolog"
 	"github.com/spf13/cobra"
 	flag "github.com/spf13/pflag"
 
@@ -36,7 +46,6 @@ import (
 
 	"github.com/arangodb-managed/oasisctl/cmd"
 	"github.com/arangodb-managed/oasisctl/pkg/selection"
-	"github.com/arangodb-managed/oasisctl/pkg/util"
 )
 
 func init() {
@@ -45,6 +54,11 @@ func init() {
 		&cobra.Command{
 			Use:   "logs",
 			Short: "Get logs of the servers of a deployment the authenticated user has access to",
+			Long: `Get logs of the servers of a deployment the authenticated user has access to.
+
+With --follow, new log lines are shown as they arrive until interrupted (Ctrl-C),
+similar to "tail -f". Lines are fetched from the timestamp of the last received line,
+skipping lines that were already shown.`,
 		},
 		func(c *cobra.Command, f *flag.FlagSet) {
 			cargs := &struct {
@@ -53,34 +67,50 @@ func init() {
 				projectID      string
 				role           string
 				limit          int
-				start          string
-				end            string
+				start          time.Time
+				end            time.Time
 				format         string
+				follow         bool
 			}{}
 			f.StringVarP(&cargs.deploymentID, "deployment-id", "d", cmd.DefaultDeployment(), "Identifier of the deployment")
 			f.StringVarP(&cargs.organizationID, "organization-id", "o", cmd.DefaultOrganization(), "Identifier of the organization")
 			f.StringVarP(&cargs.projectID, "project-id", "p", cmd.DefaultProject(), "Identifier of the project")
 			f.StringVarP(&cargs.role, "role", "r", "", "Limit logs to servers with given role only (agents|coordinators|dbservers)")
 			f.IntVarP(&cargs.limit, "limit", "l", 0, "Limit the number of log lines")
-			f.StringVar(&cargs.start, "start", "", "Start fetching logs from this timestamp (pass timestamp or duration before now)")
-			f.StringVar(&cargs.end, "end", "", "End fetching logs at this timestamp (pass timestamp or duration before now)")
+			cmd.TimeVar(f, &cargs.start, "start", "Start fetching logs from this timestamp")
+			cmd.TimeVar(f, &cargs.end, "end", "End fetching logs at this timestamp")
 			f.StringVar(&cargs.format, "format", "text", "Formatting of the log output. It can be one of two: text, json. Text is the default value.")
+			f.BoolVarP(&cargs.follow, "follow", "f", false, "Keep showing new log lines until interrupted")
 
-			c.Run = func(c *cobra.Command, args []string) {
+			c.RunE = func(c *cobra.Command, args []string) error {
 				// Validate arguments
 				log := cmd.CLILog
 				deploymentID, argsUsed := cmd.OptOption("deployment-id", cargs.deploymentID, args, 0)
-				cmd.MustCheckNumberOfArgs(args, argsUsed)
+				if err := cmd.CheckNumberOfArgs(args, argsUsed); err != nil {
+					return err
+				}
+				if cargs.follow && !cargs.end.IsZero() {
+					return cmd.UsageError("--end cannot be combined with --follow")
+				}
 
 				// Connect
-				conn := cmd.MustDialAPI()
+				conn, err := cmd.DialAPI()
+				if err != nil {
+					return err
+				}
 				datac := data.NewDataServiceClient(conn)
 				rmc := rm.NewResourceManagerServiceClient(conn)
 				monc := mon.NewMonitoringServiceClient(conn)
-				ctx := cmd.ContextWithToken()
+				ctx, err := cmd.ContextWithToken()
+				if err != nil {
+					return err
+				}
 
 				// Fetch deployment
-				item := selection.MustSelectDeployment(ctx, log, deploymentID, cargs.projectID, cargs.organizationID, datac, rmc)
+				item, err := selection.SelectDeployment(ctx, log, deploymentID, cargs.projectID, cargs.organizationID, datac, rmc)
+				if err != nil {
+					return cmd.WrapError(err, "Failed to get deployment")
+				}
 
 				// Fetch logs
 				req := &mon.GetDeploymentLogsRequest{
@@ -89,29 +119,18 @@ func init() {
 					Limit:        int32(cargs.limit),
 					Format:       cargs.format,
 				}
-				if ts := cargs.start; ts != "" {
-					t, err := util.ParseTimeFromNow(ts)
-					if err != nil {
-						log.Fatal().Err(err).Msg("Failed to parse start time")
-					}
-					req.StartAt, err = types.TimestampProto(t)
-					if err != nil {
-						log.Fatal().Err(err).Msg("Failed to encode start time")
-					}
+				if !cargs.start.IsZero() {
+					req.StartAt, _ = types.TimestampProto(cargs.start)
 				}
-				if ts := cargs.end; ts != "" {
-					t, err := util.ParseTimeFromNow(ts)
-					if err != nil {
-						log.Fatal().Err(err).Msg("Failed to parse end time")
-					}
-					req.EndAt, err = types.TimestampProto(t)
-					if err != nil {
-						log.Fatal().Err(err).Msg("Failed to encode end time")
-					}
+				if !cargs.end.IsZero() {
+					req.EndAt, _ = types.TimestampProto(cargs.end)
+				}
+				if cargs.follow {
+					return followDeploymentLogs(ctx, log, monc, req)
 				}
 				client, err := monc.GetDeploymentLogs(ctx, req)
 				if err != nil {
-					log.Fatal().Err(err).Msg("Failed to fetch deployment logs")
+					return cmd.WrapError(err, "Failed to fetch deployment logs")
 				}
 
 				// Show logs
@@ -121,11 +140,188 @@ func init() {
 						// All done
 						break
 					} else if err != nil {
-						log.Fatal().Err(err).Msg("Failed to next deployment logs chunk")
+						return cmd.WrapError(err, "Failed to next deployment logs chunk")
 					}
 					fmt.Print(string(msg.GetChunk()))
 				}
+				return nil
 			}
 		},
 	)
 }
+
+// followDeploymentLogs shows the logs of a deployment and keeps polling
+// for new log lines until interrupted.
+// Every poll fetches the lines from the timestamp of the last received line,
+// see logLineDeduplicator for how lines that were already shown are skipped.
+// Polls that fail because the API is unavailable or does not respond in time
+// are retried with a growing interval, other errors are returned.
+func followDeploymentLogs(ctx context.Context, log zerolog.Logger, monc mon.MonitoringServiceClient, req *mon.GetDeploymentLogsRequest) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sigs)
+	go func() {
+		select {
+		case <-sigs:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	dedup := newLogLineDeduplicator()
+	show := func(line string) {
+		if dedup.add(line) {
+			fmt.Println(line)
+		}
+	}
+	for {
+		err := pollUntil(ctx, 0, func() (bool, error) {
+			pollStarted := time.Now()
+			err := readDeploymentLogLines(ctx, monc, req, show)
+			if ctx.Err() != nil {
+				return false, ctx.Err()
+			} else if err != nil && cmd.IsRetryable(ctx, err) {
+				log.Warn().Err(err).Msg("Failed to fetch deployment logs, retrying")
+				return false, nil
+			} else if err != nil {
+				return false, err
+			}
+
+			// Continue from the last received line.
+			// The limit only applies to the initial lines.
+			req.StartAt, _ = types.TimestampProto(dedup.next(pollStarted))
+			req.Limit = 0
+			return true, nil
+		})
+		if ctx.Err() != nil {
+			// Interrupted
+			return nil
+		} else if err != nil {
+			return cmd.WrapError(err, "Failed to fetch deployment logs")
+		}
+
+		// Wait a bit
+		select {
+		case <-time.After(minWaitPollInterval):
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// logLineDeduplicator decides which lines of repeated polls for the logs
+// of a deployment have not been shown yet.
+// It assumes that every poll fetches the lines from the latest timestamp
+// of the previous polls (inclusive), so only lines with exactly that timestamp
+// can be fetched again. Lines within a single poll may be out of order
+// (e.g. when they come from multiple servers).
+// Lines without a timestamp cannot be ordered, they are skipped when they
+// were also fetched by the previous poll. As long as no line with a timestamp
+// has been fetched, every poll starts at the time the previous poll started,
+// so the same lines are not fetched over and over again.
+// Only the lines of the previous and current poll are remembered.
+type logLineDeduplicator struct {
+	// Latest timestamp of the lines of previous polls
+	last time.Time
+	// Lines of the previous poll that can be fetched again
+	// (with timestamp last or without timestamp)
+	previous map[string]struct{}
+	// Lines of the current poll with their timestamp (zero if unknown)
+	current map[string]time.Time
+}
+
+// newLogLineDeduplicator creates a new logLineDeduplicator.
+func newLogLineDeduplicator() *logLineDeduplicator {
+	return &logLineDeduplicator{
+		previous: make(map[string]struct{}),
+		current:  make(map[string]time.Time),
+	}
+}
+
+// add adds a line of the current poll and returns true if it must be shown.
+func (d *logLineDeduplicator) add(line string) bool {
+	ts, found := logLineTime(line)
+	if found && ts.Before(d.last) {
+		// Shown by a previous poll, or outside of the requested range
+		return false
+	}
+	if _, found := d.current[line]; found {
+		return false
+	}
+	d.current[line] = ts
+	_, shown := d.previous[line]
+	return !shown
+}
+
+// next finishes the current poll that started at the given time and
+// returns the timestamp from which the next poll must fetch lines.
+func (d *logLineDeduplicator) next(pollStarted time.Time) time.Time {
+	for _, ts := range d.current {
+		if ts.After(d.last) {
+			d.last = ts
+		}
+	}
+	d.previous = make(map[string]struct{})
+	for line, ts := range d.current {
+		if ts.IsZero() || ts.Equal(d.last) {
+			d.previous[line] = struct{}{}
+		}
+	}
+	d.current = make(map[string]time.Time)
+	if d.last.IsZero() {
+		// No timestamps so far
+		return pollStarted
+	}
+	return d.last
+}
+
+// readDeploymentLogLines fetches the logs of a deployment and calls
+// the given function for every line.
+func readDeploymentLogLines(ctx context.Context, monc mon.MonitoringServiceClient, req *mon.GetDeploymentLogsRequest, handle func(string)) error {
+	client, err := monc.GetDeploymentLogs(ctx, req)
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	for {
+		msg, err := client.Recv()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+		buf.Write(msg.GetChunk())
+	}
+	scanner := bufio.NewScanner(&buf)
+	scanner.Buffer(nil, 1024*1024)
+	for scanner.Scan() {
+		if line := scanner.Text(); line != "" {
+			handle(line)
+		}
+	}
+	return scanner.Err()
+}
+
+// logLineTime returns the timestamp of the given log line.
+// Text lines start with their timestamp, json lines have a "time" field.
+func logLineTime(line string) (time.Time, bool) {
+	value := line
+	if strings.HasPrefix(line, "{") {
+		var obj struct {
+			Time string `json:"time"`
+		}
+		if err := json.Unmarshal([]byte(line), &obj); err != nil {
+			return time.Time{}, false
+		}
+		value = obj.Time
+	} else if i := strings.IndexAny(line, " \t"); i > 0 {
+		value = line[:i]
+	}
+	ts, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return ts, true
+}
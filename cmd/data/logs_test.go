@@ -0,0 +1,106 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Ewout Prangsma
+//
+
+package data
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestLogLineTime(t *testing.T) {
+	expected := time.Date(2020, 5, 1, 12, 0, 0, 123000000, time.UTC)
+	tests := []struct {
+		line  string
+		found bool
+	}{
+		{"2020-05-01T12:00:00.123Z INFO [abc] Server started", true},
+		{"2020-05-01T12:00:00.123Z\tINFO tab separated", true},
+		{"2020-05-01T12:00:00.123Z", true},
+		{`{"time":"2020-05-01T12:00:00.123Z","message":"Server started"}`, true},
+		{"INFO [abc] no timestamp", false},
+		{"2020-05-01 12:00:00 INFO not RFC3339", false},
+		{`{"message":"no time field"}`, false},
+		{`{"time":"yesterday"}`, false},
+		{`{not json`, false},
+		{"", false},
+	}
+	for _, test := range tests {
+		ts, found := logLineTime(test.line)
+		if found != test.found || (found && !ts.Equal(expected)) {
+			t.Errorf("logLineTime(%q) = %s, %v; expected found=%v", test.line, ts, found, test.found)
+		}
+	}
+}
+
+func TestLogLineDeduplicator(t *testing.T) {
+	pollStarted := time.Date(2020, 5, 1, 13, 0, 0, 0, time.UTC)
+	d := newLogLineDeduplicator()
+	poll := func(lines ...string) []string {
+		var shown []string
+		for _, line := range lines {
+			if d.add(line) {
+				shown = append(shown, line)
+			}
+		}
+		return shown
+	}
+	expectPoll := func(lines, expected []string, expectedNext time.Time) {
+		t.Helper()
+		if shown := poll(lines...); !reflect.DeepEqual(shown, expected) {
+			t.Errorf("Expected %q to be shown, got %q", expected, shown)
+		}
+		if next := d.next(pollStarted); !next.Equal(expectedNext) {
+			t.Errorf("Expected next poll to start at %s, got %s", expectedNext, next)
+		}
+	}
+	ts := func(minutes int) time.Time {
+		return time.Date(2020, 5, 1, 12, minutes, 0, 0, time.UTC)
+	}
+	line := func(minutes int, msg string) string {
+		return ts(minutes).Format(time.RFC3339Nano) + " " + msg
+	}
+
+	// Without timestamps, polls continue from the time the poll started
+	expectPoll([]string{"starting", "unparseable"}, []string{"starting", "unparseable"}, pollStarted)
+	expectPoll([]string{"unparseable", "new"}, []string{"new"}, pollStarted)
+
+	// Lines out of order within a poll are all shown
+	expectPoll(
+		[]string{line(2, "b"), line(1, "a"), line(3, "c"), line(3, "d"), "stack trace"},
+		[]string{line(2, "b"), line(1, "a"), line(3, "c"), line(3, "d"), "stack trace"},
+		ts(3))
+
+	// Lines with the last timestamp are fetched again
+	expectPoll(
+		[]string{line(3, "c"), line(3, "d"), line(3, "e"), "stack trace", line(1, "late")},
+		[]string{line(3, "e")},
+		ts(3))
+	expectPoll([]string{line(3, "c"), line(3, "d"), line(3, "e"), line(4, "f")}, []string{line(4, "f")}, ts(4))
+
+	// Only lines of the previous poll that can be fetched again are remembered
+	expectPoll([]string{line(4, "f"), line(5, "g"), line(5, "h")}, []string{line(5, "g"), line(5, "h")}, ts(5))
+	if expected := map[string]struct{}{line(5, "g"): {}, line(5, "h"): {}}; !reflect.DeepEqual(d.previous, expected) {
+		t.Errorf("Expected only lines of the last timestamp to be remembered, got %v", d.previous)
+	}
+}
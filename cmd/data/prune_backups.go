@@ -0,0 +1,273 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Ewout Prangsma
+//
+
+package data
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+
+	backup "github.com/arangodb-managed/apis/backup/v1"
+	common "github.com/arangodb-managed/apis/common/v1"
+
+	"github.com/arangodb-managed/oasisctl/cmd"
+	"github.com/arangodb-managed/oasisctl/pkg/format"
+	"github.com/arangodb-managed/oasisctl/pkg/util"
+)
+
+// backupRetention specifies which backups to keep when pruning.
+type backupRetention struct {
+	keepLast    int
+	keepDaily   int
+	keepWeekly  int
+	keepMonthly int
+	olderThan   time.Duration
+	// Expiration time of pruned backups, zero means delete
+	expireAt time.Time
+}
+
+func init() {
+	cmd.InitCommand(
+		cmd.PruneCmd,
+		&cobra.Command{
+			Use:   "backups",
+			Short: "Prune backups of a deployment",
+			Long: `Prune backups of a deployment using a grandfather-father-son retention scheme.
+
+Backups are kept when they match any of the --keep-* options or are newer than --older-than.
+For --keep-daily, --keep-weekly and --keep-monthly, the newest backup of each of the
+latest N days, (ISO) weeks or months (in UTC) is kept.
+All other backups are deleted, or with --auto-delete-after, scheduled for automatic deletion.
+Backups created by a backup policy are not pruned unless --include-policy-backups is set.
+
+By default only the plan is shown (--dry-run). Use --dry-run=false to prune.`,
+			Example: `  oasisctl prune backups --deployment-id <id> --keep-last 10 --keep-daily 7 --older-than 30d
+  oasisctl prune backups --deployment-id <id> --keep-weekly 4 --keep-monthly 6 --dry-run=false --yes`,
+		},
+		func(c *cobra.Command, f *flag.FlagSet) {
+			cargs := &struct {
+				deploymentID         string
+				keepLast             int
+				keepDaily            int
+				keepWeekly           int
+				keepMonthly          int
+				olderThan            time.Duration
+				autoDeleteAfter      time.Duration
+				includePolicyBackups bool
+				dryRun               bool
+				yes                  bool
+			}{}
+			f.StringVarP(&cargs.deploymentID, "deployment-id", "d", cmd.DefaultDeployment(), "Identifier of the deployment")
+			f.IntVar(&cargs.keepLast, "keep-last", 0, "Keep the last N backups")
+			f.IntVar(&cargs.keepDaily, "keep-daily", 0, "Keep the last backup of each of the last N days")
+			f.IntVar(&cargs.keepWeekly, "keep-weekly", 0, "Keep the last backup of each of the last N weeks")
+			f.IntVar(&cargs.keepMonthly, "keep-monthly", 0, "Keep the last backup of each of the last N months")
+			cmd.DurationVar(f, &cargs.olderThan, "older-than", 0, "Only prune backups older than this duration (e.g. 30d)")
+			cmd.DurationVar(f, &cargs.autoDeleteAfter, "auto-delete-after", 0, "Schedule pruned backups for automatic deletion after this duration (e.g. 24h), instead of deleting them")
+			f.BoolVar(&cargs.includePolicyBackups, "include-policy-backups", false, "Also prune backups created by a backup policy")
+			f.BoolVar(&cargs.dryRun, "dry-run", true, "Only show which backups would be pruned")
+			f.BoolVarP(&cargs.yes, "yes", "y", false, "Prune without asking for confirmation")
+
+			c.RunE = func(c *cobra.Command, args []string) error {
+				// Validate arguments
+				log := cmd.CLILog
+				deploymentID, argsUsed, err := cmd.ReqOption("deployment-id", cargs.deploymentID, args, 0)
+				if err != nil {
+					return err
+				}
+				if err := cmd.CheckNumberOfArgs(args, argsUsed); err != nil {
+					return err
+				}
+				r := backupRetention{
+					keepLast:    cargs.keepLast,
+					keepDaily:   cargs.keepDaily,
+					keepWeekly:  cargs.keepWeekly,
+					keepMonthly: cargs.keepMonthly,
+					olderThan:   cargs.olderThan,
+				}
+				if r.keepLast < 0 || r.keepDaily < 0 || r.keepWeekly < 0 || r.keepMonthly < 0 {
+					return cmd.UsageError("--keep-* options must not be negative")
+				}
+				if r.olderThan < 0 {
+					return cmd.UsageError("Invalid --older-than: must not be negative")
+				}
+				if r.keepLast+r.keepDaily+r.keepWeekly+r.keepMonthly == 0 && r.olderThan == 0 {
+					return cmd.UsageError("Specify at least one of --keep-last, --keep-daily, --keep-weekly, --keep-monthly or --older-than")
+				}
+				now := time.Now()
+				if c.Flags().Changed("auto-delete-after") {
+					if cargs.autoDeleteAfter <= 0 {
+						return cmd.UsageError("Invalid --auto-delete-after: must be positive")
+					}
+					r.expireAt = now.Add(cargs.autoDeleteAfter)
+				}
+
+				// Connect
+				conn, err := cmd.DialAPI()
+				if err != nil {
+					return err
+				}
+				backupc := backup.NewBackupServiceClient(conn)
+				ctx, err := cmd.ContextWithToken()
+				if err != nil {
+					return err
+				}
+
+				// Fetch backups
+				list, err := backupc.ListBackups(ctx, &backup.ListBackupsRequest{DeploymentId: deploymentID})
+				if err != nil {
+					return cmd.WrapError(err, "Failed to list backups")
+				}
+				var backups []*backup.Backup
+				for _, x := range list.GetItems() {
+					if x.GetIsDeleted() {
+						continue
+					}
+					if x.GetBackupPolicyId() != "" && !cargs.includePolicyBackups {
+						log.Debug().Str("backup", x.GetId()).Msg("Skipping backup created by backup policy")
+						continue
+					}
+					backups = append(backups, x)
+				}
+
+				// Create plan
+				plan := planBackupPrune(backups, r, now)
+				pruneCount := 0
+				for _, x := range plan {
+					if len(x.Reasons) == 0 {
+						pruneCount++
+					}
+				}
+				if cargs.dryRun || pruneCount == 0 {
+					if err := cmd.ShowResult(format.BackupPrunePlan(plan, cmd.RootArgs.Format)); err != nil {
+						return err
+					}
+					if cargs.dryRun && pruneCount > 0 {
+						log.Info().Msgf("Dry run: %d of %d backup(s) would be pruned. Use --dry-run=false to prune.", pruneCount, len(plan))
+					}
+					return nil
+				}
+
+				// Confirm
+				verb := "Delete"
+				if !r.expireAt.IsZero() {
+					verb = "Schedule automatic deletion of"
+				}
+				question := fmt.Sprintf("%s %d of %d backup(s) of deployment '%s'?", verb, pruneCount, len(plan), deploymentID)
+				if err := cmd.Confirm(question, cargs.yes); err != nil {
+					return err
+				}
+
+				// Prune backups
+				for i, x := range plan {
+					if len(x.Reasons) > 0 {
+						continue
+					}
+					if r.expireAt.IsZero() {
+						if _, err := backupc.DeleteBackup(ctx, &common.IDOptions{Id: x.Backup.GetId()}); err != nil {
+							return cmd.WrapError(err, "Failed to delete backup '%s'", x.Backup.GetId())
+						}
+						plan[i].Action = "deleted"
+					} else {
+						x.Backup.AutoDeletedAt, _ = types.TimestampProto(r.expireAt)
+						updated, err := backupc.UpdateBackup(ctx, x.Backup)
+						if err != nil {
+							return cmd.WrapError(err, "Failed to update backup '%s'", x.Backup.GetId())
+						}
+						plan[i].Backup = updated
+						plan[i].Action = "expired"
+					}
+					log.Debug().Str("backup", x.Backup.GetId()).Str("action", plan[i].Action).Msg("Pruned backup")
+				}
+
+				// Show result
+				return cmd.ShowResult(format.BackupPrunePlan(plan, cmd.RootArgs.Format))
+			}
+		},
+	)
+}
+
+// planBackupPrune decides which of the given backups to keep according to the given retention.
+// Returns all backups, newest first, with the reasons for keeping them.
+func planBackupPrune(backups []*backup.Backup, r backupRetention, now time.Time) []format.BackupPruneItem {
+	plan := make([]format.BackupPruneItem, 0, len(backups))
+	for _, x := range backups {
+		plan = append(plan, format.BackupPruneItem{Backup: x})
+	}
+	createdAt := func(i int) time.Time {
+		t, _ := types.TimestampFromProto(plan[i].Backup.GetCreatedAt())
+		return t.UTC()
+	}
+	sort.SliceStable(plan, func(i, j int) bool { return createdAt(i).After(createdAt(j)) })
+
+	// keep adds the given reason to the newest backup of each of the first n buckets.
+	keep := func(reason string, n int, bucket func(time.Time) string) {
+		seen := make(map[string]struct{})
+		for i := range plan {
+			if len(seen) >= n {
+				return
+			}
+			key := bucket(createdAt(i))
+			if _, found := seen[key]; !found {
+				seen[key] = struct{}{}
+				plan[i].Reasons = append(plan[i].Reasons, reason)
+			}
+		}
+	}
+	for i := 0; i < r.keepLast && i < len(plan); i++ {
+		plan[i].Reasons = append(plan[i].Reasons, "last")
+	}
+	keep("daily", r.keepDaily, func(t time.Time) string { return t.Format("2006-01-02") })
+	keep("weekly", r.keepWeekly, func(t time.Time) string {
+		year, week := t.ISOWeek()
+		return fmt.Sprintf("%d-W%02d", year, week)
+	})
+	keep("monthly", r.keepMonthly, func(t time.Time) string { return t.Format("2006-01") })
+
+	for i := range plan {
+		x := &plan[i]
+		if r.olderThan > 0 && now.Sub(createdAt(i)) < r.olderThan {
+			x.Reasons = append(x.Reasons, "newer than "+util.FormatDuration(r.olderThan))
+		}
+		if !r.expireAt.IsZero() {
+			if autoDeletedAt := x.Backup.GetAutoDeletedAt(); autoDeletedAt != nil {
+				if t, _ := types.TimestampFromProto(autoDeletedAt); !t.After(r.expireAt) {
+					x.Reasons = append(x.Reasons, "expires already")
+				}
+			}
+		}
+		switch {
+		case len(x.Reasons) > 0:
+			x.Action = "keep"
+		case r.expireAt.IsZero():
+			x.Action = "delete"
+		default:
+			x.Action = "expire"
+		}
+	}
+	return plan
+}
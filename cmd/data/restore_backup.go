@@ -0,0 +1,192 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Gergely Brautigam
+//
+
+package data
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/gogo/protobuf/types"
+	"github.com/rs/zerolog"
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+
+	backup "github.com/arangodb-managed/apis/backup/v1"
+	common "github.com/arangodb-managed/apis/common/v1"
+	data "github.com/arangodb-managed/apis/data/v1"
+
+	"github.com/arangodb-managed/oasisctl/cmd"
+	"github.com/arangodb-managed/oasisctl/pkg/selection"
+)
+
+func init() {
+	cmd.InitCommand(
+		cmd.RestoreCmd,
+		&cobra.Command{
+			Use:   "backup",
+			Short: "Restore a backup into its deployment",
+			Long: `Restore a backup into the deployment it was created from.
+All data in the deployment is replaced by the data of the backup.`,
+		},
+		func(c *cobra.Command, f *flag.FlagSet) {
+			cargs := &struct {
+				ID          string
+				yes         bool
+				wait        bool
+				waitTimeout time.Duration
+			}{}
+			f.StringVarP(&cargs.ID, "id", "i", "", "Identifier of the backup")
+			f.BoolVarP(&cargs.yes, "yes", "y", false, "Restore without asking for confirmation")
+			f.BoolVar(&cargs.wait, "wait", false, "Wait until the backup has been restored and the deployment is ready")
+			cmd.DurationVar(f, &cargs.waitTimeout, "wait-timeout", defaultWaitDeploymentTimeout, "How long to wait for the restore to complete (0 for no limit)")
+
+			c.RunE = func(c *cobra.Command, args []string) error {
+				// Validate arguments
+				log := cmd.CLILog
+				id, argsUsed, err := cmd.ReqOption("id", cargs.ID, args, 0)
+				if err != nil {
+					return err
+				}
+				if err := cmd.CheckNumberOfArgs(args, argsUsed); err != nil {
+					return err
+				}
+
+				// Connect
+				conn, err := cmd.DialAPI()
+				if err != nil {
+					return err
+				}
+				backupc := backup.NewBackupServiceClient(conn)
+				datac := data.NewDataServiceClient(conn)
+				ctx, err := cmd.ContextWithToken()
+				if err != nil {
+					return err
+				}
+
+				// Fetch backup & deployment
+				item, err := selection.SelectBackup(ctx, log, id, backupc)
+				if err != nil {
+					return cmd.WrapError(err, "Failed to get backup")
+				}
+				depl, err := datac.GetDeployment(ctx, &common.IDOptions{Id: item.GetDeploymentId()})
+				if err != nil {
+					return cmd.WrapError(err, "Failed to get deployment")
+				}
+
+				// Check that the backup can be restored into the deployment
+				status := item.GetStatus()
+				if status.GetUploadOnly() {
+					return cmd.PreconditionError("Backup '%s' is only available in the storage it was uploaded to, download it first using 'oasisctl download backup --id %s'", item.GetName(), item.GetId())
+				}
+				if status.GetIsFailed() || !status.GetAvailable() {
+					return cmd.PreconditionError("Backup '%s' is not available for restore (state %s)", item.GetName(), status.GetState())
+				}
+				info := item.GetDeploymentInfo()
+				backupDBServers := dbServerCount(info.GetServers(), info.GetModel())
+				if backupDBServers == 0 {
+					return cmd.PreconditionError("Backup '%s' has no recorded number of DB-Servers, so it cannot be checked against deployment '%s'", item.GetName(), depl.GetName())
+				}
+				warning := ""
+				if deplDBServers := dbServerCount(depl.GetServers(), depl.GetModel()); deplDBServers == 0 {
+					log.Warn().Str("deployment", depl.GetId()).Msg("Deployment has no known number of DB-Servers, skipping DB-Server check")
+					warning = fmt.Sprintf("\nWarning: the number of DB-Servers of the deployment is unknown, so it cannot be checked against the %d DB-Servers of the backup.", backupDBServers)
+				} else if backupDBServers != deplDBServers {
+					return cmd.PreconditionError("Backup was created with %d DB-Servers, but deployment '%s' has %d DB-Servers", backupDBServers, depl.GetName(), deplDBServers)
+				}
+
+				// Confirm
+				createdAt, _ := types.TimestampFromProto(item.GetCreatedAt())
+				question := fmt.Sprintf("Restore backup '%s' (created %s) into deployment '%s' (%s)? This replaces all data in the deployment.%s",
+					item.GetName(), humanize.Time(createdAt), depl.GetName(), depl.GetId(), warning)
+				if err := cmd.Confirm(question, cargs.yes); err != nil {
+					return err
+				}
+
+				// Restore backup
+				if _, err := backupc.RestoreBackup(ctx, &common.IDOptions{Id: item.GetId()}); err != nil {
+					return cmd.WrapError(err, "Failed to restore backup")
+				}
+				if !cargs.wait {
+					fmt.Println("Backup restore started successfully!")
+					return nil
+				}
+
+				// Wait for the restore to complete
+				if err := waitForRestore(ctx, log, datac, depl.GetId(), cargs.waitTimeout); err != nil {
+					return err
+				}
+
+				// Show result
+				fmt.Println("Backup restored successfully!")
+				return nil
+			}
+		},
+	)
+}
+
+// dbServerCount returns the number of DB-Servers of the given servers
+// specification, or of the given model when there is no servers specification.
+// Returns 0 when the number is unknown.
+func dbServerCount(servers *data.Deployment_ServersSpec, model *data.Deployment_ModelSpec) int32 {
+	if servers != nil {
+		return servers.GetDbservers()
+	}
+	if model.GetModel() == data.ModelFlexible {
+		return 0
+	}
+	return model.GetNodeCount()
+}
+
+// waitForRestore waits until the deployment with given ID has restored its
+// latest backup and is ready.
+func waitForRestore(ctx context.Context, log zerolog.Logger, datac data.DataServiceClient, deploymentID string, timeout time.Duration) error {
+	err := pollUntil(ctx, timeout, func() (bool, error) {
+		depl, err := datac.GetDeployment(ctx, &common.IDOptions{Id: deploymentID})
+		if err != nil {
+			return false, cmd.WrapError(err, "Failed to get deployment")
+		}
+		status := depl.GetStatus()
+		restoreStatus := status.GetBackupRestoreStatus()
+		if restoreStatus.GetRevision() >= depl.GetBackupRestore().GetRevision() && !restoreStatus.GetRestoring() {
+			switch restoreStatus.GetStatus() {
+			case "Failed":
+				return false, fmt.Errorf("Restore of backup failed: %s", restoreStatus.GetFailureReason())
+			case "Restored":
+				if status.GetReady() {
+					return true, nil
+				}
+			}
+		}
+		log.Info().
+			Str("restore-status", restoreStatus.GetStatus()).
+			Str("status", status.GetDescription()).
+			Msg("Waiting for backup restore")
+		return false, nil
+	})
+	if err == errPollTimeout {
+		return fmt.Errorf("Backup not restored after %s", timeout)
+	}
+	return err
+}
@@ -37,85 +37,96 @@ import (
 	"github.com/arangodb-managed/oasisctl/pkg/selection"
 )
 
-func init() {
-	cmd.InitCommand(
-		cmd.UpdateCmd,
-		&cobra.Command{
-			Use:   "backup",
-			Short: "Update a backup",
-		},
-		func(c *cobra.Command, f *flag.FlagSet) {
-			cargs := &struct {
-				backupID      string
-				name          string
-				description   string
-				autoDeletedAt int
-				upload        bool
-			}{}
-			f.StringVarP(&cargs.backupID, "backup-id", "d", "", "Identifier of the backup")
-			f.StringVar(&cargs.name, "name", "", "Name of the backup")
-			f.StringVar(&cargs.description, "description", "", "Description of the backup")
-			f.BoolVar(&cargs.upload, "upload", false, "The backups should be uploaded")
-			f.IntVar(&cargs.autoDeletedAt, "auto-deleted-at", 0, "Time (h) until auto delete of the backup")
+var updateBackupCmd = cmd.InitCommand(
+	cmd.UpdateCmd,
+	&cobra.Command{
+		Use:   "backup",
+		Short: "Update a backup",
+	},
+	func(c *cobra.Command, f *flag.FlagSet) {
+		cargs := &struct {
+			backupID      string
+			name          string
+			description   string
+			autoDeletedAt time.Time
+			upload        bool
+		}{}
+		f.StringVarP(&cargs.backupID, "backup-id", "d", "", "Identifier of the backup")
+		f.StringVar(&cargs.name, "name", "", "Name of the backup")
+		f.StringVar(&cargs.description, "description", "", "Description of the backup")
+		f.BoolVar(&cargs.upload, "upload", false, "The backups should be uploaded")
+		cmd.FutureTimeVar(f, &cargs.autoDeletedAt, "auto-deleted-at", "Time of automatic deletion of the backup")
 
-			c.Run = func(c *cobra.Command, args []string) {
-				// Validate arguments
-				log := cmd.CLILog
-				backupID, argsUsed := cmd.OptOption("backup-id", cargs.backupID, args, 0)
-				cmd.MustCheckNumberOfArgs(args, argsUsed)
-
-				// Connect
-				conn := cmd.MustDialAPI()
-				backupc := backup.NewBackupServiceClient(conn)
-				ctx := cmd.ContextWithToken()
+		c.RunE = func(c *cobra.Command, args []string) error {
+			// Validate arguments
+			log := cmd.CLILog
+			backupID, argsUsed := cmd.OptOption("backup-id", cargs.backupID, args, 0)
+			if err := cmd.CheckNumberOfArgs(args, argsUsed); err != nil {
+				return err
+			}
 
-				// Select a backup to update
-				item := selection.MustSelectBackup(ctx, log, backupID, backupc)
+			// Connect
+			conn, err := cmd.DialAPI()
+			if err != nil {
+				return err
+			}
+			backupc := backup.NewBackupServiceClient(conn)
+			ctx, err := cmd.ContextWithToken()
+			if err != nil {
+				return err
+			}
 
-				// Set changes
-				f := c.Flags()
-				hasChanges := false
-				if f.Changed("name") {
-					item.Name = cargs.name
-					hasChanges = true
-				}
-				if f.Changed("description") {
-					item.Description = cargs.description
-					hasChanges = true
-				}
-				if f.Changed("upload") {
-					item.Upload = cargs.upload
-					hasChanges = true
-				}
-				if !item.Upload && cargs.autoDeletedAt == 0 {
-					cargs.autoDeletedAt = 6
-					f.AddFlag(&flag.Flag{Name: "auto-deleted-at", Changed: true})
-				}
-				if f.Changed("auto-deleted-at") {
-					t := time.Now().Add(time.Duration(cargs.autoDeletedAt) * time.Hour)
-					tp, err := types.TimestampProto(t)
-					if err != nil {
-						log.Fatal().Err(err).Msg("Failed to convert from time to proto time")
-					}
-					item.AutoDeletedAt = tp
-					hasChanges = true
-				}
+			// Select a backup to update
+			item, err := selection.SelectBackup(ctx, log, backupID, backupc)
+			if err != nil {
+				return cmd.WrapError(err, "Failed to get backup")
+			}
 
-				if !hasChanges {
-					fmt.Println("No changes")
-					return
+			// Set changes
+			f := c.Flags()
+			hasChanges := false
+			if f.Changed("name") {
+				item.Name = cargs.name
+				hasChanges = true
+			}
+			if f.Changed("description") {
+				item.Description = cargs.description
+				hasChanges = true
+			}
+			if f.Changed("upload") {
+				item.Upload = cargs.upload
+				hasChanges = true
+			}
+			autoDeletedAt := cargs.autoDeletedAt
+			if !item.Upload && item.AutoDeletedAt == nil && autoDeletedAt.IsZero() {
+				autoDeletedAt = time.Now().Add(defaultBackupAutoDeleteAfter)
+			}
+			if !autoDeletedAt.IsZero() {
+				if !autoDeletedAt.After(time.Now()) {
+					return cmd.UsageError("--auto-deleted-at must be in the future")
 				}
-
-				// Update backup
-				updated, err := backupc.UpdateBackup(ctx, item)
+				tp, err := types.TimestampProto(autoDeletedAt)
 				if err != nil {
-					log.Fatal().Err(err).Msg("Failed to update backup")
+					return cmd.WrapError(err, "Failed to convert from time to proto time")
 				}
+				item.AutoDeletedAt = tp
+				hasChanges = true
+			}
+
+			if !hasChanges {
+				fmt.Println("No changes")
+				return nil
+			}
 
-				// Show result
-				fmt.Println("Updated backup!")
-				fmt.Println(format.Backup(updated, cmd.RootArgs.Format))
+			// Update backup
+			updated, err := backupc.UpdateBackup(ctx, item)
+			if err != nil {
+				return cmd.WrapError(err, "Failed to update backup")
 			}
-		},
-	)
-}
+
+			// Show result
+			fmt.Println("Updated backup!")
+			return cmd.ShowResult(format.Backup(updated, cmd.RootArgs.Format))
+		}
+	},
+)
@@ -0,0 +1,114 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Ewout Prangsma
+//
+
+package data
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+
+	backup "github.com/arangodb-managed/apis/backup/v1"
+
+	"github.com/arangodb-managed/oasisctl/cmd"
+	"github.com/arangodb-managed/oasisctl/pkg/format"
+	"github.com/arangodb-managed/oasisctl/pkg/selection"
+)
+
+func init() {
+	cmd.InitCommand(
+		updateBackupCmd,
+		&cobra.Command{
+			Use:   "policy",
+			Short: "Update a backup policy",
+			Example: `  oasisctl update backup policy --id <id> --paused
+  oasisctl update backup policy --id nightly --deployment-id <id> --days mon,wed,fri --time 03:00`,
+		},
+		func(c *cobra.Command, f *flag.FlagSet) {
+			cargs := &struct {
+				ID           string
+				deploymentID string
+				name         string
+				policy       backupPolicyArgs
+			}{}
+			f.StringVarP(&cargs.ID, "id", "i", "", "Identifier or name of the backup policy")
+			f.StringVar(&cargs.deploymentID, "deployment-id", "", "ID of the deployment (required to select a backup policy by name)")
+			f.StringVar(&cargs.name, "name", "", "Name of the backup policy")
+			cargs.policy.addFlags(f)
+
+			c.RunE = func(c *cobra.Command, args []string) error {
+				// Validate arguments
+				log := cmd.CLILog
+				id, argsUsed := cmd.OptOption("id", cargs.ID, args, 0)
+				if err := cmd.CheckNumberOfArgs(args, argsUsed); err != nil {
+					return err
+				}
+
+				// Connect
+				conn, err := cmd.DialAPI()
+				if err != nil {
+					return err
+				}
+				backupc := backup.NewBackupServiceClient(conn)
+				ctx, err := cmd.ContextWithToken()
+				if err != nil {
+					return err
+				}
+
+				// Select a backup policy to update
+				item, err := selection.SelectBackupPolicy(ctx, log, id, cargs.deploymentID, backupc)
+				if err != nil {
+					return cmd.WrapError(err, "Failed to get backup policy")
+				}
+
+				// Set changes
+				f := c.Flags()
+				hasChanges := false
+				if f.Changed("name") {
+					item.Name = cargs.name
+					hasChanges = true
+				}
+				changed, err := cargs.policy.apply(f, item)
+				if err != nil {
+					return err
+				}
+				hasChanges = hasChanges || changed
+
+				if !hasChanges {
+					fmt.Println("No changes")
+					return nil
+				}
+
+				// Update backup policy
+				updated, err := backupc.UpdateBackupPolicy(ctx, item)
+				if err != nil {
+					return cmd.WrapError(err, "Failed to update backup policy")
+				}
+
+				// Show result
+				fmt.Println("Updated backup policy!")
+				return cmd.ShowResult(format.BackupPolicy(updated, cmd.RootArgs.Format))
+			}
+		},
+	)
+}
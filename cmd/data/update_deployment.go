@@ -25,6 +25,7 @@ package data
 import (
 	"fmt"
 
+	"github.com/gogo/protobuf/proto"
 	"github.com/spf13/cobra"
 	flag "github.com/spf13/pflag"
 
@@ -33,6 +34,7 @@ import (
 
 	"github.com/arangodb-managed/oasisctl/cmd"
 	"github.com/arangodb-managed/oasisctl/pkg/format"
+	"github.com/arangodb-managed/oasisctl/pkg/manifest"
 	"github.com/arangodb-managed/oasisctl/pkg/selection"
 )
 
@@ -62,6 +64,8 @@ func init() {
 				dbservers             int32
 				dbserverMemorySize    int32
 				dbserverDiskSize      int32
+
+				dryRun bool
 			}{}
 			f.StringVarP(&cargs.deploymentID, "deployment-id", "d", cmd.DefaultDeployment(), "Identifier of the deployment")
 			f.StringVarP(&cargs.organizationID, "organization-id", "o", cmd.DefaultOrganization(), "Identifier of the organization")
@@ -79,21 +83,34 @@ func init() {
 			f.Int32Var(&cargs.dbservers, "dbservers", 3, "Set number of dbservers for flexible deployments")
 			f.Int32Var(&cargs.dbserverMemorySize, "dbserver-memory-size", 4, "Set memory size of dbservers for flexible deployments (GB)")
 			f.Int32Var(&cargs.dbserverDiskSize, "dbserver-disk-size", 32, "Set disk size of dbservers for flexible deployments (GB)")
+			f.BoolVar(&cargs.dryRun, "dry-run", false, "Only show the changes that would be made")
 
-			c.Run = func(c *cobra.Command, args []string) {
+			c.RunE = func(c *cobra.Command, args []string) error {
 				// Validate arguments
 				log := cmd.CLILog
 				deploymentID, argsUsed := cmd.OptOption("deployment-id", cargs.deploymentID, args, 0)
-				cmd.MustCheckNumberOfArgs(args, argsUsed)
+				if err := cmd.CheckNumberOfArgs(args, argsUsed); err != nil {
+					return err
+				}
 
 				// Connect
-				conn := cmd.MustDialAPI()
+				conn, err := cmd.DialAPI()
+				if err != nil {
+					return err
+				}
 				datac := data.NewDataServiceClient(conn)
 				rmc := rm.NewResourceManagerServiceClient(conn)
-				ctx := cmd.ContextWithToken()
+				ctx, err := cmd.ContextWithToken()
+				if err != nil {
+					return err
+				}
 
 				// Fetch deployment
-				item := selection.MustSelectDeployment(ctx, log, deploymentID, cargs.projectID, cargs.organizationID, datac, rmc)
+				item, err := selection.SelectDeployment(ctx, log, deploymentID, cargs.projectID, cargs.organizationID, datac, rmc)
+				if err != nil {
+					return cmd.WrapError(err, "Failed to get deployment")
+				}
+				original := proto.Clone(item).(*data.Deployment)
 				ensureModel := func() *data.Deployment_ModelSpec {
 					if item.Model == nil {
 						item.Model = &data.Deployment_ModelSpec{}
@@ -161,19 +178,29 @@ func init() {
 					ensureServers().DbserverDiskSize = cargs.dbserverDiskSize
 					hasChanges = true
 				}
-				if !hasChanges {
+				if cargs.dryRun {
+					// Show changes only, leaving out flags that set the current value
+					if update := manifest.NewDeploymentUpdate(original, item); len(update.Changes) == 0 {
+						fmt.Println("No changes")
+					} else {
+						fmt.Print(update.Diff(cmd.UseColor()))
+					}
+				} else if !hasChanges {
 					fmt.Println("No changes")
 				} else {
 					// Update deployment
 					updated, err := datac.UpdateDeployment(ctx, item)
 					if err != nil {
-						log.Fatal().Err(err).Msg("Failed to update deployment")
+						return cmd.WrapError(err, "Failed to update deployment")
 					}
 
 					// Show result
 					fmt.Println("Updated deployment!")
-					fmt.Println(format.Deployment(updated, nil, cmd.RootArgs.Format, false))
+					if err := cmd.ShowResult(format.Deployment(updated, nil, cmd.RootArgs.Format, false)); err != nil {
+						return err
+					}
 				}
+				return nil
 			}
 		},
 	)
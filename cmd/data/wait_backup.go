@@ -0,0 +1,131 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Ewout Prangsma
+//
+
+package data
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+
+	backup "github.com/arangodb-managed/apis/backup/v1"
+	common "github.com/arangodb-managed/apis/common/v1"
+
+	"github.com/arangodb-managed/oasisctl/cmd"
+	"github.com/arangodb-managed/oasisctl/pkg/format"
+)
+
+const (
+	defaultWaitBackupTimeout = time.Hour
+)
+
+func init() {
+	cmd.InitCommand(
+		cmd.WaitCmd,
+		&cobra.Command{
+			Use:   "backup",
+			Short: "Wait for a backup to be ready",
+			Long: `Wait for a backup to be ready.
+If the backup is to be uploaded, this also waits until the upload has completed.
+Fails when the backup (or its upload) has failed.`,
+		},
+		func(c *cobra.Command, f *flag.FlagSet) {
+			cargs := &struct {
+				ID      string
+				timeout time.Duration
+			}{}
+			f.StringVarP(&cargs.ID, "id", "i", "", "Identifier of the backup")
+			cmd.DurationVarP(f, &cargs.timeout, "timeout", "t", defaultWaitBackupTimeout, "How long to wait for the backup to be ready (0 for no limit)")
+
+			c.RunE = func(c *cobra.Command, args []string) error {
+				// Validate arguments
+				log := cmd.CLILog
+				id, argsUsed, err := cmd.ReqOption("id", cargs.ID, args, 0)
+				if err != nil {
+					return err
+				}
+				if err := cmd.CheckNumberOfArgs(args, argsUsed); err != nil {
+					return err
+				}
+
+				// Connect
+				conn, err := cmd.DialAPI()
+				if err != nil {
+					return err
+				}
+				backupc := backup.NewBackupServiceClient(conn)
+				ctx, err := cmd.ContextWithToken()
+				if err != nil {
+					return err
+				}
+
+				// Wait for the backup
+				result, err := waitForBackup(ctx, log, backupc, id, cargs.timeout)
+				if err != nil {
+					return err
+				}
+
+				// Show result
+				return cmd.ShowResult(format.Backup(result, cmd.RootArgs.Format))
+			}
+		},
+	)
+}
+
+// waitForBackup waits until the backup with given ID is ready and,
+// if it is to be uploaded, uploaded.
+func waitForBackup(ctx context.Context, log zerolog.Logger, backupc backup.BackupServiceClient, id string, timeout time.Duration) (*backup.Backup, error) {
+	var b *backup.Backup
+	err := pollUntil(ctx, timeout, func() (bool, error) {
+		var err error
+		b, err = backupc.GetBackup(ctx, &common.IDOptions{Id: id})
+		if err != nil {
+			return false, cmd.WrapError(err, "Failed to get backup")
+		}
+		status := b.GetStatus()
+		uploaded := status.GetUploadStatus().GetUploaded()
+		switch state := status.GetState(); {
+		case status.GetIsFailed() || state == "Failed" || state == "UploadError":
+			return false, fmt.Errorf("Backup failed (%s): %s", state, status.GetMessage())
+		case b.GetIsDeleted() || state == "Deleted":
+			return false, fmt.Errorf("Backup has been deleted")
+		case state == "Ready" && (!b.GetUpload() || uploaded):
+			return true, nil
+		}
+		log.Info().
+			Str("state", status.GetState()).
+			Str("progress", status.GetProgress()).
+			Bool("uploaded", uploaded).
+			Msg("Waiting for backup")
+		return false, nil
+	})
+	if err == errPollTimeout {
+		return nil, fmt.Errorf("Backup not ready after %s (state %s)", timeout, b.GetStatus().GetState())
+	} else if err != nil {
+		return nil, err
+	}
+	return b, nil
+}
@@ -23,78 +23,388 @@
 package data
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"os"
+	"sort"
+	"strings"
 	"time"
 
+	"github.com/rs/zerolog"
 	"github.com/spf13/cobra"
 	flag "github.com/spf13/pflag"
 
+	common "github.com/arangodb-managed/apis/common/v1"
 	data "github.com/arangodb-managed/apis/data/v1"
 	rm "github.com/arangodb-managed/apis/resourcemanager/v1"
 
 	"github.com/arangodb-managed/oasisctl/cmd"
+	"github.com/arangodb-managed/oasisctl/pkg/format"
 	"github.com/arangodb-managed/oasisctl/pkg/selection"
 )
 
 const (
 	defaultWaitDeploymentTimeout = time.Minute * 20
+	// Poll interval of the first poll, it grows exponentially up to maxWaitPollInterval.
+	minWaitPollInterval = time.Second
+	maxWaitPollInterval = time.Second * 30
 )
 
+// errPollTimeout is returned by pollUntil when the timeout has been reached.
+var errPollTimeout = errors.New("timeout")
+
+// pollUntil calls the given poll function until it returns true or an error,
+// waiting between polls with an interval that grows from minWaitPollInterval
+// up to maxWaitPollInterval.
+// Returns errPollTimeout when the given timeout (if > 0) has been reached
+// and the error of the context when it is done.
+func pollUntil(ctx context.Context, timeout time.Duration, poll func() (bool, error)) error {
+	start := time.Now()
+	interval := minWaitPollInterval
+	for {
+		done, err := poll()
+		if err != nil {
+			return err
+		}
+		if done {
+			return nil
+		}
+
+		// Check timeout
+		wait := interval
+		if timeout > 0 {
+			remaining := timeout - time.Since(start)
+			if remaining <= 0 {
+				return errPollTimeout
+			}
+			if wait > remaining {
+				wait = remaining
+			}
+		}
+
+		// Wait a bit
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+		interval = interval * 3 / 2
+		if interval > maxWaitPollInterval {
+			interval = maxWaitPollInterval
+		}
+	}
+}
+
+// deploymentConditions maps the conditions that can be waited for to
+// a function that checks them.
+// A nil deployment is a deployment that no longer exists.
+var deploymentConditions = map[string]func(*data.Deployment) bool{
+	"ready": func(x *data.Deployment) bool {
+		return x.GetStatus().GetReady()
+	},
+	"bootstrapped": func(x *data.Deployment) bool {
+		return x.GetStatus().GetBootstrapped()
+	},
+	"upgraded": func(x *data.Deployment) bool {
+		status := x.GetStatus()
+		if x == nil || status.GetUpgrading() {
+			return false
+		}
+		for _, v := range status.GetServerVersions() {
+			if v != x.GetVersion() {
+				return false
+			}
+		}
+		for _, s := range status.GetServers() {
+			if v := s.GetVersion(); v != "" && v != x.GetVersion() {
+				return false
+			}
+		}
+		return true
+	},
+	"deleted": func(x *data.Deployment) bool {
+		return x == nil || x.GetIsDeleted()
+	},
+	"paused": func(x *data.Deployment) bool {
+		return x.GetIsPaused()
+	},
+	"servers-ok": func(x *data.Deployment) bool {
+		servers := x.GetStatus().GetServers()
+		for _, s := range servers {
+			if !s.GetOk() || s.GetFailed() {
+				return false
+			}
+		}
+		return len(servers) > 0
+	},
+}
+
+// deploymentConditionNames returns the sorted names of all conditions.
+func deploymentConditionNames() []string {
+	result := make([]string, 0, len(deploymentConditions))
+	for name := range deploymentConditions {
+		result = append(result, name)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// waitTarget is a deployment that is waited for.
+type waitTarget struct {
+	id       string
+	name     string
+	reached  bool
+	duration time.Duration
+	progress string
+}
+
 func init() {
 	cmd.InitCommand(
 		cmd.WaitCmd,
 		&cobra.Command{
-			Use:   "deployment",
-			Short: "Wait for a deployment to reach the ready status",
+			Use:   "deployment [deployment-id...]",
+			Short: "Wait for one or more deployments to reach a status",
+			Long: `Wait for one or more deployments to reach all given conditions.
+
+Conditions (--for): ` + strings.Join(deploymentConditionNames(), ", ") + `.
+Deployments are given as arguments, using --deployment-id or using --all for all deployments of a project.
+Without any of these, the only deployment of the project is used.
+The result shows how long it took for each deployment to reach the conditions.`,
 		},
 		func(c *cobra.Command, f *flag.FlagSet) {
 			cargs := &struct {
 				deploymentID   string
 				organizationID string
 				projectID      string
+				all            bool
+				conditions     []string
 				timeout        time.Duration
 			}{}
 			f.StringVarP(&cargs.deploymentID, "deployment-id", "d", cmd.DefaultDeployment(), "Identifier of the deployment")
 			f.StringVarP(&cargs.organizationID, "organization-id", "o", cmd.DefaultOrganization(), "Identifier of the organization")
 			f.StringVarP(&cargs.projectID, "project-id", "p", cmd.DefaultProject(), "Identifier of the project")
-			f.DurationVarP(&cargs.timeout, "timeout", "t", defaultWaitDeploymentTimeout, "How long to wait for the deployment to reach the ready status")
+			f.BoolVar(&cargs.all, "all", false, "Wait for all deployments of the project")
+			f.StringSliceVar(&cargs.conditions, "for", []string{"ready"}, "Conditions to wait for ("+strings.Join(deploymentConditionNames(), "|")+")")
+			cmd.DurationVarP(f, &cargs.timeout, "timeout", "t", defaultWaitDeploymentTimeout, "How long to wait for the deployments to reach the conditions (0 for no limit)")
 
-			c.Run = func(c *cobra.Command, args []string) {
+			c.RunE = func(c *cobra.Command, args []string) error {
 				// Validate arguments
 				log := cmd.CLILog
-				deploymentID, argsUsed := cmd.OptOption("deployment-id", cargs.deploymentID, args, 0)
-				cmd.MustCheckNumberOfArgs(args, argsUsed)
+				for _, name := range cargs.conditions {
+					if _, found := deploymentConditions[name]; !found {
+						return cmd.UsageError("Unknown condition '%s', expected one of: %s", name, strings.Join(deploymentConditionNames(), ", "))
+					}
+				}
+				deploymentIDs := args
+				if cargs.deploymentID != "" && (c.Flags().Changed("deployment-id") || len(args) == 0) && !cargs.all {
+					deploymentIDs = append([]string{cargs.deploymentID}, args...)
+				}
+				if cargs.all && len(args) > 0 {
+					return cmd.UsageError("--all cannot be combined with deployment arguments")
+				}
 
 				// Connect
-				conn := cmd.MustDialAPI()
+				conn, err := cmd.DialAPI()
+				if err != nil {
+					return err
+				}
 				datac := data.NewDataServiceClient(conn)
 				rmc := rm.NewResourceManagerServiceClient(conn)
-				ctx := cmd.ContextWithToken()
+				ctx, err := cmd.ContextWithToken()
+				if err != nil {
+					return err
+				}
 
-				start := time.Now()
-				for {
-					// Fetch deployment
-					item := selection.MustSelectDeployment(ctx, log, deploymentID, cargs.projectID, cargs.organizationID, datac, rmc)
-
-					// Check status
-					status := item.GetStatus()
-					if status.GetReady() {
-						// Status ready
-						break
+				// Select deployments
+				waitForDeleted := false
+				for _, name := range cargs.conditions {
+					waitForDeleted = waitForDeleted || name == "deleted"
+				}
+				var targets []*waitTarget
+				if cargs.all {
+					project, err := selection.SelectProject(ctx, log, cargs.projectID, cargs.organizationID, rmc)
+					if err != nil {
+						return cmd.WrapError(err, "Failed to get project")
 					}
-
-					// Check timeout
-					if time.Since(start) > cargs.timeout {
-						log.Fatal().Msg("Deployment not ready after timeout")
+					list, err := datac.ListDeployments(ctx, &common.ListOptions{ContextId: project.GetId()})
+					if err != nil {
+						return cmd.WrapError(err, "Failed to list deployments")
+					}
+					for _, x := range list.GetItems() {
+						targets = append(targets, &waitTarget{id: x.GetId(), name: x.GetName()})
+					}
+				} else {
+					if len(deploymentIDs) == 0 {
+						// Select the only deployment of the project
+						x, err := selection.SelectDeployment(ctx, log, "", cargs.projectID, cargs.organizationID, datac, rmc)
+						if err != nil {
+							return cmd.WrapError(err, "Failed to get deployment")
+						}
+						targets = append(targets, &waitTarget{id: x.GetId(), name: x.GetName()})
 					}
+					for _, id := range deploymentIDs {
+						x, err := datac.GetDeployment(ctx, &common.IDOptions{Id: id})
+						if common.IsNotFound(err) && waitForDeleted {
+							// Already deleted
+							targets = append(targets, &waitTarget{id: id})
+							continue
+						} else if err != nil {
+							// Select by name
+							x, err = selection.SelectDeployment(ctx, log, id, cargs.projectID, cargs.organizationID, datac, rmc)
+							if err != nil {
+								return cmd.WrapError(err, "Failed to get deployment")
+							}
+						}
+						targets = append(targets, &waitTarget{id: x.GetId(), name: x.GetName()})
+					}
+				}
 
-					// Wait a bit
-					log.Debug().Str("status", status.GetDescription()).Msg("Deployment not yet ready")
-					time.Sleep(time.Second * 2)
+				// Wait for the conditions
+				start := time.Now()
+				progress := newWaitProgress(log)
+				pending := 0
+				err = pollUntil(ctx, cargs.timeout, func() (bool, error) {
+					pending = 0
+					for _, t := range targets {
+						if t.reached {
+							continue
+						}
+						if err := t.poll(ctx, datac, cargs.conditions); err != nil {
+							return false, err
+						}
+						if t.reached {
+							t.duration = time.Since(start)
+						} else {
+							pending++
+						}
+					}
+					progress.show(targets)
+					return pending == 0, nil
+				})
+				progress.done()
+				if err == errPollTimeout {
+					if err := cmd.ShowResult(format.WaitResultList(waitResults(targets, cargs.conditions, time.Since(start)), cmd.RootArgs.Format)); err != nil {
+						return err
+					}
+					return fmt.Errorf("%d of %d deployment(s) did not reach %s within %s", pending, len(targets), strings.Join(cargs.conditions, ","), cargs.timeout)
+				} else if err != nil {
+					return cmd.WrapError(err, "Failed to get deployment")
 				}
 
-				fmt.Println("Deployment ready")
+				// Show result
+				if cmd.RootArgs.Format.Format == "table" {
+					what := "Deployment"
+					if len(targets) != 1 {
+						what = fmt.Sprintf("%d deployments", len(targets))
+					}
+					if len(cargs.conditions) == 1 && cargs.conditions[0] == "ready" {
+						fmt.Printf("%s ready\n", what)
+					} else {
+						fmt.Printf("%s reached %s\n", what, strings.Join(cargs.conditions, ","))
+					}
+				}
+				return cmd.ShowResult(format.WaitResultList(waitResults(targets, cargs.conditions, time.Since(start)), cmd.RootArgs.Format))
 			}
 		},
 	)
 }
+
+// poll fetches the deployment and checks the given conditions.
+func (t *waitTarget) poll(ctx context.Context, datac data.DataServiceClient, conditions []string) error {
+	x, err := datac.GetDeployment(ctx, &common.IDOptions{Id: t.id})
+	if common.IsNotFound(err) {
+		x = nil
+	} else if err != nil {
+		return err
+	}
+	t.reached = true
+	for _, name := range conditions {
+		if !deploymentConditions[name](x) {
+			t.reached = false
+		}
+	}
+	if x == nil {
+		t.progress = "Deleted"
+		return nil
+	}
+	t.progress = x.GetStatus().GetDescription()
+	if servers := x.GetStatus().GetServers(); len(servers) > 0 {
+		ready := 0
+		for _, s := range servers {
+			if s.GetReady() {
+				ready++
+			}
+		}
+		t.progress = strings.TrimSpace(fmt.Sprintf("%s (%d/%d servers ready)", t.progress, ready, len(servers)))
+	}
+	return nil
+}
+
+// waitResults returns the results of waiting for the given targets.
+// Targets that did not reach the conditions get the given duration.
+func waitResults(targets []*waitTarget, conditions []string, duration time.Duration) []format.WaitResult {
+	result := make([]format.WaitResult, 0, len(targets))
+	for _, t := range targets {
+		r := format.WaitResult{
+			ID:         t.id,
+			Name:       t.name,
+			Conditions: conditions,
+			Reached:    t.reached,
+			Duration:   duration,
+			Status:     t.progress,
+		}
+		if t.reached {
+			r.Duration = t.duration
+		}
+		result = append(result, r)
+	}
+	return result
+}
+
+// waitProgress shows the progress of waiting on stderr.
+// On a terminal a single line is updated in place, otherwise
+// a log line is written for every change.
+type waitProgress struct {
+	log      zerolog.Logger
+	terminal bool
+	last     map[string]string
+}
+
+// newWaitProgress creates a new waitProgress.
+func newWaitProgress(log zerolog.Logger) *waitProgress {
+	return &waitProgress{
+		log:      log,
+		terminal: cmd.StderrIsTerminal(),
+		last:     make(map[string]string),
+	}
+}
+
+// show the progress of the given targets.
+func (p *waitProgress) show(targets []*waitTarget) {
+	var parts []string
+	for _, t := range targets {
+		if t.reached {
+			continue
+		}
+		name := t.name
+		if name == "" {
+			name = t.id
+		}
+		parts = append(parts, fmt.Sprintf("%s: %s", name, t.progress))
+		if !p.terminal && p.last[t.id] != t.progress {
+			p.log.Info().Str("deployment", t.id).Str("status", t.progress).Msg("Waiting for deployment")
+		}
+		p.last[t.id] = t.progress
+	}
+	if p.terminal {
+		fmt.Fprintf(os.Stderr, "\r\x1b[KWaiting for %d deployment(s): %s", len(parts), strings.Join(parts, ", "))
+	}
+}
+
+// done clears the progress line.
+func (p *waitProgress) done() {
+	if p.terminal {
+		fmt.Fprint(os.Stderr, "\r\x1b[K")
+	}
+}
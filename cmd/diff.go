@@ -0,0 +1,96 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Ewout Prangsma
+//
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+
+	"github.com/arangodb-managed/oasisctl/pkg/manifest"
+)
+
+func init() {
+	InitCommand(
+		RootCmd,
+		&cobra.Command{
+			Use:   "diff",
+			Short: "Show the changes that applying manifest files would make",
+			Long: `Show the field level changes that 'oasisctl apply' would make for the given manifest files.
+
+The command exits with code 0 when the current state matches the manifests
+and with code 8 when there are differences, which makes it usable as drift detector.`,
+		},
+		func(c *cobra.Command, f *flag.FlagSet) {
+			cargs := &struct {
+				files          []string
+				organizationID string
+				noColor        bool
+			}{}
+			f.StringSliceVarP(&cargs.files, "file", "f", nil, "Manifest file(s) to compare with (use - for stdin)")
+			f.StringVarP(&cargs.organizationID, "organization-id", "o", DefaultOrganization(), "Identifier of the organization (if not specified in the manifest)")
+			f.BoolVar(&cargs.noColor, "no-color", false, "Do not color the output")
+
+			c.RunE = func(c *cobra.Command, args []string) error {
+				// Validate arguments
+				log := CLILog
+				if err := CheckNumberOfArgs(args, 0); err != nil {
+					return err
+				}
+				if len(cargs.files) == 0 {
+					return UsageError("--file missing")
+				}
+				m, err := manifest.LoadAll(cargs.files)
+				if err != nil {
+					return WrapError(err, "Failed to load manifest")
+				}
+
+				// Connect
+				conn, err := DialAPI()
+				if err != nil {
+					return err
+				}
+				clients := newManifestClients(conn)
+				ctx, err := ContextWithToken()
+				if err != nil {
+					return err
+				}
+
+				// Compute changes
+				plan, err := manifest.NewPlan(ctx, log, m, cargs.organizationID, clients)
+				if err != nil {
+					return WrapError(err, "Failed to compute changes")
+				}
+
+				// Show result
+				if !plan.HasChanges() {
+					fmt.Println("No changes")
+					return nil
+				}
+				fmt.Print(plan.Diff(UseColor() && !cargs.noColor))
+				return ExitError(ExitCodeDrift)
+			}
+		},
+	)
+}
@@ -0,0 +1,193 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Ewout Prangsma
+//
+
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Exit codes of oasisctl.
+const (
+	// ExitCodeOK is returned when the command succeeded.
+	ExitCodeOK = 0
+	// ExitCodeGeneral is returned for errors without a more specific exit code.
+	ExitCodeGeneral = 1
+	// ExitCodeUsage is returned for invalid arguments, flags or values.
+	ExitCodeUsage = 3
+	// ExitCodeUnauthenticated is returned when the token is missing, invalid or expired.
+	ExitCodeUnauthenticated = 4
+	// ExitCodePermissionDenied is returned when the caller lacks permissions.
+	ExitCodePermissionDenied = 5
+	// ExitCodeNotFound is returned when a requested resource does not exist.
+	ExitCodeNotFound = 6
+	// ExitCodeUnavailable is returned when the API cannot be reached.
+	ExitCodeUnavailable = 7
+	// ExitCodeDrift is returned by `oasisctl diff` when there are differences.
+	// It does not use 2, since that is the exit code of a Go panic.
+	ExitCodeDrift = 8
+)
+
+// commandError is an error with a message describing what failed.
+type commandError struct {
+	msg      string
+	err      error
+	exitCode int
+}
+
+// Error implements the error interface.
+func (e *commandError) Error() string {
+	if e.err == nil {
+		return e.msg
+	}
+	if e.msg == "" {
+		return e.err.Error()
+	}
+	return e.msg + ": " + e.err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e *commandError) Unwrap() error {
+	return e.err
+}
+
+// WrapError returns an error that describes what failed (the formatted message)
+// because of the given error.
+// The exit code is derived from the given error.
+func WrapError(err error, msg string, args ...interface{}) error {
+	if len(args) > 0 {
+		msg = fmt.Sprintf(msg, args...)
+	}
+	return &commandError{msg: msg, err: err}
+}
+
+// UsageError returns an error for invalid arguments, flags or values.
+func UsageError(msg string, args ...interface{}) error {
+	if len(args) > 0 {
+		msg = fmt.Sprintf(msg, args...)
+	}
+	return &commandError{msg: msg, exitCode: ExitCodeUsage}
+}
+
+// PreconditionError returns an error for an operation that cannot be
+// performed in the current state of a resource.
+func PreconditionError(msg string, args ...interface{}) error {
+	if len(args) > 0 {
+		msg = fmt.Sprintf(msg, args...)
+	}
+	return &commandError{msg: msg, exitCode: ExitCodeGeneral}
+}
+
+// ExitError returns an error that results in the given exit code,
+// without showing an error message.
+func ExitError(exitCode int) error {
+	return &commandError{exitCode: exitCode}
+}
+
+// ExitCode returns the exit code for the given error.
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitCodeOK
+	}
+	var ce *commandError
+	if errors.As(err, &ce) && ce.exitCode != 0 {
+		return ce.exitCode
+	}
+	switch grpcCode(err) {
+	case codes.InvalidArgument:
+		return ExitCodeUsage
+	case codes.Unauthenticated:
+		return ExitCodeUnauthenticated
+	case codes.PermissionDenied:
+		return ExitCodePermissionDenied
+	case codes.NotFound:
+		return ExitCodeNotFound
+	case codes.Unavailable:
+		return ExitCodeUnavailable
+	}
+	return ExitCodeGeneral
+}
+
+// grpcCode returns the gRPC status code of the (wrapped) given error.
+func grpcCode(err error) codes.Code {
+	for ; err != nil; err = errors.Unwrap(err) {
+		if s, ok := status.FromError(err); ok {
+			return s.Code()
+		}
+	}
+	return codes.Unknown
+}
+
+// HandleError shows the given error on stderr and returns the exit code
+// for it.
+// If the JSON output format is selected, the error is written as a JSON object.
+func HandleError(err error) int {
+	exitCode := ExitCode(err)
+	var ce *commandError
+	if errors.As(err, &ce) && ce.msg == "" && ce.err == nil {
+		// Silent exit
+		return exitCode
+	}
+	msg, cause := err.Error(), ""
+	if errors.As(err, &ce) && ce.err != nil {
+		msg, cause = ce.msg, ce.err.Error()
+		if s, ok := status.FromError(ce.err); ok {
+			cause = s.Message()
+		}
+	}
+	if RootArgs.Format.Format == "json" {
+		encoded, _ := json.MarshalIndent(struct {
+			Error    string `json:"error"`
+			Cause    string `json:"cause,omitempty"`
+			Code     string `json:"code,omitempty"`
+			ExitCode int    `json:"exit-code"`
+		}{
+			Error:    msg,
+			Cause:    cause,
+			Code:     codeName(err),
+			ExitCode: exitCode,
+		}, "", "  ")
+		fmt.Fprintln(os.Stderr, string(encoded))
+		return exitCode
+	}
+	if cause != "" {
+		CLILog.Error().Str("error", cause).Msg(msg)
+	} else {
+		CLILog.Error().Msg(msg)
+	}
+	return exitCode
+}
+
+// codeName returns the name of the gRPC status code of the given error,
+// or an empty string if the error is not a gRPC error.
+func codeName(err error) string {
+	if code := grpcCode(err); code != codes.Unknown {
+		return code.String()
+	}
+	return ""
+}
@@ -23,8 +23,6 @@
 package example
 
 import (
-	"fmt"
-
 	"github.com/spf13/cobra"
 	flag "github.com/spf13/pflag"
 
@@ -55,25 +53,39 @@ func init() {
 			f.StringVarP(&cargs.projectID, "project-id", "p", cmd.DefaultProject(), "Identifier of the project")
 			f.StringVarP(&cargs.exampleDatasetID, "example-dataset-id", "e", "", "ID of the example dataset")
 
-			c.Run = func(c *cobra.Command, args []string) {
+			c.RunE = func(c *cobra.Command, args []string) error {
 				// Validate arguments
 				log := cmd.CLILog
 				deploymentID, argsUsed := cmd.OptOption("deployment-id", cargs.deploymentID, args, 0)
 				exampleDatasetID, argsUsed := cmd.OptOption("example-dataset-id", cargs.exampleDatasetID, args, 0)
-				cmd.MustCheckNumberOfArgs(args, argsUsed)
+				if err := cmd.CheckNumberOfArgs(args, argsUsed); err != nil {
+					return err
+				}
 
 				// Connect
-				conn := cmd.MustDialAPI()
+				conn, err := cmd.DialAPI()
+				if err != nil {
+					return err
+				}
 				examplec := example.NewExampleDatasetServiceClient(conn)
 				datac := data.NewDataServiceClient(conn)
 				rmc := rm.NewResourceManagerServiceClient(conn)
-				ctx := cmd.ContextWithToken()
+				ctx, err := cmd.ContextWithToken()
+				if err != nil {
+					return err
+				}
 
 				// Select deployment
-				deployment := selection.MustSelectDeployment(ctx, log, deploymentID, cargs.projectID, cargs.organizationID, datac, rmc)
+				deployment, err := selection.SelectDeployment(ctx, log, deploymentID, cargs.projectID, cargs.organizationID, datac, rmc)
+				if err != nil {
+					return cmd.WrapError(err, "Failed to get deployment")
+				}
 
 				// Select example
-				exampleDS := selection.MustSelectExampleDataset(ctx, log, exampleDatasetID, examplec)
+				exampleDS, err := selection.SelectExampleDataset(ctx, log, exampleDatasetID, examplec)
+				if err != nil {
+					return cmd.WrapError(err, "Failed to get example dataset")
+				}
 
 				// Create installation
 				req := &example.ExampleDatasetInstallation{
@@ -82,12 +94,12 @@ func init() {
 				}
 				result, err := examplec.CreateExampleDatasetInstallation(ctx, req)
 				if err != nil {
-					log.Fatal().Err(err).Msg("Failed to create installation")
+					return cmd.WrapError(err, "Failed to create installation")
 				}
 
 				// Show result
 				format.DisplaySuccess(cmd.RootArgs.Format)
-				fmt.Println(format.ExampleDatasetInstallation(result, cmd.RootArgs.Format))
+				return cmd.ShowResult(format.ExampleDatasetInstallation(result, cmd.RootArgs.Format))
 			}
 		},
 	)
@@ -55,29 +55,41 @@ func init() {
 			f.StringVarP(&cargs.projectID, "project-id", "p", cmd.DefaultProject(), "Identifier of the project")
 			f.StringVar(&cargs.installationID, "installation-id", "", "The ID of the installation to delete.")
 
-			c.Run = func(c *cobra.Command, args []string) {
+			c.RunE = func(c *cobra.Command, args []string) error {
 				// Validate arguments
 				log := cmd.CLILog
 				installationID, argsUsed := cmd.OptOption("installation-id", cargs.installationID, args, 0)
-				cmd.MustCheckNumberOfArgs(args, argsUsed)
+				if err := cmd.CheckNumberOfArgs(args, argsUsed); err != nil {
+					return err
+				}
 
 				// Connect
-				conn := cmd.MustDialAPI()
+				conn, err := cmd.DialAPI()
+				if err != nil {
+					return err
+				}
 				examplec := example.NewExampleDatasetServiceClient(conn)
 				datac := data.NewDataServiceClient(conn)
 				rmc := rm.NewResourceManagerServiceClient(conn)
-				ctx := cmd.ContextWithToken()
+				ctx, err := cmd.ContextWithToken()
+				if err != nil {
+					return err
+				}
 
 				// Select installation
-				item := selection.MustSelectExampleDatasetInstallation(ctx, log, installationID, cargs.deploymentID, cargs.projectID, cargs.organizationID, datac, examplec, rmc)
+				item, err := selection.SelectExampleDatasetInstallation(ctx, log, installationID, cargs.deploymentID, cargs.projectID, cargs.organizationID, datac, examplec, rmc)
+				if err != nil {
+					return cmd.WrapError(err, "Failed to get example dataset installation")
+				}
 
 				// Delete installation
 				if _, err := examplec.DeleteExampleDatasetInstallation(ctx, &common.IDOptions{Id: item.GetId()}); err != nil {
-					log.Fatal().Err(err).Msg("Failed to delete example dataset installation")
+					return cmd.WrapError(err, "Failed to delete example dataset installation")
 				}
 
 				// Show result
 				fmt.Println("Success")
+				return nil
 			}
 		},
 	)
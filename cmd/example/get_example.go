@@ -23,8 +23,6 @@
 package example
 
 import (
-	"fmt"
-
 	example "github.com/arangodb-managed/apis/example/v1"
 	"github.com/arangodb-managed/oasisctl/cmd"
 	"github.com/arangodb-managed/oasisctl/pkg/format"
@@ -45,22 +43,33 @@ var getExampleCmd = cmd.InitCommand(
 		}{}
 		f.StringVarP(&cargs.exampleDatasetID, "example-dataset-id", "e", "", "ID of the example dataset")
 
-		c.Run = func(c *cobra.Command, args []string) {
+		c.RunE = func(c *cobra.Command, args []string) error {
 			// Validate arguments
 			log := cmd.CLILog
 			exampleDatasetID, argsUsed := cmd.OptOption("example-dataset-id", cargs.exampleDatasetID, args, 0)
-			cmd.MustCheckNumberOfArgs(args, argsUsed)
+			if err := cmd.CheckNumberOfArgs(args, argsUsed); err != nil {
+				return err
+			}
 
 			// Connect
-			conn := cmd.MustDialAPI()
+			conn, err := cmd.DialAPI()
+			if err != nil {
+				return err
+			}
 			examplec := example.NewExampleDatasetServiceClient(conn)
-			ctx := cmd.ContextWithToken()
+			ctx, err := cmd.ContextWithToken()
+			if err != nil {
+				return err
+			}
 
 			// Select example
-			example := selection.MustSelectExampleDataset(ctx, log, exampleDatasetID, examplec)
+			example, err := selection.SelectExampleDataset(ctx, log, exampleDatasetID, examplec)
+			if err != nil {
+				return cmd.WrapError(err, "Failed to get example dataset")
+			}
 
 			// Show result
-			fmt.Println(format.Example(example, cmd.RootArgs.Format))
+			return cmd.ShowResult(format.Example(example, cmd.RootArgs.Format))
 		}
 	},
 )
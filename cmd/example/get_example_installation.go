@@ -23,8 +23,6 @@
 package example
 
 import (
-	"fmt"
-
 	data "github.com/arangodb-managed/apis/data/v1"
 	example "github.com/arangodb-managed/apis/example/v1"
 	rm "github.com/arangodb-managed/apis/resourcemanager/v1"
@@ -54,24 +52,35 @@ func init() {
 			f.StringVarP(&cargs.projectID, "project-id", "p", cmd.DefaultProject(), "Identifier of the project")
 			f.StringVar(&cargs.installationID, "installation-id", "", "The ID of the installation to get.")
 
-			c.Run = func(c *cobra.Command, args []string) {
+			c.RunE = func(c *cobra.Command, args []string) error {
 				// Validate arguments
 				log := cmd.CLILog
 				installationID, argsUsed := cmd.OptOption("installation-id", cargs.installationID, args, 0)
-				cmd.MustCheckNumberOfArgs(args, argsUsed)
+				if err := cmd.CheckNumberOfArgs(args, argsUsed); err != nil {
+					return err
+				}
 
 				// Connect
-				conn := cmd.MustDialAPI()
+				conn, err := cmd.DialAPI()
+				if err != nil {
+					return err
+				}
 				examplec := example.NewExampleDatasetServiceClient(conn)
 				datac := data.NewDataServiceClient(conn)
 				rmc := rm.NewResourceManagerServiceClient(conn)
-				ctx := cmd.ContextWithToken()
+				ctx, err := cmd.ContextWithToken()
+				if err != nil {
+					return err
+				}
 
 				// Select installation
-				item := selection.MustSelectExampleDatasetInstallation(ctx, log, installationID, cargs.deploymentID, cargs.projectID, cargs.organizationID, datac, examplec, rmc)
+				item, err := selection.SelectExampleDatasetInstallation(ctx, log, installationID, cargs.deploymentID, cargs.projectID, cargs.organizationID, datac, examplec, rmc)
+				if err != nil {
+					return cmd.WrapError(err, "Failed to get example dataset installation")
+				}
 
 				// Show result
-				fmt.Println(format.ExampleDatasetInstallation(item, cmd.RootArgs.Format))
+				return cmd.ShowResult(format.ExampleDatasetInstallation(item, cmd.RootArgs.Format))
 			}
 		},
 	)
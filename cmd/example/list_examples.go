@@ -24,8 +24,6 @@
 package example
 
 import (
-	"fmt"
-
 	"github.com/spf13/cobra"
 	flag "github.com/spf13/pflag"
 
@@ -49,20 +47,29 @@ func init() {
 				organizationID string
 			}{}
 			f.StringVarP(&cargs.organizationID, "organization-id", "o", cmd.DefaultOrganization(), "Identifier of the organization")
-			c.Run = func(c *cobra.Command, args []string) {
+			c.RunE = func(c *cobra.Command, args []string) error {
 				// Validate arguments
 				log := cmd.CLILog
 
 				// Connect
-				conn := cmd.MustDialAPI()
+				conn, err := cmd.DialAPI()
+				if err != nil {
+					return err
+				}
 				examplec := example.NewExampleDatasetServiceClient(conn)
 				rmc := rm.NewResourceManagerServiceClient(conn)
-				ctx := cmd.ContextWithToken()
+				ctx, err := cmd.ContextWithToken()
+				if err != nil {
+					return err
+				}
 
 				var orgID string
 				// Fetch organization
 				if cargs.organizationID != "" {
-					org := selection.MustSelectOrganization(ctx, log, cargs.organizationID, rmc)
+					org, err := selection.SelectOrganization(ctx, log, cargs.organizationID, rmc)
+					if err != nil {
+						return cmd.WrapError(err, "Failed to get organization")
+					}
 					orgID = org.GetId()
 				}
 
@@ -70,11 +77,11 @@ func init() {
 					OrganizationId: orgID,
 				})
 				if err != nil {
-					log.Fatal().Err(err).Msg("Failed to list examples")
+					return cmd.WrapError(err, "Failed to list examples")
 				}
 
 				// Show result
-				fmt.Println(format.ExampleList(list.Items, cmd.RootArgs.Format))
+				return cmd.ShowResult(format.ExampleList(list.Items, cmd.RootArgs.Format))
 			}
 		},
 	)
@@ -0,0 +1,95 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Ewout Prangsma
+//
+
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+	"gopkg.in/yaml.v2"
+
+	"github.com/arangodb-managed/oasisctl/pkg/manifest"
+)
+
+func init() {
+	InitCommand(
+		RootCmd,
+		&cobra.Command{
+			Use:   "export",
+			Short: "Export the resources of an organization as manifest",
+			Long: `Export the groups, custom roles, policies, projects, CA certificates, IP whitelists
+and deployments of an organization as manifest (YAML), that can be used with 'oasisctl apply'.
+
+Identifiers are left out, references between resources are expressed by name
+and users are referenced by email address.`,
+		},
+		func(c *cobra.Command, f *flag.FlagSet) {
+			cargs := &struct {
+				organizationID string
+				output         string
+			}{}
+			f.StringVarP(&cargs.organizationID, "organization-id", "o", DefaultOrganization(), "Identifier of the organization")
+			f.StringVar(&cargs.output, "output", "", "File to write the manifest to (default stdout)")
+
+			c.RunE = func(c *cobra.Command, args []string) error {
+				// Validate arguments
+				log := CLILog
+				organizationID, argsUsed := OptOption("organization-id", cargs.organizationID, args, 0)
+				if err := CheckNumberOfArgs(args, argsUsed); err != nil {
+					return err
+				}
+
+				// Connect
+				conn, err := DialAPI()
+				if err != nil {
+					return err
+				}
+				clients := newManifestClients(conn)
+				ctx, err := ContextWithToken()
+				if err != nil {
+					return err
+				}
+
+				// Export resources
+				m, err := manifest.Export(ctx, log, organizationID, clients)
+				if err != nil {
+					return WrapError(err, "Failed to export organization")
+				}
+				encoded, err := yaml.Marshal(m)
+				if err != nil {
+					return WrapError(err, "Failed to encode manifest")
+				}
+
+				// Show result
+				if cargs.output == "" {
+					fmt.Print(string(encoded))
+				} else if err := ioutil.WriteFile(cargs.output, encoded, 0644); err != nil {
+					return WrapError(err, "Failed to write manifest")
+				}
+				return nil
+			}
+		},
+	)
+}
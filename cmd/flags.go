@@ -0,0 +1,98 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Ewout Prangsma
+//
+
+package cmd
+
+import (
+	"strconv"
+	"time"
+
+	flag "github.com/spf13/pflag"
+
+	"github.com/arangodb-managed/oasisctl/pkg/util"
+)
+
+// durationValue is a flag value for durations parsed with util.ParseDuration.
+type durationValue time.Duration
+
+func (v *durationValue) Set(s string) error {
+	d, err := util.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*v = durationValue(d)
+	return nil
+}
+func (v *durationValue) Type() string   { return "duration" }
+func (v *durationValue) String() string { return time.Duration(*v).String() }
+
+// DurationVar defines a duration flag, accepting days & weeks in
+// addition to the units of time.ParseDuration, e.g. "7d".
+func DurationVar(f *flag.FlagSet, p *time.Duration, name string, value time.Duration, usage string) {
+	DurationVarP(f, p, name, "", value, usage)
+}
+
+// DurationVarP is like DurationVar, but accepts a shorthand letter.
+func DurationVarP(f *flag.FlagSet, p *time.Duration, name, shorthand string, value time.Duration, usage string) {
+	*p = value
+	f.VarP((*durationValue)(p), name, shorthand, usage)
+}
+
+// timeValue is a flag value for timestamps parsed with util.ParseTime.
+type timeValue struct {
+	t      *time.Time
+	future bool
+	raw    string
+}
+
+func (v *timeValue) Set(s string) error {
+	if v.future {
+		// A plain number is a number of hours from now.
+		if hours, err := strconv.Atoi(s); err == nil {
+			*v.t = time.Now().Add(time.Duration(hours) * time.Hour)
+			v.raw = s
+			return nil
+		}
+	}
+	t, err := util.ParseTime(s, v.future)
+	if err != nil {
+		return err
+	}
+	*v.t = t
+	v.raw = s
+	return nil
+}
+func (v *timeValue) Type() string   { return "time" }
+func (v *timeValue) String() string { return v.raw }
+
+// TimeVar defines a timestamp flag. A relative value such as "2h" or "7d"
+// is taken as a time before now. See util.ParseTime for all supported formats.
+// The zero time means that the flag was not set.
+func TimeVar(f *flag.FlagSet, p *time.Time, name, usage string) {
+	f.Var(&timeValue{t: p}, name, usage+" (e.g. 2h, 7d, yesterday, 2020-05-01 or RFC3339)")
+}
+
+// FutureTimeVar is like TimeVar, but a relative value is taken as a time
+// after now. A plain number is a number of hours from now.
+func FutureTimeVar(f *flag.FlagSet, p *time.Time, name, usage string) {
+	f.Var(&timeValue{t: p, future: true}, name, usage+" (e.g. 6h, 7d, tomorrow, 2020-05-01 or RFC3339)")
+}
@@ -38,7 +38,7 @@ var (
 	GenerateCmd = &cobra.Command{
 		Use:                "generate-docs",
 		Short:              "Generate output",
-		Run:                generateMarkdownRun,
+		RunE:               generateMarkdownRun,
 		DisableAutoGenTag:  true,
 		DisableSuggestions: true,
 	}
@@ -64,9 +64,8 @@ func init() {
 	f.StringVarP(&generateArgs.replaceUnderscoreWith, "replace-underscore-with", "r", "", "Replace the underscore in links with the given character")
 }
 
-func generateMarkdownRun(c *cobra.Command, args []string) {
+func generateMarkdownRun(c *cobra.Command, args []string) error {
 	// Validate arguments
-	log := CLILog
 	cargs := generateArgs
 
 	filePrepender := func(filename string) string {
@@ -91,11 +90,12 @@ func generateMarkdownRun(c *cobra.Command, args []string) {
 	}
 
 	if _, err := os.Stat(cargs.outputDir); os.IsNotExist(err) {
-		log.Fatal().Err(err).Str("output", cargs.outputDir).Msg("Output directory does not exist.")
+		return WrapError(err, "Output directory %s does not exist", cargs.outputDir)
 	}
 
 	err := doc.GenMarkdownTreeCustom(RootCmd, cargs.outputDir, filePrepender, linkHandler)
 	if err != nil {
-		log.Fatal().Err(err).Msg("Unable to generate document")
+		return WrapError(err, "Unable to generate document")
 	}
+	return nil
 }
@@ -23,6 +23,8 @@
 package iam
 
 import (
+	"fmt"
+
 	"github.com/spf13/cobra"
 
 	common "github.com/arangodb-managed/apis/common/v1"
@@ -39,7 +41,7 @@ var (
 	addGroupMembersCmd = &cobra.Command{
 		Use:   "members",
 		Short: "Add members to group",
-		Run:   addGroupMembersCmdRun,
+		RunE:  addGroupMembersCmdRun,
 	}
 	addGroupMembersArgs struct {
 		organizationID string
@@ -57,49 +59,67 @@ func init() {
 	addGroupMembersArgs.userEmails = f.StringSliceP("user-emails", "u", []string{}, "A comma separated list of user email addresses")
 }
 
-func addGroupMembersCmdRun(c *cobra.Command, args []string) {
+func addGroupMembersCmdRun(c *cobra.Command, args []string) error {
 	// Validate arguments
 	log := cmd.CLILog
 	cargs := addGroupMembersArgs
 	groupID, argsUsed := cmd.OptOption("group-id", cargs.groupID, args, 0)
 	organizationID, argsUsed := cmd.OptOption("organiztaion-id", cargs.organizationID, args, 0)
-	cmd.MustCheckNumberOfArgs(args, argsUsed)
+	if err := cmd.CheckNumberOfArgs(args, argsUsed); err != nil {
+		return err
+	}
 
 	// Connect
-	conn := cmd.MustDialAPI()
+	conn, err := cmd.DialAPI()
+	if err != nil {
+		return err
+	}
 	iamc := iam.NewIAMServiceClient(conn)
 	rmc := rm.NewResourceManagerServiceClient(conn)
-	ctx := cmd.ContextWithToken()
+	ctx, err := cmd.ContextWithToken()
+	if err != nil {
+		return err
+	}
+
+	organization, err := selection.SelectOrganization(ctx, log, organizationID, rmc)
 
-	organization := selection.MustSelectOrganization(ctx, log, organizationID, rmc)
-	group := selection.MustSelectGroup(ctx, log, groupID, organization.Id, iamc, rmc)
+	if err != nil {
+
+		return cmd.WrapError(err, "Failed to get organization")
+
+	}
+	group, err := selection.SelectGroup(ctx, log, groupID, organization.Id, iamc, rmc)
+	if err != nil {
+		return cmd.WrapError(err, "Failed to get group")
+	}
 
 	log.Info().Msgf("Adding members: %s", cargs.userEmails)
 	var userIds []string
 	members, err := rmc.ListOrganizationMembers(ctx, &common.ListOptions{ContextId: organization.Id})
 	if err != nil {
-		log.Fatal().Err(err).Msg("Failed to list organization members.")
+		return cmd.WrapError(err, "Failed to list organization members.")
 	}
 	emailIDMap := make(map[string]string)
 	for _, u := range members.Items {
 		user, err := iamc.GetUser(ctx, &common.IDOptions{Id: u.UserId})
 		if err != nil {
-			log.Fatal().Err(err).Msg("Failed to get user")
+			return cmd.WrapError(err, "Failed to get user")
 		}
 		emailIDMap[user.Email] = user.Id
 	}
 
 	for _, e := range *cargs.userEmails {
 		if id, ok := emailIDMap[e]; !ok {
-			log.Fatal().Str("email", e).Msg("User not found or not part of the ogranization")
+			return fmt.Errorf("User %s not found or not part of the organization", e)
 		} else {
 			userIds = append(userIds, id)
 		}
 	}
 
 	if _, err := iamc.AddGroupMembers(ctx, &iam.GroupMembersRequest{GroupId: group.Id, UserIds: userIds}); err != nil {
-		log.Fatal().Err(err).Msg("Failed to add users.")
+		return cmd.WrapError(err, "Failed to add users.")
 	}
 
 	format.DisplaySuccess(cmd.RootArgs.Format)
+	return nil
 }
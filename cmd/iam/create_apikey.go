@@ -23,8 +23,6 @@
 package iam
 
 import (
-	"fmt"
-
 	"github.com/spf13/cobra"
 	flag "github.com/spf13/pflag"
 
@@ -51,21 +49,32 @@ func init() {
 			f.BoolVar(&cargs.readonly, "readonly", false, "If set, the newly created API key will grant readonly access only")
 			f.StringVarP(&cargs.organizationID, "organization-id", "o", "", "If set, the newly created API key will grant access to this organization only")
 
-			c.Run = func(c *cobra.Command, args []string) {
+			c.RunE = func(c *cobra.Command, args []string) error {
 				// Validate arguments
 				log := cmd.CLILog
-				cmd.MustCheckNumberOfArgs(args, 0)
+				if err := cmd.CheckNumberOfArgs(args, 0); err != nil {
+					return err
+				}
 
 				// Connect
-				conn := cmd.MustDialAPI()
+				conn, err := cmd.DialAPI()
+				if err != nil {
+					return err
+				}
 				iamc := iam.NewIAMServiceClient(conn)
 				rmc := rm.NewResourceManagerServiceClient(conn)
-				ctx := cmd.ContextWithToken()
+				ctx, err := cmd.ContextWithToken()
+				if err != nil {
+					return err
+				}
 
 				var orgID string
 				// Fetch organization
 				if cargs.organizationID != "" {
-					org := selection.MustSelectOrganization(ctx, log, cargs.organizationID, rmc)
+					org, err := selection.SelectOrganization(ctx, log, cargs.organizationID, rmc)
+					if err != nil {
+						return cmd.WrapError(err, "Failed to get organization")
+					}
 					orgID = org.GetId()
 				}
 
@@ -75,12 +84,12 @@ func init() {
 					OrganizationId: orgID,
 				})
 				if err != nil {
-					log.Fatal().Err(err).Msg("Failed to create API key")
+					return cmd.WrapError(err, "Failed to create API key")
 				}
 
 				// Show result
 				format.DisplaySuccess(cmd.RootArgs.Format)
-				fmt.Println(format.APIKeySecret(result, cmd.RootArgs.Format))
+				return cmd.ShowResult(format.APIKeySecret(result, cmd.RootArgs.Format))
 			}
 		},
 	)
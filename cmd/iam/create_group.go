@@ -23,8 +23,6 @@
 package iam
 
 import (
-	"fmt"
-
 	"github.com/spf13/cobra"
 
 	iam "github.com/arangodb-managed/apis/iam/v1"
@@ -40,7 +38,7 @@ var (
 	createGroupCmd = &cobra.Command{
 		Use:   "group",
 		Short: "Create a new group",
-		Run:   createGroupCmdRun,
+		RunE:  createGroupCmdRun,
 	}
 	createGroupArgs struct {
 		name           string
@@ -58,22 +56,36 @@ func init() {
 	f.StringVarP(&createGroupArgs.organizationID, "organization-id", "o", cmd.DefaultOrganization(), "Identifier of the organization to create the group in")
 }
 
-func createGroupCmdRun(c *cobra.Command, args []string) {
+func createGroupCmdRun(c *cobra.Command, args []string) error {
 	// Validate arguments
 	log := cmd.CLILog
 	cargs := createGroupArgs
-	name, argsUsed := cmd.ReqOption("name", cargs.name, args, 0)
+	name, argsUsed, err := cmd.ReqOption("name", cargs.name, args, 0)
+	if err != nil {
+		return err
+	}
 	description := cargs.description
-	cmd.MustCheckNumberOfArgs(args, argsUsed)
+	if err := cmd.CheckNumberOfArgs(args, argsUsed); err != nil {
+		return err
+	}
 
 	// Connect
-	conn := cmd.MustDialAPI()
+	conn, err := cmd.DialAPI()
+	if err != nil {
+		return err
+	}
 	iamc := iam.NewIAMServiceClient(conn)
 	rmc := rm.NewResourceManagerServiceClient(conn)
-	ctx := cmd.ContextWithToken()
+	ctx, err := cmd.ContextWithToken()
+	if err != nil {
+		return err
+	}
 
 	// Fetch organization
-	org := selection.MustSelectOrganization(ctx, log, cargs.organizationID, rmc)
+	org, err := selection.SelectOrganization(ctx, log, cargs.organizationID, rmc)
+	if err != nil {
+		return cmd.WrapError(err, "Failed to get organization")
+	}
 
 	// Create group
 	result, err := iamc.CreateGroup(ctx, &iam.Group{
@@ -82,10 +94,10 @@ func createGroupCmdRun(c *cobra.Command, args []string) {
 		Description:    description,
 	})
 	if err != nil {
-		log.Fatal().Err(err).Msg("Failed to create group")
+		return cmd.WrapError(err, "Failed to create group")
 	}
 
 	// Show result
 	format.DisplaySuccess(cmd.RootArgs.Format)
-	fmt.Println(format.Group(result, cmd.RootArgs.Format))
+	return cmd.ShowResult(format.Group(result, cmd.RootArgs.Format))
 }
@@ -23,8 +23,6 @@
 package iam
 
 import (
-	"fmt"
-
 	"github.com/spf13/cobra"
 
 	iam "github.com/arangodb-managed/apis/iam/v1"
@@ -40,7 +38,7 @@ var (
 	createRoleCmd = &cobra.Command{
 		Use:   "role",
 		Short: "Create a new role",
-		Run:   createRoleCmdRun,
+		RunE:  createRoleCmdRun,
 	}
 	createRoleArgs struct {
 		name           string
@@ -60,23 +58,37 @@ func init() {
 	f.StringVarP(&createRoleArgs.organizationID, "organization-id", "o", cmd.DefaultOrganization(), "Identifier of the organization to create the role in")
 }
 
-func createRoleCmdRun(c *cobra.Command, args []string) {
+func createRoleCmdRun(c *cobra.Command, args []string) error {
 	// Validate arguments
 	log := cmd.CLILog
 	cargs := createRoleArgs
-	name, argsUsed := cmd.ReqOption("name", cargs.name, args, 0)
+	name, argsUsed, err := cmd.ReqOption("name", cargs.name, args, 0)
+	if err != nil {
+		return err
+	}
 	description := cargs.description
 	permissions := cargs.permissions
-	cmd.MustCheckNumberOfArgs(args, argsUsed)
+	if err := cmd.CheckNumberOfArgs(args, argsUsed); err != nil {
+		return err
+	}
 
 	// Connect
-	conn := cmd.MustDialAPI()
+	conn, err := cmd.DialAPI()
+	if err != nil {
+		return err
+	}
 	iamc := iam.NewIAMServiceClient(conn)
 	rmc := rm.NewResourceManagerServiceClient(conn)
-	ctx := cmd.ContextWithToken()
+	ctx, err := cmd.ContextWithToken()
+	if err != nil {
+		return err
+	}
 
 	// Fetch organization
-	org := selection.MustSelectOrganization(ctx, log, cargs.organizationID, rmc)
+	org, err := selection.SelectOrganization(ctx, log, cargs.organizationID, rmc)
+	if err != nil {
+		return cmd.WrapError(err, "Failed to get organization")
+	}
 
 	// Create role
 	result, err := iamc.CreateRole(ctx, &iam.Role{
@@ -86,10 +98,10 @@ func createRoleCmdRun(c *cobra.Command, args []string) {
 		Permissions:    permissions,
 	})
 	if err != nil {
-		log.Fatal().Err(err).Msg("Failed to create role")
+		return cmd.WrapError(err, "Failed to create role")
 	}
 
 	// Show result
 	format.DisplaySuccess(cmd.RootArgs.Format)
-	fmt.Println(format.Role(result, cmd.RootArgs.Format))
+	return cmd.ShowResult(format.Role(result, cmd.RootArgs.Format))
 }
@@ -47,27 +47,38 @@ func init() {
 			}{}
 			f.StringVarP(&cargs.apiKeyID, "apikey-id", "i", "", "Identifier of the API key to delete")
 
-			c.Run = func(c *cobra.Command, args []string) {
+			c.RunE = func(c *cobra.Command, args []string) error {
 				// Validate arguments
-				log := cmd.CLILog
-				apiKeyID, argsUsed := cmd.ReqOption("apikey-id", cargs.apiKeyID, args, 0)
-				cmd.MustCheckNumberOfArgs(args, argsUsed)
+				apiKeyID, argsUsed, err := cmd.ReqOption("apikey-id", cargs.apiKeyID, args, 0)
+				if err != nil {
+					return err
+				}
+				if err := cmd.CheckNumberOfArgs(args, argsUsed); err != nil {
+					return err
+				}
 
 				// Connect
-				conn := cmd.MustDialAPI()
+				conn, err := cmd.DialAPI()
+				if err != nil {
+					return err
+				}
 				iamc := iam.NewIAMServiceClient(conn)
-				ctx := cmd.ContextWithToken()
+				ctx, err := cmd.ContextWithToken()
+				if err != nil {
+					return err
+				}
 
 				// Delete API key
-				_, err := iamc.DeleteAPIKey(ctx, &common.IDOptions{
+				_, err = iamc.DeleteAPIKey(ctx, &common.IDOptions{
 					Id: apiKeyID,
 				})
 				if err != nil {
-					log.Fatal().Err(err).Msg("Failed to delete API key")
+					return cmd.WrapError(err, "Failed to delete API key")
 				}
 
 				// Show result
 				fmt.Println("Deleted API key!")
+				return nil
 			}
 		},
 	)
@@ -40,7 +40,7 @@ var (
 	deleteGroupCmd = &cobra.Command{
 		Use:   "group",
 		Short: "Delete a group the authenticated user has access to",
-		Run:   deleteGroupCmdRun,
+		RunE:  deleteGroupCmdRun,
 	}
 	deleteGroupArgs struct {
 		organizationID string
@@ -55,27 +55,39 @@ func init() {
 	f.StringVarP(&deleteGroupArgs.organizationID, "organization-id", "o", cmd.DefaultOrganization(), "Identifier of the organization")
 }
 
-func deleteGroupCmdRun(c *cobra.Command, args []string) {
+func deleteGroupCmdRun(c *cobra.Command, args []string) error {
 	// Validate arguments
 	log := cmd.CLILog
 	cargs := deleteGroupArgs
 	groupID, argsUsed := cmd.OptOption("group-id", cargs.groupID, args, 0)
-	cmd.MustCheckNumberOfArgs(args, argsUsed)
+	if err := cmd.CheckNumberOfArgs(args, argsUsed); err != nil {
+		return err
+	}
 
 	// Connect
-	conn := cmd.MustDialAPI()
+	conn, err := cmd.DialAPI()
+	if err != nil {
+		return err
+	}
 	iamc := iam.NewIAMServiceClient(conn)
 	rmc := rm.NewResourceManagerServiceClient(conn)
-	ctx := cmd.ContextWithToken()
+	ctx, err := cmd.ContextWithToken()
+	if err != nil {
+		return err
+	}
 
 	// Fetch group
-	item := selection.MustSelectGroup(ctx, log, groupID, cargs.organizationID, iamc, rmc)
+	item, err := selection.SelectGroup(ctx, log, groupID, cargs.organizationID, iamc, rmc)
+	if err != nil {
+		return cmd.WrapError(err, "Failed to get group")
+	}
 
 	// Delete group
 	if _, err := iamc.DeleteGroup(ctx, &common.IDOptions{Id: item.GetId()}); err != nil {
-		log.Fatal().Err(err).Msg("Failed to delete group")
+		return cmd.WrapError(err, "Failed to delete group")
 	}
 
 	// Show result
 	fmt.Println("Deleted group!")
+	return nil
 }
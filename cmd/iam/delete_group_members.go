@@ -23,6 +23,8 @@
 package iam
 
 import (
+	"fmt"
+
 	"github.com/spf13/cobra"
 
 	common "github.com/arangodb-managed/apis/common/v1"
@@ -39,7 +41,7 @@ var (
 	deleteGroupMembersCmd = &cobra.Command{
 		Use:   "members",
 		Short: "Delete members from group",
-		Run:   deleteGroupMembersCmdRun,
+		RunE:  deleteGroupMembersCmdRun,
 	}
 	deleteGroupMembersArgs struct {
 		groupID        string
@@ -57,49 +59,67 @@ func init() {
 	deleteGroupMembersArgs.userEmails = f.StringSliceP("user-emails", "u", []string{}, "A comma separated list of user email addresses")
 }
 
-func deleteGroupMembersCmdRun(c *cobra.Command, args []string) {
+func deleteGroupMembersCmdRun(c *cobra.Command, args []string) error {
 	// Validate arguments
 	log := cmd.CLILog
 	cargs := deleteGroupMembersArgs
 	groupID, argsUsed := cmd.OptOption("group-id", cargs.groupID, args, 0)
 	organizationID, argsUsed := cmd.OptOption("organization-id", cargs.organizationID, args, 0)
-	cmd.MustCheckNumberOfArgs(args, argsUsed)
+	if err := cmd.CheckNumberOfArgs(args, argsUsed); err != nil {
+		return err
+	}
 
 	// Connect
-	conn := cmd.MustDialAPI()
+	conn, err := cmd.DialAPI()
+	if err != nil {
+		return err
+	}
 	iamc := iam.NewIAMServiceClient(conn)
-	ctx := cmd.ContextWithToken()
+	ctx, err := cmd.ContextWithToken()
+	if err != nil {
+		return err
+	}
 	rmc := rm.NewResourceManagerServiceClient(conn)
 
-	organization := selection.MustSelectOrganization(ctx, log, organizationID, rmc)
-	group := selection.MustSelectGroup(ctx, log, groupID, organization.Id, iamc, rmc)
+	organization, err := selection.SelectOrganization(ctx, log, organizationID, rmc)
+
+	if err != nil {
+
+		return cmd.WrapError(err, "Failed to get organization")
+
+	}
+	group, err := selection.SelectGroup(ctx, log, groupID, organization.Id, iamc, rmc)
+	if err != nil {
+		return cmd.WrapError(err, "Failed to get group")
+	}
 
 	log.Info().Msgf("Deleting members: %s", cargs.userEmails)
 	var userIds []string
 	members, err := iamc.ListGroupMembers(ctx, &common.ListOptions{ContextId: group.Id})
 	if err != nil {
-		log.Fatal().Err(err).Msg("Failed to list group members.")
+		return cmd.WrapError(err, "Failed to list group members.")
 	}
 	emailIDMap := make(map[string]string)
 	for _, id := range members.Items {
 		user, err := iamc.GetUser(ctx, &common.IDOptions{Id: id})
 		if err != nil {
-			log.Fatal().Err(err).Msg("Failed to get user")
+			return cmd.WrapError(err, "Failed to get user")
 		}
 		emailIDMap[user.Email] = user.Id
 	}
 
 	for _, e := range *cargs.userEmails {
 		if id, ok := emailIDMap[e]; !ok {
-			log.Fatal().Str("email", e).Str("group-id", group.Id).Msg("User not part of the group")
+			return fmt.Errorf("User %s not part of the group %s", e, group.Id)
 		} else {
 			userIds = append(userIds, id)
 		}
 	}
 
 	if _, err := iamc.DeleteGroupMembers(ctx, &iam.GroupMembersRequest{GroupId: group.Id, UserIds: userIds}); err != nil {
-		log.Fatal().Err(err).Msg("Failed to delete users.")
+		return cmd.WrapError(err, "Failed to delete users.")
 	}
 
 	format.DisplaySuccess(cmd.RootArgs.Format)
+	return nil
 }
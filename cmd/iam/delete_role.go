@@ -40,7 +40,7 @@ var (
 	deleteRoleCmd = &cobra.Command{
 		Use:   "role",
 		Short: "Delete a role the authenticated user has access to",
-		Run:   deleteRoleCmdRun,
+		RunE:  deleteRoleCmdRun,
 	}
 	deleteRoleArgs struct {
 		organizationID string
@@ -55,27 +55,39 @@ func init() {
 	f.StringVarP(&deleteRoleArgs.organizationID, "organization-id", "o", cmd.DefaultOrganization(), "Identifier of the organization")
 }
 
-func deleteRoleCmdRun(c *cobra.Command, args []string) {
+func deleteRoleCmdRun(c *cobra.Command, args []string) error {
 	// Validate arguments
 	log := cmd.CLILog
 	cargs := deleteRoleArgs
 	roleID, argsUsed := cmd.OptOption("role-id", cargs.roleID, args, 0)
-	cmd.MustCheckNumberOfArgs(args, argsUsed)
+	if err := cmd.CheckNumberOfArgs(args, argsUsed); err != nil {
+		return err
+	}
 
 	// Connect
-	conn := cmd.MustDialAPI()
+	conn, err := cmd.DialAPI()
+	if err != nil {
+		return err
+	}
 	iamc := iam.NewIAMServiceClient(conn)
 	rmc := rm.NewResourceManagerServiceClient(conn)
-	ctx := cmd.ContextWithToken()
+	ctx, err := cmd.ContextWithToken()
+	if err != nil {
+		return err
+	}
 
 	// Fetch role
-	item := selection.MustSelectRole(ctx, log, roleID, cargs.organizationID, iamc, rmc)
+	item, err := selection.SelectRole(ctx, log, roleID, cargs.organizationID, iamc, rmc)
+	if err != nil {
+		return cmd.WrapError(err, "Failed to get role")
+	}
 
 	// Delete role
 	if _, err := iamc.DeleteRole(ctx, &common.IDOptions{Id: item.GetId()}); err != nil {
-		log.Fatal().Err(err).Msg("Failed to delete role")
+		return cmd.WrapError(err, "Failed to delete role")
 	}
 
 	// Show result
 	fmt.Println("Deleted role!")
+	return nil
 }
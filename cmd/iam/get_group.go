@@ -23,8 +23,6 @@
 package iam
 
 import (
-	"fmt"
-
 	"github.com/spf13/cobra"
 
 	iam "github.com/arangodb-managed/apis/iam/v1"
@@ -40,7 +38,7 @@ var (
 	getGroupCmd = &cobra.Command{
 		Use:   "group",
 		Short: "Get a group the authenticated user has access to",
-		Run:   getGroupCmdRun,
+		RunE:  getGroupCmdRun,
 	}
 	getGroupArgs struct {
 		groupID        string
@@ -55,22 +53,33 @@ func init() {
 	f.StringVarP(&getGroupArgs.organizationID, "organization-id", "o", cmd.DefaultOrganization(), "Identifier of the organization")
 }
 
-func getGroupCmdRun(c *cobra.Command, args []string) {
+func getGroupCmdRun(c *cobra.Command, args []string) error {
 	// Validate arguments
 	log := cmd.CLILog
 	cargs := getGroupArgs
 	groupID, argsUsed := cmd.OptOption("group-id", cargs.groupID, args, 0)
-	cmd.MustCheckNumberOfArgs(args, argsUsed)
+	if err := cmd.CheckNumberOfArgs(args, argsUsed); err != nil {
+		return err
+	}
 
 	// Connect
-	conn := cmd.MustDialAPI()
+	conn, err := cmd.DialAPI()
+	if err != nil {
+		return err
+	}
 	iamc := iam.NewIAMServiceClient(conn)
 	rmc := rm.NewResourceManagerServiceClient(conn)
-	ctx := cmd.ContextWithToken()
+	ctx, err := cmd.ContextWithToken()
+	if err != nil {
+		return err
+	}
 
 	// Fetch group
-	item := selection.MustSelectGroup(ctx, log, groupID, cargs.organizationID, iamc, rmc)
+	item, err := selection.SelectGroup(ctx, log, groupID, cargs.organizationID, iamc, rmc)
+	if err != nil {
+		return cmd.WrapError(err, "Failed to get group")
+	}
 
 	// Show result
-	fmt.Println(format.Group(item, cmd.RootArgs.Format))
+	return cmd.ShowResult(format.Group(item, cmd.RootArgs.Format))
 }
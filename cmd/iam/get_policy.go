@@ -23,8 +23,6 @@
 package iam
 
 import (
-	"fmt"
-
 	"github.com/spf13/cobra"
 
 	common "github.com/arangodb-managed/apis/common/v1"
@@ -39,7 +37,7 @@ var (
 	getPolicyCmd = &cobra.Command{
 		Use:   "policy",
 		Short: "Get a policy the authenticated user has access to",
-		Run:   getPolicyCmdRun,
+		RunE:  getPolicyCmdRun,
 	}
 	getPolicyArgs struct {
 		url string
@@ -52,24 +50,31 @@ func init() {
 	f.StringVarP(&getPolicyArgs.url, "url", "u", cmd.DefaultURL(), "URL of the resource to inspect the policy for")
 }
 
-func getPolicyCmdRun(c *cobra.Command, args []string) {
+func getPolicyCmdRun(c *cobra.Command, args []string) error {
 	// Validate arguments
-	log := cmd.CLILog
 	cargs := getPolicyArgs
 	url, argsUsed := cmd.OptOption("url", cargs.url, args, 0)
-	cmd.MustCheckNumberOfArgs(args, argsUsed)
+	if err := cmd.CheckNumberOfArgs(args, argsUsed); err != nil {
+		return err
+	}
 
 	// Connect
-	conn := cmd.MustDialAPI()
+	conn, err := cmd.DialAPI()
+	if err != nil {
+		return err
+	}
 	iamc := iam.NewIAMServiceClient(conn)
-	ctx := cmd.ContextWithToken()
+	ctx, err := cmd.ContextWithToken()
+	if err != nil {
+		return err
+	}
 
 	// Fetch policy
 	item, err := iamc.GetPolicy(ctx, &common.URLOptions{Url: url})
 	if err != nil {
-		log.Fatal().Err(err).Msg("Failed to get policy")
+		return cmd.WrapError(err, "Failed to get policy")
 	}
 
 	// Show result
-	fmt.Println(format.Policy(ctx, item, iamc, cmd.RootArgs.Format))
+	return cmd.ShowResult(format.Policy(ctx, item, iamc, cmd.RootArgs.Format))
 }
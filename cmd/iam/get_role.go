@@ -23,8 +23,6 @@
 package iam
 
 import (
-	"fmt"
-
 	"github.com/spf13/cobra"
 
 	iam "github.com/arangodb-managed/apis/iam/v1"
@@ -40,7 +38,7 @@ var (
 	getRoleCmd = &cobra.Command{
 		Use:   "role",
 		Short: "Get a role the authenticated user has access to",
-		Run:   getRoleCmdRun,
+		RunE:  getRoleCmdRun,
 	}
 	getRoleArgs struct {
 		roleID         string
@@ -55,22 +53,33 @@ func init() {
 	f.StringVarP(&getRoleArgs.organizationID, "organization-id", "o", cmd.DefaultOrganization(), "Identifier of the organization")
 }
 
-func getRoleCmdRun(c *cobra.Command, args []string) {
+func getRoleCmdRun(c *cobra.Command, args []string) error {
 	// Validate arguments
 	log := cmd.CLILog
 	cargs := getRoleArgs
 	roleID, argsUsed := cmd.OptOption("role-id", cargs.roleID, args, 0)
-	cmd.MustCheckNumberOfArgs(args, argsUsed)
+	if err := cmd.CheckNumberOfArgs(args, argsUsed); err != nil {
+		return err
+	}
 
 	// Connect
-	conn := cmd.MustDialAPI()
+	conn, err := cmd.DialAPI()
+	if err != nil {
+		return err
+	}
 	iamc := iam.NewIAMServiceClient(conn)
 	rmc := rm.NewResourceManagerServiceClient(conn)
-	ctx := cmd.ContextWithToken()
+	ctx, err := cmd.ContextWithToken()
+	if err != nil {
+		return err
+	}
 
 	// Fetch role
-	item := selection.MustSelectRole(ctx, log, roleID, cargs.organizationID, iamc, rmc)
+	item, err := selection.SelectRole(ctx, log, roleID, cargs.organizationID, iamc, rmc)
+	if err != nil {
+		return cmd.WrapError(err, "Failed to get role")
+	}
 
 	// Show result
-	fmt.Println(format.Role(item, cmd.RootArgs.Format))
+	return cmd.ShowResult(format.Role(item, cmd.RootArgs.Format))
 }
@@ -23,8 +23,6 @@
 package iam
 
 import (
-	"fmt"
-
 	"github.com/spf13/cobra"
 
 	common "github.com/arangodb-managed/apis/common/v1"
@@ -39,7 +37,7 @@ var (
 	getSelfCmd = &cobra.Command{
 		Use:   "self",
 		Short: "Get information about the authenticated user",
-		Run:   getSelfCmdRun,
+		RunE:  getSelfCmdRun,
 	}
 )
 
@@ -47,22 +45,29 @@ func init() {
 	cmd.GetCmd.AddCommand(getSelfCmd)
 }
 
-func getSelfCmdRun(c *cobra.Command, args []string) {
+func getSelfCmdRun(c *cobra.Command, args []string) error {
 	// Validate arguments
-	log := cmd.CLILog
-	cmd.MustCheckNumberOfArgs(args, 0)
+	if err := cmd.CheckNumberOfArgs(args, 0); err != nil {
+		return err
+	}
 
 	// Connect
-	conn := cmd.MustDialAPI()
+	conn, err := cmd.DialAPI()
+	if err != nil {
+		return err
+	}
 	iamc := iam.NewIAMServiceClient(conn)
-	ctx := cmd.ContextWithToken()
+	ctx, err := cmd.ContextWithToken()
+	if err != nil {
+		return err
+	}
 
 	// Fetch user info
 	user, err := iamc.GetThisUser(ctx, &common.Empty{})
 	if err != nil {
-		log.Fatal().Err(err).Msg("Failed to get user info")
+		return cmd.WrapError(err, "Failed to get user info")
 	}
 
 	// Show result
-	fmt.Println(format.User(user, cmd.RootArgs.Format))
+	return cmd.ShowResult(format.User(user, cmd.RootArgs.Format))
 }
@@ -23,8 +23,6 @@
 package iam
 
 import (
-	"fmt"
-
 	"github.com/spf13/cobra"
 	flag "github.com/spf13/pflag"
 
@@ -43,24 +41,31 @@ func init() {
 			Short: "List all API keys created for the current user",
 		},
 		func(c *cobra.Command, f *flag.FlagSet) {
-			c.Run = func(c *cobra.Command, args []string) {
+			c.RunE = func(c *cobra.Command, args []string) error {
 				// Validate arguments
-				log := cmd.CLILog
-				cmd.MustCheckNumberOfArgs(args, 0)
+				if err := cmd.CheckNumberOfArgs(args, 0); err != nil {
+					return err
+				}
 
 				// Connect
-				conn := cmd.MustDialAPI()
+				conn, err := cmd.DialAPI()
+				if err != nil {
+					return err
+				}
 				iamc := iam.NewIAMServiceClient(conn)
-				ctx := cmd.ContextWithToken()
+				ctx, err := cmd.ContextWithToken()
+				if err != nil {
+					return err
+				}
 
 				// List API keys
 				result, err := iamc.ListAPIKeys(ctx, &common.ListOptions{})
 				if err != nil {
-					log.Fatal().Err(err).Msg("Failed to list API keys")
+					return cmd.WrapError(err, "Failed to list API keys")
 				}
 
 				// Show result
-				fmt.Println(format.APIKeyList(result.GetItems(), cmd.RootArgs.Format))
+				return cmd.ShowResult(format.APIKeyList(result.GetItems(), cmd.RootArgs.Format))
 			}
 		},
 	)
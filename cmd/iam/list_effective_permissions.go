@@ -23,8 +23,6 @@
 package iam
 
 import (
-	"fmt"
-
 	"github.com/spf13/cobra"
 
 	common "github.com/arangodb-managed/apis/common/v1"
@@ -39,7 +37,7 @@ var (
 	listEffectivePermissionsCmd = &cobra.Command{
 		Use:   "permissions",
 		Short: "List the effective permissions, the authenticated user has for a given URL",
-		Run:   listEffectivePermissionsCmdRun,
+		RunE:  listEffectivePermissionsCmdRun,
 	}
 	listEffectivePermissionsArgs struct {
 		url string
@@ -52,24 +50,34 @@ func init() {
 	f.StringVarP(&listEffectivePermissionsArgs.url, "url", "u", cmd.DefaultURL(), "URL of resource to get effective permissions for")
 }
 
-func listEffectivePermissionsCmdRun(c *cobra.Command, args []string) {
+func listEffectivePermissionsCmdRun(c *cobra.Command, args []string) error {
 	// Validate arguments
-	log := cmd.CLILog
 	cargs := listEffectivePermissionsArgs
-	url, argsUsed := cmd.ReqOption("url", cargs.url, args, 0)
-	cmd.MustCheckNumberOfArgs(args, argsUsed)
+	url, argsUsed, err := cmd.ReqOption("url", cargs.url, args, 0)
+	if err != nil {
+		return err
+	}
+	if err := cmd.CheckNumberOfArgs(args, argsUsed); err != nil {
+		return err
+	}
 
 	// Connect
-	conn := cmd.MustDialAPI()
+	conn, err := cmd.DialAPI()
+	if err != nil {
+		return err
+	}
 	iamc := iam.NewIAMServiceClient(conn)
-	ctx := cmd.ContextWithToken()
+	ctx, err := cmd.ContextWithToken()
+	if err != nil {
+		return err
+	}
 
 	// Fetch permissions
 	list, err := iamc.GetEffectivePermissions(ctx, &common.URLOptions{Url: url})
 	if err != nil {
-		log.Fatal().Err(err).Msg("Failed to list effective permissions")
+		return cmd.WrapError(err, "Failed to list effective permissions")
 	}
 
 	// Show result
-	fmt.Println(format.PermissionList(list.Items, cmd.RootArgs.Format))
+	return cmd.ShowResult(format.PermissionList(list.Items, cmd.RootArgs.Format))
 }
@@ -23,8 +23,6 @@
 package iam
 
 import (
-	"fmt"
-
 	"github.com/spf13/cobra"
 
 	common "github.com/arangodb-managed/apis/common/v1"
@@ -41,7 +39,7 @@ var (
 	listGroupMembersCmd = &cobra.Command{
 		Use:   "members",
 		Short: "List members of a group the authenticated user is a member of",
-		Run:   listGroupMembersCmdRun,
+		RunE:  listGroupMembersCmdRun,
 	}
 	listGroupMembersArgs struct {
 		groupID        string
@@ -56,27 +54,41 @@ func init() {
 	f.StringVarP(&listGroupMembersArgs.organizationID, "organization-id", "o", cmd.DefaultOrganization(), "Identifier of the organization")
 }
 
-func listGroupMembersCmdRun(c *cobra.Command, args []string) {
+func listGroupMembersCmdRun(c *cobra.Command, args []string) error {
 	// Validate arguments
 	log := cmd.CLILog
 	cargs := listGroupMembersArgs
-	groupID, argsUsed := cmd.ReqOption("group-id", cargs.groupID, args, 0)
-	cmd.MustCheckNumberOfArgs(args, argsUsed)
+	groupID, argsUsed, err := cmd.ReqOption("group-id", cargs.groupID, args, 0)
+	if err != nil {
+		return err
+	}
+	if err := cmd.CheckNumberOfArgs(args, argsUsed); err != nil {
+		return err
+	}
 
 	// Connect
-	conn := cmd.MustDialAPI()
+	conn, err := cmd.DialAPI()
+	if err != nil {
+		return err
+	}
 	iamc := iam.NewIAMServiceClient(conn)
 	rmc := rm.NewResourceManagerServiceClient(conn)
-	ctx := cmd.ContextWithToken()
+	ctx, err := cmd.ContextWithToken()
+	if err != nil {
+		return err
+	}
 
 	// Fetch group
-	group := selection.MustSelectGroup(ctx, log, groupID, cargs.organizationID, iamc, rmc)
+	group, err := selection.SelectGroup(ctx, log, groupID, cargs.organizationID, iamc, rmc)
+	if err != nil {
+		return cmd.WrapError(err, "Failed to get group")
+	}
 
 	list, err := iamc.ListGroupMembers(ctx, &common.ListOptions{ContextId: group.GetId()})
 	if err != nil {
-		log.Fatal().Err(err).Msg("Failed to list group members")
+		return cmd.WrapError(err, "Failed to list group members")
 	}
 
 	// Show result
-	fmt.Println(format.GroupMemberList(ctx, list.GetItems(), iamc, cmd.RootArgs.Format))
+	return cmd.ShowResult(format.GroupMemberList(ctx, list.GetItems(), iamc, cmd.RootArgs.Format))
 }
@@ -23,8 +23,6 @@
 package iam
 
 import (
-	"fmt"
-
 	"github.com/spf13/cobra"
 
 	common "github.com/arangodb-managed/apis/common/v1"
@@ -39,7 +37,7 @@ var (
 	listPermissionsCmd = &cobra.Command{
 		Use:   "permissions",
 		Short: "List the known permissions",
-		Run:   listPermissionsCmdRun,
+		RunE:  listPermissionsCmdRun,
 	}
 	listPermissionsArgs struct {
 	}
@@ -49,21 +47,26 @@ func init() {
 	cmd.ListCmd.AddCommand(listPermissionsCmd)
 }
 
-func listPermissionsCmdRun(c *cobra.Command, args []string) {
+func listPermissionsCmdRun(c *cobra.Command, args []string) error {
 	// Validate arguments
-	log := cmd.CLILog
 
 	// Connect
-	conn := cmd.MustDialAPI()
+	conn, err := cmd.DialAPI()
+	if err != nil {
+		return err
+	}
 	iamc := iam.NewIAMServiceClient(conn)
-	ctx := cmd.ContextWithToken()
+	ctx, err := cmd.ContextWithToken()
+	if err != nil {
+		return err
+	}
 
 	// Fetch permissions
 	list, err := iamc.ListPermissions(ctx, &common.Empty{})
 	if err != nil {
-		log.Fatal().Err(err).Msg("Failed to list permissions")
+		return cmd.WrapError(err, "Failed to list permissions")
 	}
 
 	// Show result
-	fmt.Println(format.PermissionList(list.Items, cmd.RootArgs.Format))
+	return cmd.ShowResult(format.PermissionList(list.Items, cmd.RootArgs.Format))
 }
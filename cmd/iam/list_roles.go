@@ -23,8 +23,6 @@
 package iam
 
 import (
-	"fmt"
-
 	"github.com/spf13/cobra"
 
 	common "github.com/arangodb-managed/apis/common/v1"
@@ -41,7 +39,7 @@ var (
 	listRolesCmd = &cobra.Command{
 		Use:   "roles",
 		Short: "List all roles of the given organization",
-		Run:   listRolesCmdRun,
+		RunE:  listRolesCmdRun,
 	}
 	listRolesArgs struct {
 		organizationID string
@@ -54,28 +52,39 @@ func init() {
 	f.StringVarP(&listRolesArgs.organizationID, "organization-id", "o", cmd.DefaultOrganization(), "Identifier of the organization")
 }
 
-func listRolesCmdRun(c *cobra.Command, args []string) {
+func listRolesCmdRun(c *cobra.Command, args []string) error {
 	// Validate arguments
 	log := cmd.CLILog
 	cargs := listRolesArgs
 	organizationID, argsUsed := cmd.OptOption("organization-id", cargs.organizationID, args, 0)
-	cmd.MustCheckNumberOfArgs(args, argsUsed)
+	if err := cmd.CheckNumberOfArgs(args, argsUsed); err != nil {
+		return err
+	}
 
 	// Connect
-	conn := cmd.MustDialAPI()
+	conn, err := cmd.DialAPI()
+	if err != nil {
+		return err
+	}
 	iamc := iam.NewIAMServiceClient(conn)
 	rmc := rm.NewResourceManagerServiceClient(conn)
-	ctx := cmd.ContextWithToken()
+	ctx, err := cmd.ContextWithToken()
+	if err != nil {
+		return err
+	}
 
 	// Fetch organization
-	org := selection.MustSelectOrganization(ctx, log, organizationID, rmc)
+	org, err := selection.SelectOrganization(ctx, log, organizationID, rmc)
+	if err != nil {
+		return cmd.WrapError(err, "Failed to get organization")
+	}
 
 	// Fetch roles in organization
 	list, err := iamc.ListRoles(ctx, &common.ListOptions{ContextId: org.GetId()})
 	if err != nil {
-		log.Fatal().Err(err).Msg("Failed to list roles")
+		return cmd.WrapError(err, "Failed to list roles")
 	}
 
 	// Show result
-	fmt.Println(format.RoleList(list.Items, cmd.RootArgs.Format))
+	return cmd.ShowResult(format.RoleList(list.Items, cmd.RootArgs.Format))
 }
@@ -53,27 +53,35 @@ func init() {
 			Long:  "Renew the token (resulting from API key authentication)",
 		},
 		func(c *cobra.Command, f *flag.FlagSet) {
-			c.Run = func(c *cobra.Command, args []string) {
+			c.RunE = func(c *cobra.Command, args []string) error {
 				// Validate arguments
-				log := cmd.CLILog
-				cmd.MustCheckNumberOfArgs(args, 0)
+				if err := cmd.CheckNumberOfArgs(args, 0); err != nil {
+					return err
+				}
 
 				// Connect
-				conn := cmd.MustDialAPI()
+				conn, err := cmd.DialAPI()
+				if err != nil {
+					return err
+				}
 				iamc := iam.NewIAMServiceClient(conn)
-				ctx := cmd.ContextWithToken()
+				ctx, err := cmd.ContextWithToken()
+				if err != nil {
+					return err
+				}
 
 				// Renew API key token
 				resp, err := iamc.RenewAPIKeyToken(ctx, &iam.RenewAPIKeyTokenRequest{
 					Token: cmd.RootArgs.Token,
 				})
 				if err != nil {
-					log.Fatal().Err(err).Msg("Failed to renew API key token")
+					return cmd.WrapError(err, "Failed to renew API key token")
 				}
 
 				actualTTL, err := types.DurationFromProto(resp.GetTimeToLive())
 				// Show result
 				fmt.Printf("Renewed API key token! (ttl=%s)\n", actualTTL)
+				return nil
 			}
 		},
 	)
@@ -42,26 +42,34 @@ func init() {
 			Long:  "Revoke the token (resulting from API key authentication)",
 		},
 		func(c *cobra.Command, f *flag.FlagSet) {
-			c.Run = func(c *cobra.Command, args []string) {
+			c.RunE = func(c *cobra.Command, args []string) error {
 				// Validate arguments
-				log := cmd.CLILog
-				cmd.MustCheckNumberOfArgs(args, 0)
+				if err := cmd.CheckNumberOfArgs(args, 0); err != nil {
+					return err
+				}
 
 				// Connect
-				conn := cmd.MustDialAPI()
+				conn, err := cmd.DialAPI()
+				if err != nil {
+					return err
+				}
 				iamc := iam.NewIAMServiceClient(conn)
-				ctx := cmd.ContextWithToken()
+				ctx, err := cmd.ContextWithToken()
+				if err != nil {
+					return err
+				}
 
 				// Revoke API key token
-				_, err := iamc.RevokeAPIKeyToken(ctx, &iam.RevokeAPIKeyTokenRequest{
+				_, err = iamc.RevokeAPIKeyToken(ctx, &iam.RevokeAPIKeyTokenRequest{
 					Token: cmd.RootArgs.Token,
 				})
 				if err != nil {
-					log.Fatal().Err(err).Msg("Failed to revoke API key token")
+					return cmd.WrapError(err, "Failed to revoke API key token")
 				}
 
 				// Show result
 				fmt.Println("Revoked API key token!")
+				return nil
 			}
 		},
 	)
@@ -40,7 +40,7 @@ var (
 	updateGroupCmd = &cobra.Command{
 		Use:   "group",
 		Short: "Update a group the authenticated user has access to",
-		Run:   updateGroupCmdRun,
+		RunE:  updateGroupCmdRun,
 	}
 	updateGroupArgs struct {
 		groupID        string
@@ -59,21 +59,32 @@ func init() {
 	f.StringVar(&updateGroupArgs.description, "description", "", "Description of the group")
 }
 
-func updateGroupCmdRun(c *cobra.Command, args []string) {
+func updateGroupCmdRun(c *cobra.Command, args []string) error {
 	// Validate arguments
 	log := cmd.CLILog
 	cargs := updateGroupArgs
 	groupID, argsUsed := cmd.OptOption("group-id", cargs.groupID, args, 0)
-	cmd.MustCheckNumberOfArgs(args, argsUsed)
+	if err := cmd.CheckNumberOfArgs(args, argsUsed); err != nil {
+		return err
+	}
 
 	// Connect
-	conn := cmd.MustDialAPI()
+	conn, err := cmd.DialAPI()
+	if err != nil {
+		return err
+	}
 	iamc := iam.NewIAMServiceClient(conn)
 	rmc := rm.NewResourceManagerServiceClient(conn)
-	ctx := cmd.ContextWithToken()
+	ctx, err := cmd.ContextWithToken()
+	if err != nil {
+		return err
+	}
 
 	// Fetch group
-	item := selection.MustSelectGroup(ctx, log, groupID, cargs.organizationID, iamc, rmc)
+	item, err := selection.SelectGroup(ctx, log, groupID, cargs.organizationID, iamc, rmc)
+	if err != nil {
+		return cmd.WrapError(err, "Failed to get group")
+	}
 
 	// Set changes
 	f := c.Flags()
@@ -92,11 +103,14 @@ func updateGroupCmdRun(c *cobra.Command, args []string) {
 		// Update group
 		updated, err := iamc.UpdateGroup(ctx, item)
 		if err != nil {
-			log.Fatal().Err(err).Msg("Failed to update group")
+			return cmd.WrapError(err, "Failed to update group")
 		}
 
 		// Show result
 		fmt.Println("Updated group!")
-		fmt.Println(format.Group(updated, cmd.RootArgs.Format))
+		if err := cmd.ShowResult(format.Group(updated, cmd.RootArgs.Format)); err != nil {
+			return err
+		}
 	}
+	return nil
 }
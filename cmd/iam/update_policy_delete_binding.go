@@ -40,7 +40,7 @@ var (
 	updatePolicyDeleteBindingCmd = &cobra.Command{
 		Use:   "binding",
 		Short: "Delete a role binding from a policy",
-		Run:   updatePolicyDeleteBindingCmdRun,
+		RunE:  updatePolicyDeleteBindingCmdRun,
 	}
 	updatePolicyDeleteBindingArgs struct {
 		url      string
@@ -59,35 +59,49 @@ func init() {
 	f.StringSliceVar(&updatePolicyDeleteBindingArgs.groupIDs, "group-id", nil, "Identifiers of the groups to delete bindings for")
 }
 
-func updatePolicyDeleteBindingCmdRun(c *cobra.Command, args []string) {
+func updatePolicyDeleteBindingCmdRun(c *cobra.Command, args []string) error {
 	// Validate arguments
 	log := cmd.CLILog
 	cargs := updatePolicyDeleteBindingArgs
 	url, argsUsed := cmd.OptOption("url", cargs.url, args, 0)
-	roleID, _ := cmd.ReqOption("role-id", cargs.roleID, nil, 0)
-	cmd.MustCheckNumberOfArgs(args, argsUsed)
+	roleID, _, err := cmd.ReqOption("role-id", cargs.roleID, nil, 0)
+	if err != nil {
+		return err
+	}
+	if err := cmd.CheckNumberOfArgs(args, argsUsed); err != nil {
+		return err
+	}
 	if len(cargs.userIDs) == 0 &&
 		len(cargs.groupIDs) == 0 {
-		log.Fatal().Msg("Provide at least one --user-id or --group-id")
+		return cmd.UsageError("Provide at least one --user-id or --group-id")
 	}
 
 	// Connect
-	conn := cmd.MustDialAPI()
+	conn, err := cmd.DialAPI()
+	if err != nil {
+		return err
+	}
 	iamc := iam.NewIAMServiceClient(conn)
 	rmc := rm.NewResourceManagerServiceClient(conn)
-	ctx := cmd.ContextWithToken()
+	ctx, err := cmd.ContextWithToken()
+	if err != nil {
+		return err
+	}
 
 	// Parse URL to get organization ID from URL
 	resURL, err := rm.ParseResourceURL(cargs.url)
 	if err != nil {
-		log.Fatal().Err(err).Msg("Invalid resource URL")
+		return cmd.WrapError(err, "Invalid resource URL")
 	}
 
 	// Get organization ID
 	orgID := resURL.OrganizationID()
 
 	// Fetch role
-	role := selection.MustSelectRole(ctx, log, roleID, orgID, iamc, rmc)
+	role, err := selection.SelectRole(ctx, log, roleID, orgID, iamc, rmc)
+	if err != nil {
+		return cmd.WrapError(err, "Failed to get role")
+	}
 
 	// Add role binding
 	req := &iam.RoleBindingsRequest{
@@ -95,7 +109,10 @@ func updatePolicyDeleteBindingCmdRun(c *cobra.Command, args []string) {
 	}
 	for _, uid := range cargs.userIDs {
 		// Append users
-		item := selection.MustSelectMember(ctx, log, uid, orgID, iamc, rmc)
+		item, err := selection.SelectMember(ctx, log, uid, orgID, iamc, rmc)
+		if err != nil {
+			return cmd.WrapError(err, "Failed to get member")
+		}
 		req.Bindings = append(req.Bindings, &iam.RoleBinding{
 			MemberId: iam.CreateMemberIDFromUserID(item.GetId()),
 			RoleId:   role.GetId(),
@@ -103,7 +120,10 @@ func updatePolicyDeleteBindingCmdRun(c *cobra.Command, args []string) {
 	}
 	for _, gid := range cargs.groupIDs {
 		// Append groups
-		item := selection.MustSelectGroup(ctx, log, gid, orgID, iamc, rmc)
+		item, err := selection.SelectGroup(ctx, log, gid, orgID, iamc, rmc)
+		if err != nil {
+			return cmd.WrapError(err, "Failed to get group")
+		}
 		req.Bindings = append(req.Bindings, &iam.RoleBinding{
 			MemberId: iam.CreateMemberIDFromGroupID(item.GetId()),
 			RoleId:   role.GetId(),
@@ -111,10 +131,10 @@ func updatePolicyDeleteBindingCmdRun(c *cobra.Command, args []string) {
 	}
 	updated, err := iamc.DeleteRoleBindings(ctx, req)
 	if err != nil {
-		log.Fatal().Err(err).Msg("Failed to update policy")
+		return cmd.WrapError(err, "Failed to update policy")
 	}
 
 	// Show result
 	fmt.Println("Updated policy!")
-	fmt.Println(format.Policy(ctx, updated, iamc, cmd.RootArgs.Format))
+	return cmd.ShowResult(format.Policy(ctx, updated, iamc, cmd.RootArgs.Format))
 }
@@ -40,7 +40,7 @@ var (
 	updateRoleCmd = &cobra.Command{
 		Use:   "role",
 		Short: "Update a role the authenticated user has access to",
-		Run:   updateRoleCmdRun,
+		RunE:  updateRoleCmdRun,
 	}
 	updateRoleArgs struct {
 		roleID            string
@@ -63,21 +63,32 @@ func init() {
 	f.StringSliceVar(&updateRoleArgs.removePermissions, "remove-permission", nil, "Permissions to remove from the role")
 }
 
-func updateRoleCmdRun(c *cobra.Command, args []string) {
+func updateRoleCmdRun(c *cobra.Command, args []string) error {
 	// Validate arguments
 	log := cmd.CLILog
 	cargs := updateRoleArgs
 	roleID, argsUsed := cmd.OptOption("role-id", cargs.roleID, args, 0)
-	cmd.MustCheckNumberOfArgs(args, argsUsed)
+	if err := cmd.CheckNumberOfArgs(args, argsUsed); err != nil {
+		return err
+	}
 
 	// Connect
-	conn := cmd.MustDialAPI()
+	conn, err := cmd.DialAPI()
+	if err != nil {
+		return err
+	}
 	iamc := iam.NewIAMServiceClient(conn)
 	rmc := rm.NewResourceManagerServiceClient(conn)
-	ctx := cmd.ContextWithToken()
+	ctx, err := cmd.ContextWithToken()
+	if err != nil {
+		return err
+	}
 
 	// Fetch role
-	item := selection.MustSelectRole(ctx, log, roleID, cargs.organizationID, iamc, rmc)
+	item, err := selection.SelectRole(ctx, log, roleID, cargs.organizationID, iamc, rmc)
+	if err != nil {
+		return cmd.WrapError(err, "Failed to get role")
+	}
 
 	// Set changes
 	f := c.Flags()
@@ -106,13 +117,16 @@ func updateRoleCmdRun(c *cobra.Command, args []string) {
 		// Update role
 		updated, err := iamc.UpdateRole(ctx, item)
 		if err != nil {
-			log.Fatal().Err(err).Msg("Failed to update role")
+			return cmd.WrapError(err, "Failed to update role")
 		}
 
 		// Show result
 		fmt.Println("Updated role!")
-		fmt.Println(format.Role(updated, cmd.RootArgs.Format))
+		if err := cmd.ShowResult(format.Role(updated, cmd.RootArgs.Format)); err != nil {
+			return err
+		}
 	}
+	return nil
 }
 
 // stringSliceUnion returns a union of the elements in both slices.
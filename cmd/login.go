@@ -29,7 +29,7 @@ import (
 	"github.com/spf13/cobra"
 	flag "github.com/spf13/pflag"
 
-	iam "github.com/arangodb-managed/apis/iam/v1"
+	"github.com/arangodb-managed/oasisctl/pkg/config"
 )
 
 var (
@@ -46,39 +46,85 @@ func init() {
 		&cobra.Command{
 			Use:   "login",
 			Short: "Login to ArangoDB Oasis using an API key",
-			Long: `To authenticate in a script environment, run:
+			Long: `Login to ArangoDB Oasis using an API key.
+
+The API key and the resulting token are stored in the credentials file
+(default ~/.config/oasisctl/credentials.yaml, override using OASIS_CREDENTIALS)
+for the current profile. Subsequent commands use the stored token and
+automatically obtain a new token when it is about to expire.
+
+To store an existing token instead, pass it explicitly:
+
+	oasisctl login --token=<your-token>
+
+A token taken from the OASIS_TOKEN environment variable is never stored.
+
+To authenticate in a script environment without storing credentials, run:
 	
-	export OASIS_TOKEN=$(oasisctl login --key-id=<your-key-id> --key-secret=<your-key-secret>)
+	export OASIS_TOKEN=$(oasisctl login --store=false --key-id=<your-key-id> --key-secret=<your-key-secret>)
 `,
 		},
 		func(c *cobra.Command, f *flag.FlagSet) {
 			cargs := &struct {
 				keyID     string
 				keySecret string
+				store     bool
 			}{}
 			f.StringVarP(&cargs.keyID, "key-id", "i", "", "API key identifier")
 			f.StringVarP(&cargs.keySecret, "key-secret", "s", "", "API key secret")
+			f.BoolVar(&cargs.store, "store", true, "Store the credentials for the current profile")
 
-			c.Run = func(c *cobra.Command, args []string) {
+			c.RunE = func(c *cobra.Command, args []string) error {
 				// Validate arguments
 				log := CLILog
-				keyID, argsUsed := ReqOption("key-id", cargs.keyID, args, 0)
-				keySecret, argsUsed := ReqOption("key-secret", cargs.keySecret, args, argsUsed)
-				MustCheckNumberOfArgs(args, argsUsed)
+				pc := &config.ProfileCredentials{}
+				if cargs.keyID == "" && cargs.keySecret == "" && len(args) == 0 && c.Flags().Changed("token") {
+					// Store token given explicitly using --token.
+					// A token from OASIS_TOKEN or the profile is never stored.
+					pc.Token = RootArgs.Token
+				} else {
+					keyID, argsUsed, err := ReqOption("key-id", cargs.keyID, args, 0)
+					if err != nil {
+						return err
+					}
+					keySecret, argsUsed, err := ReqOption("key-secret", cargs.keySecret, args, argsUsed)
+					if err != nil {
+						return err
+					}
+					if err := CheckNumberOfArgs(args, argsUsed); err != nil {
+						return err
+					}
 
-				// Connect
-				conn := MustDialAPI()
-				iamc := iam.NewIAMServiceClient(conn)
-				ctx := context.Background()
+					// Authenticate
+					token, expiresAt, err := authenticateAPIKey(context.Background(), keyID, keySecret)
+					if err != nil {
+						return WrapError(err, "Authentication failed")
+					}
+					pc.KeyID = keyID
+					pc.KeySecret = keySecret
+					pc.Token = token
+					pc.ExpiresAt = expiresAt
+				}
 
-				resp, err := iamc.AuthenticateAPIKey(ctx, &iam.AuthenticateAPIKeyRequest{
-					Id:     keyID,
-					Secret: keySecret,
-				})
-				if err != nil {
-					log.Fatal().Err(err).Msg("Authentication failed")
+				// Store credentials
+				if cargs.store {
+					cfg, err := LoadConfig()
+					if err != nil {
+						return err
+					}
+					profileName := CurrentProfileName(cfg)
+					creds, err := LoadCredentials()
+					if err != nil {
+						return err
+					}
+					creds.SetProfile(profileName, pc)
+					if err := SaveCredentials(creds); err != nil {
+						return err
+					}
+					log.Info().Str("profile", profileName).Msg("Stored credentials")
 				}
-				fmt.Println(resp.GetToken())
+				fmt.Println(pc.Token)
+				return nil
 			}
 		},
 	)
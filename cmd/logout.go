@@ -0,0 +1,82 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Ewout Prangsma
+//
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+)
+
+func init() {
+	InitCommand(
+		RootCmd,
+		&cobra.Command{
+			Use:   "logout",
+			Short: "Remove the stored credentials of the current profile",
+		},
+		func(c *cobra.Command, f *flag.FlagSet) {
+			cargs := &struct {
+				all bool
+			}{}
+			f.BoolVar(&cargs.all, "all", false, "Remove the stored credentials of all profiles")
+
+			c.RunE = func(c *cobra.Command, args []string) error {
+				// Validate arguments
+				if err := CheckNumberOfArgs(args, 0); err != nil {
+					return err
+				}
+
+				// Remove credentials
+				if cargs.all {
+					path, err := CredentialsPath()
+					if err != nil {
+						return err
+					}
+					if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+						return WrapError(err, "Failed to remove credentials")
+					}
+				} else {
+					creds, err := LoadCredentials()
+					if err != nil {
+						return err
+					}
+					cfg, err := LoadConfig()
+					if err != nil {
+						return err
+					}
+					creds.SetProfile(CurrentProfileName(cfg), nil)
+					if err := SaveCredentials(creds); err != nil {
+						return err
+					}
+				}
+
+				// Show result
+				fmt.Println("Logged out")
+				return nil
+			}
+		},
+	)
+}
@@ -23,8 +23,6 @@
 package platform
 
 import (
-	"fmt"
-
 	"github.com/spf13/cobra"
 	flag "github.com/spf13/pflag"
 
@@ -50,22 +48,33 @@ func init() {
 			f.StringVarP(&cargs.organizationID, "organization-id", "o", cmd.DefaultOrganization(), "Optional Identifier of the organization")
 			f.StringVarP(&cargs.providerID, "provider-id", "p", cmd.DefaultProvider(), "Identifier of the provider")
 
-			c.Run = func(c *cobra.Command, args []string) {
+			c.RunE = func(c *cobra.Command, args []string) error {
 				// Validate arguments
 				log := cmd.CLILog
 				providerID, argsUsed := cmd.OptOption("provider-id", cargs.providerID, args, 0)
-				cmd.MustCheckNumberOfArgs(args, argsUsed)
+				if err := cmd.CheckNumberOfArgs(args, argsUsed); err != nil {
+					return err
+				}
 
 				// Connect
-				conn := cmd.MustDialAPI()
+				conn, err := cmd.DialAPI()
+				if err != nil {
+					return err
+				}
 				platformc := platform.NewPlatformServiceClient(conn)
-				ctx := cmd.ContextWithToken()
+				ctx, err := cmd.ContextWithToken()
+				if err != nil {
+					return err
+				}
 
 				// Fetch provider
-				item := selection.MustSelectProvider(ctx, log, providerID, cargs.organizationID, platformc)
+				item, err := selection.SelectProvider(ctx, log, providerID, cargs.organizationID, platformc)
+				if err != nil {
+					return cmd.WrapError(err, "Failed to get provider")
+				}
 
 				// Show result
-				fmt.Println(format.Provider(item, cmd.RootArgs.Format))
+				return cmd.ShowResult(format.Provider(item, cmd.RootArgs.Format))
 			}
 		},
 	)
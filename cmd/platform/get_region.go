@@ -23,8 +23,6 @@
 package platform
 
 import (
-	"fmt"
-
 	"github.com/spf13/cobra"
 	flag "github.com/spf13/pflag"
 
@@ -52,22 +50,33 @@ func init() {
 			f.StringVarP(&cargs.regionID, "region-id", "r", cmd.DefaultRegion(), "Identifier of the region")
 			f.StringVarP(&cargs.providerID, "provider-id", "p", cmd.DefaultProvider(), "Identifier of the provider")
 
-			c.Run = func(c *cobra.Command, args []string) {
+			c.RunE = func(c *cobra.Command, args []string) error {
 				// Validate arguments
 				log := cmd.CLILog
 				regionID, argsUsed := cmd.OptOption("region-id", cargs.regionID, args, 0)
-				cmd.MustCheckNumberOfArgs(args, argsUsed)
+				if err := cmd.CheckNumberOfArgs(args, argsUsed); err != nil {
+					return err
+				}
 
 				// Connect
-				conn := cmd.MustDialAPI()
+				conn, err := cmd.DialAPI()
+				if err != nil {
+					return err
+				}
 				platformc := platform.NewPlatformServiceClient(conn)
-				ctx := cmd.ContextWithToken()
+				ctx, err := cmd.ContextWithToken()
+				if err != nil {
+					return err
+				}
 
 				// Fetch region
-				item := selection.MustSelectRegion(ctx, log, regionID, cargs.providerID, cargs.organizationID, platformc)
+				item, err := selection.SelectRegion(ctx, log, regionID, cargs.providerID, cargs.organizationID, platformc)
+				if err != nil {
+					return cmd.WrapError(err, "Failed to get region")
+				}
 
 				// Show result
-				fmt.Println(format.Region(item, cmd.RootArgs.Format))
+				return cmd.ShowResult(format.Region(item, cmd.RootArgs.Format))
 			}
 		},
 	)
@@ -23,8 +23,6 @@
 package platform
 
 import (
-	"fmt"
-
 	"github.com/spf13/cobra"
 	flag "github.com/spf13/pflag"
 
@@ -49,32 +47,43 @@ func init() {
 				organizationID string
 			}{}
 			f.StringVarP(&cargs.organizationID, "organization-id", "o", cmd.DefaultOrganization(), "Optional Identifier of the organization")
-			c.Run = func(c *cobra.Command, args []string) {
+			c.RunE = func(c *cobra.Command, args []string) error {
 				// Validate arguments
 				log := cmd.CLILog
-				cmd.MustCheckNumberOfArgs(args, 0)
+				if err := cmd.CheckNumberOfArgs(args, 0); err != nil {
+					return err
+				}
 
 				// Connect
-				conn := cmd.MustDialAPI()
+				conn, err := cmd.DialAPI()
+				if err != nil {
+					return err
+				}
 				platformc := platform.NewPlatformServiceClient(conn)
 				rmc := rm.NewResourceManagerServiceClient(conn)
-				ctx := cmd.ContextWithToken()
+				ctx, err := cmd.ContextWithToken()
+				if err != nil {
+					return err
+				}
 
 				var orgID string
 				// Fetch organization
 				if cargs.organizationID != "" {
-					org := selection.MustSelectOrganization(ctx, log, cargs.organizationID, rmc)
+					org, err := selection.SelectOrganization(ctx, log, cargs.organizationID, rmc)
+					if err != nil {
+						return cmd.WrapError(err, "Failed to get organization")
+					}
 					orgID = org.GetId()
 				}
 
 				// Fetch providers
 				list, err := platformc.ListProviders(ctx, &platform.ListProvidersRequest{OrganizationId: orgID, Options: &common.ListOptions{}})
 				if err != nil {
-					log.Fatal().Err(err).Msg("Failed to list providers")
+					return cmd.WrapError(err, "Failed to list providers")
 				}
 
 				// Show result
-				fmt.Println(format.ProviderList(list.Items, cmd.RootArgs.Format))
+				return cmd.ShowResult(format.ProviderList(list.Items, cmd.RootArgs.Format))
 			}
 		},
 	)
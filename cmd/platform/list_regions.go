@@ -23,8 +23,6 @@
 package platform
 
 import (
-	"fmt"
-
 	"github.com/spf13/cobra"
 	flag "github.com/spf13/pflag"
 
@@ -52,36 +50,50 @@ func init() {
 			f.StringVarP(&cargs.organizationID, "organization-id", "o", cmd.DefaultOrganization(), "Optional Identifier of the organization")
 			f.StringVarP(&cargs.providerID, "provider-id", "p", cmd.DefaultProvider(), "Identifier of the provider")
 
-			c.Run = func(c *cobra.Command, args []string) {
+			c.RunE = func(c *cobra.Command, args []string) error {
 				// Validate arguments
 				log := cmd.CLILog
 				providerID, argsUsed := cmd.OptOption("provider-id", cargs.providerID, args, 0)
-				cmd.MustCheckNumberOfArgs(args, argsUsed)
+				if err := cmd.CheckNumberOfArgs(args, argsUsed); err != nil {
+					return err
+				}
 
 				// Connect
-				conn := cmd.MustDialAPI()
+				conn, err := cmd.DialAPI()
+				if err != nil {
+					return err
+				}
 				platformc := platform.NewPlatformServiceClient(conn)
 				rmc := rm.NewResourceManagerServiceClient(conn)
-				ctx := cmd.ContextWithToken()
+				ctx, err := cmd.ContextWithToken()
+				if err != nil {
+					return err
+				}
 
 				var orgID string
 				// Fetch organization
 				if cargs.organizationID != "" {
-					org := selection.MustSelectOrganization(ctx, log, cargs.organizationID, rmc)
+					org, err := selection.SelectOrganization(ctx, log, cargs.organizationID, rmc)
+					if err != nil {
+						return cmd.WrapError(err, "Failed to get organization")
+					}
 					orgID = org.GetId()
 				}
 
 				// Fetch provider
-				provider := selection.MustSelectProvider(ctx, log, providerID, cargs.organizationID, platformc)
+				provider, err := selection.SelectProvider(ctx, log, providerID, cargs.organizationID, platformc)
+				if err != nil {
+					return cmd.WrapError(err, "Failed to get provider")
+				}
 
 				// Fetch regions in provider
 				list, err := platformc.ListRegions(ctx, &platform.ListRegionsRequest{ProviderId: provider.GetId(), OrganizationId: orgID, Options: &common.ListOptions{}})
 				if err != nil {
-					log.Fatal().Err(err).Msg("Failed to list regions")
+					return cmd.WrapError(err, "Failed to list regions")
 				}
 
 				// Show result
-				fmt.Println(format.RegionList(list.Items, cmd.RootArgs.Format))
+				return cmd.ShowResult(format.RegionList(list.Items, cmd.RootArgs.Format))
 			}
 		},
 	)
@@ -40,7 +40,7 @@ var (
 	acceptOrganizationInviteCmd = &cobra.Command{
 		Use:   "invite",
 		Short: "Accept an organization invite the authenticated user has access to",
-		Run:   acceptOrganizationInviteCmdRun,
+		RunE:  acceptOrganizationInviteCmdRun,
 	}
 	acceptOrganizationInviteArgs struct {
 		organizationID string
@@ -55,24 +55,35 @@ func init() {
 	f.StringVarP(&acceptOrganizationInviteArgs.organizationID, "organization-id", "o", cmd.DefaultOrganization(), "Identifier of the organization")
 }
 
-func acceptOrganizationInviteCmdRun(c *cobra.Command, args []string) {
+func acceptOrganizationInviteCmdRun(c *cobra.Command, args []string) error {
 	// Validate arguments
 	log := cmd.CLILog
 	cargs := acceptOrganizationInviteArgs
 	inviteID, argsUsed := cmd.OptOption("invite-id", cargs.inviteID, args, 0)
-	cmd.MustCheckNumberOfArgs(args, argsUsed)
+	if err := cmd.CheckNumberOfArgs(args, argsUsed); err != nil {
+		return err
+	}
 
 	// Connect
-	conn := cmd.MustDialAPI()
+	conn, err := cmd.DialAPI()
+	if err != nil {
+		return err
+	}
 	rmc := rm.NewResourceManagerServiceClient(conn)
-	ctx := cmd.ContextWithToken()
+	ctx, err := cmd.ContextWithToken()
+	if err != nil {
+		return err
+	}
 
 	// Fetch invite
-	invite := selection.MustSelectOrganizationInvite(ctx, log, inviteID, cargs.organizationID, rmc)
+	invite, err := selection.SelectOrganizationInvite(ctx, log, inviteID, cargs.organizationID, rmc)
+	if err != nil {
+		return cmd.WrapError(err, "Failed to get organization invite")
+	}
 
 	// Accept invite
 	if _, err := rmc.AcceptOrganizationInvite(ctx, &common.IDOptions{Id: invite.GetId()}); err != nil {
-		log.Fatal().Err(err).Msg("Failed to accept organization invite")
+		return cmd.WrapError(err, "Failed to accept organization invite")
 	}
 
 	// Fetch organization
@@ -86,4 +97,5 @@ func acceptOrganizationInviteCmdRun(c *cobra.Command, args []string) {
 	// Show result
 	format.DisplaySuccess(cmd.RootArgs.Format)
 	fmt.Printf("You are now a member of the '%s' organization.\n", orgName)
+	return nil
 }
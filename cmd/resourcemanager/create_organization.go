@@ -23,8 +23,6 @@
 package rm
 
 import (
-	"fmt"
-
 	"github.com/spf13/cobra"
 
 	rm "github.com/arangodb-managed/apis/resourcemanager/v1"
@@ -38,7 +36,7 @@ var (
 	createOrganizationCmd = &cobra.Command{
 		Use:   "organization",
 		Short: "Create a new organization",
-		Run:   createOrganizationCmdRun,
+		RunE:  createOrganizationCmdRun,
 	}
 	createOrganizationArgs struct {
 		name        string
@@ -54,18 +52,28 @@ func init() {
 	f.StringVar(&createOrganizationArgs.description, "description", "", "Description of the organization")
 }
 
-func createOrganizationCmdRun(c *cobra.Command, args []string) {
+func createOrganizationCmdRun(c *cobra.Command, args []string) error {
 	// Validate arguments
-	log := cmd.CLILog
 	cargs := createOrganizationArgs
-	name, argsUsed := cmd.ReqOption("name", cargs.name, args, 0)
+	name, argsUsed, err := cmd.ReqOption("name", cargs.name, args, 0)
+	if err != nil {
+		return err
+	}
 	description := cargs.description
-	cmd.MustCheckNumberOfArgs(args, argsUsed)
+	if err := cmd.CheckNumberOfArgs(args, argsUsed); err != nil {
+		return err
+	}
 
 	// Connect
-	conn := cmd.MustDialAPI()
+	conn, err := cmd.DialAPI()
+	if err != nil {
+		return err
+	}
 	rmc := rm.NewResourceManagerServiceClient(conn)
-	ctx := cmd.ContextWithToken()
+	ctx, err := cmd.ContextWithToken()
+	if err != nil {
+		return err
+	}
 
 	// Create organization
 	result, err := rmc.CreateOrganization(ctx, &rm.Organization{
@@ -73,10 +81,10 @@ func createOrganizationCmdRun(c *cobra.Command, args []string) {
 		Description: description,
 	})
 	if err != nil {
-		log.Fatal().Err(err).Msg("Failed to create organization")
+		return cmd.WrapError(err, "Failed to create organization")
 	}
 
 	// Show result
 	format.DisplaySuccess(cmd.RootArgs.Format)
-	fmt.Println(format.Organization(result, cmd.RootArgs.Format))
+	return cmd.ShowResult(format.Organization(result, cmd.RootArgs.Format))
 }
@@ -23,8 +23,6 @@
 package rm
 
 import (
-	"fmt"
-
 	"github.com/spf13/cobra"
 
 	iam "github.com/arangodb-managed/apis/iam/v1"
@@ -40,7 +38,7 @@ var (
 	createOrganizationInviteCmd = &cobra.Command{
 		Use:   "invite",
 		Short: "Create a new invite to an organization",
-		Run:   createOrganizationInviteCmdRun,
+		RunE:  createOrganizationInviteCmdRun,
 	}
 	createOrganizationInviteArgs struct {
 		email          string
@@ -56,21 +54,35 @@ func init() {
 	f.StringVarP(&createOrganizationInviteArgs.organizationID, "organization-id", "o", cmd.DefaultOrganization(), "Identifier of the organization to create the invite in")
 }
 
-func createOrganizationInviteCmdRun(c *cobra.Command, args []string) {
+func createOrganizationInviteCmdRun(c *cobra.Command, args []string) error {
 	// Validate arguments
 	log := cmd.CLILog
 	cargs := createOrganizationInviteArgs
-	email, argsUsed := cmd.ReqOption("email", cargs.email, args, 0)
-	cmd.MustCheckNumberOfArgs(args, argsUsed)
+	email, argsUsed, err := cmd.ReqOption("email", cargs.email, args, 0)
+	if err != nil {
+		return err
+	}
+	if err := cmd.CheckNumberOfArgs(args, argsUsed); err != nil {
+		return err
+	}
 
 	// Connect
-	conn := cmd.MustDialAPI()
+	conn, err := cmd.DialAPI()
+	if err != nil {
+		return err
+	}
 	iamc := iam.NewIAMServiceClient(conn)
 	rmc := rm.NewResourceManagerServiceClient(conn)
-	ctx := cmd.ContextWithToken()
+	ctx, err := cmd.ContextWithToken()
+	if err != nil {
+		return err
+	}
 
 	// Fetch organization
-	org := selection.MustSelectOrganization(ctx, log, cargs.organizationID, rmc)
+	org, err := selection.SelectOrganization(ctx, log, cargs.organizationID, rmc)
+	if err != nil {
+		return cmd.WrapError(err, "Failed to get organization")
+	}
 
 	// Create invite
 	result, err := rmc.CreateOrganizationInvite(ctx, &rm.OrganizationInvite{
@@ -78,10 +90,10 @@ func createOrganizationInviteCmdRun(c *cobra.Command, args []string) {
 		Email:          email,
 	})
 	if err != nil {
-		log.Fatal().Err(err).Msg("Failed to create organization invite")
+		return cmd.WrapError(err, "Failed to create organization invite")
 	}
 
 	// Show result
 	format.DisplaySuccess(cmd.RootArgs.Format)
-	fmt.Println(format.OrganizationInvite(ctx, result, iamc, cmd.RootArgs.Format))
+	return cmd.ShowResult(format.OrganizationInvite(ctx, result, iamc, cmd.RootArgs.Format))
 }
@@ -23,8 +23,6 @@
 package rm
 
 import (
-	"fmt"
-
 	"github.com/spf13/cobra"
 
 	rm "github.com/arangodb-managed/apis/resourcemanager/v1"
@@ -39,7 +37,7 @@ var (
 	createProjectCmd = &cobra.Command{
 		Use:   "project",
 		Short: "Create a new project",
-		Run:   createProjectCmdRun,
+		RunE:  createProjectCmdRun,
 	}
 	createProjectArgs struct {
 		name           string
@@ -57,21 +55,35 @@ func init() {
 	f.StringVarP(&createProjectArgs.organizationID, "organization-id", "o", cmd.DefaultOrganization(), "Identifier of the organization to create the project in")
 }
 
-func createProjectCmdRun(c *cobra.Command, args []string) {
+func createProjectCmdRun(c *cobra.Command, args []string) error {
 	// Validate arguments
 	log := cmd.CLILog
 	cargs := createProjectArgs
-	name, argsUsed := cmd.ReqOption("name", cargs.name, args, 0)
+	name, argsUsed, err := cmd.ReqOption("name", cargs.name, args, 0)
+	if err != nil {
+		return err
+	}
 	description := createProjectArgs.description
-	cmd.MustCheckNumberOfArgs(args, argsUsed)
+	if err := cmd.CheckNumberOfArgs(args, argsUsed); err != nil {
+		return err
+	}
 
 	// Connect
-	conn := cmd.MustDialAPI()
+	conn, err := cmd.DialAPI()
+	if err != nil {
+		return err
+	}
 	rmc := rm.NewResourceManagerServiceClient(conn)
-	ctx := cmd.ContextWithToken()
+	ctx, err := cmd.ContextWithToken()
+	if err != nil {
+		return err
+	}
 
 	// Fetch organization
-	org := selection.MustSelectOrganization(ctx, log, cargs.organizationID, rmc)
+	org, err := selection.SelectOrganization(ctx, log, cargs.organizationID, rmc)
+	if err != nil {
+		return cmd.WrapError(err, "Failed to get organization")
+	}
 
 	// Create project
 	result, err := rmc.CreateProject(ctx, &rm.Project{
@@ -80,10 +92,10 @@ func createProjectCmdRun(c *cobra.Command, args []string) {
 		Description:    description,
 	})
 	if err != nil {
-		log.Fatal().Err(err).Msg("Failed to create project")
+		return cmd.WrapError(err, "Failed to create project")
 	}
 
 	// Show result
 	format.DisplaySuccess(cmd.RootArgs.Format)
-	fmt.Println(format.Project(result, cmd.RootArgs.Format))
+	return cmd.ShowResult(format.Project(result, cmd.RootArgs.Format))
 }
@@ -39,7 +39,7 @@ var (
 	deleteOrganizationCmd = &cobra.Command{
 		Use:   "organization",
 		Short: "Delete an organization the authenticated user has access to",
-		Run:   deleteOrganizationCmdRun,
+		RunE:  deleteOrganizationCmdRun,
 	}
 	deleteOrganizationArgs struct {
 		organizationID string
@@ -52,26 +52,38 @@ func init() {
 	f.StringVarP(&deleteOrganizationArgs.organizationID, "organization-id", "o", cmd.DefaultOrganization(), "Identifier of the organization")
 }
 
-func deleteOrganizationCmdRun(c *cobra.Command, args []string) {
+func deleteOrganizationCmdRun(c *cobra.Command, args []string) error {
 	// Validate arguments
 	log := cmd.CLILog
 	cargs := deleteOrganizationArgs
 	organizationID, argsUsed := cmd.OptOption("organization-id", cargs.organizationID, args, 0)
-	cmd.MustCheckNumberOfArgs(args, argsUsed)
+	if err := cmd.CheckNumberOfArgs(args, argsUsed); err != nil {
+		return err
+	}
 
 	// Connect
-	conn := cmd.MustDialAPI()
+	conn, err := cmd.DialAPI()
+	if err != nil {
+		return err
+	}
 	rmc := rm.NewResourceManagerServiceClient(conn)
-	ctx := cmd.ContextWithToken()
+	ctx, err := cmd.ContextWithToken()
+	if err != nil {
+		return err
+	}
 
 	// Fetch organization
-	item := selection.MustSelectOrganization(ctx, log, organizationID, rmc)
+	item, err := selection.SelectOrganization(ctx, log, organizationID, rmc)
+	if err != nil {
+		return cmd.WrapError(err, "Failed to get organization")
+	}
 
 	// Delete project
 	if _, err := rmc.DeleteOrganization(ctx, &common.IDOptions{Id: item.GetId()}); err != nil {
-		log.Fatal().Err(err).Msg("Failed to delete organization")
+		return cmd.WrapError(err, "Failed to delete organization")
 	}
 
 	// Show result
 	fmt.Println("Deleted organization!")
+	return nil
 }
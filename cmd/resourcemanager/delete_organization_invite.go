@@ -39,7 +39,7 @@ var (
 	deleteOrganizationInviteCmd = &cobra.Command{
 		Use:   "invite",
 		Short: "Delete an organization invite the authenticated user has access to",
-		Run:   deleteOrganizationInviteCmdRun,
+		RunE:  deleteOrganizationInviteCmdRun,
 	}
 	deleteOrganizationInviteArgs struct {
 		organizationID string
@@ -54,26 +54,38 @@ func init() {
 	f.StringVarP(&deleteOrganizationInviteArgs.organizationID, "organization-id", "o", cmd.DefaultOrganization(), "Identifier of the organization")
 }
 
-func deleteOrganizationInviteCmdRun(c *cobra.Command, args []string) {
+func deleteOrganizationInviteCmdRun(c *cobra.Command, args []string) error {
 	// Validate arguments
 	log := cmd.CLILog
 	cargs := deleteOrganizationInviteArgs
 	inviteID, argsUsed := cmd.OptOption("invite-id", cargs.inviteID, args, 0)
-	cmd.MustCheckNumberOfArgs(args, argsUsed)
+	if err := cmd.CheckNumberOfArgs(args, argsUsed); err != nil {
+		return err
+	}
 
 	// Connect
-	conn := cmd.MustDialAPI()
+	conn, err := cmd.DialAPI()
+	if err != nil {
+		return err
+	}
 	rmc := rm.NewResourceManagerServiceClient(conn)
-	ctx := cmd.ContextWithToken()
+	ctx, err := cmd.ContextWithToken()
+	if err != nil {
+		return err
+	}
 
 	// Fetch invite
-	item := selection.MustSelectOrganizationInvite(ctx, log, inviteID, cargs.organizationID, rmc)
+	item, err := selection.SelectOrganizationInvite(ctx, log, inviteID, cargs.organizationID, rmc)
+	if err != nil {
+		return cmd.WrapError(err, "Failed to get organization invite")
+	}
 
 	// Delete invite
 	if _, err := rmc.DeleteOrganizationInvite(ctx, &common.IDOptions{Id: item.GetId()}); err != nil {
-		log.Fatal().Err(err).Msg("Failed to delete organization invite")
+		return cmd.WrapError(err, "Failed to delete organization invite")
 	}
 
 	// Show result
 	fmt.Println("Deleted organization invite!")
+	return nil
 }
@@ -23,6 +23,8 @@
 package rm
 
 import (
+	"fmt"
+
 	"github.com/spf13/cobra"
 
 	common "github.com/arangodb-managed/apis/common/v1"
@@ -39,7 +41,7 @@ var (
 	deleteOrgMembersCmd = &cobra.Command{
 		Use:   "members",
 		Short: "Delete members from organization",
-		Run:   deleteOrgMembersCmdRun,
+		RunE:  deleteOrgMembersCmdRun,
 	}
 	deleteOrgMembersArgs struct {
 		organizationID string
@@ -56,47 +58,61 @@ func init() {
 
 }
 
-func deleteOrgMembersCmdRun(c *cobra.Command, args []string) {
+func deleteOrgMembersCmdRun(c *cobra.Command, args []string) error {
 	// Validate arguments
 	log := cmd.CLILog
 	cargs := deleteOrgMembersArgs
 	organizationID, argsUsed := cmd.OptOption("organization-id", cargs.organizationID, args, 0)
-	cmd.MustCheckNumberOfArgs(args, argsUsed)
+	if err := cmd.CheckNumberOfArgs(args, argsUsed); err != nil {
+		return err
+	}
 
 	log.Info().Msgf("Deleting members: %s", cargs.userEmails)
 	// Connect
-	conn := cmd.MustDialAPI()
+	conn, err := cmd.DialAPI()
+	if err != nil {
+		return err
+	}
 	iamc := iam.NewIAMServiceClient(conn)
 	rmc := rm.NewResourceManagerServiceClient(conn)
-	ctx := cmd.ContextWithToken()
+	ctx, err := cmd.ContextWithToken()
+	if err != nil {
+		return err
+	}
 
-	organization := selection.MustSelectOrganization(ctx, log, organizationID, rmc)
+	organization, err := selection.SelectOrganization(ctx, log, organizationID, rmc)
+
+	if err != nil {
+
+		return cmd.WrapError(err, "Failed to get organization")
+
+	}
 
 	org, err := rmc.GetOrganization(ctx, &common.IDOptions{Id: organization.Id})
 	if err != nil {
-		log.Fatal().Err(err).Msg("Failed to get organization.")
+		return cmd.WrapError(err, "Failed to get organization.")
 	}
 	if org.IsDeleted {
-		log.Fatal().Str("organization_id", organization.Id).Msg("May not delete members from deleted organization.")
+		return fmt.Errorf("May not delete members from deleted organization %s.", organization.Id)
 	}
 
 	membersToDelete := &rm.MemberList{Items: make([]*rm.Member, 0)}
 	members, err := rmc.ListOrganizationMembers(ctx, &common.ListOptions{ContextId: organization.Id})
 	if err != nil {
-		log.Fatal().Err(err).Msg("Failed to list organization members.")
+		return cmd.WrapError(err, "Failed to list organization members.")
 	}
 	emailIDMap := make(map[string]string)
 	for _, u := range members.Items {
 		user, err := iamc.GetUser(ctx, &common.IDOptions{Id: u.UserId})
 		if err != nil {
-			log.Fatal().Err(err).Msg("Failed to get user")
+			return cmd.WrapError(err, "Failed to get user")
 		}
 		emailIDMap[user.Email] = user.Id
 	}
 
 	for _, e := range *cargs.userEmails {
 		if id, ok := emailIDMap[e]; !ok {
-			log.Fatal().Str("email", e).Str("organization-id", organization.Id).Msg("User is not a member of the organization.")
+			return fmt.Errorf("User %s is not a member of the organization %s.", e, organization.Id)
 		} else {
 			membersToDelete.Items = append(membersToDelete.Items, &rm.Member{UserId: id})
 		}
@@ -106,8 +122,9 @@ func deleteOrgMembersCmdRun(c *cobra.Command, args []string) {
 		OrganizationId: organization.Id,
 		Members:        membersToDelete,
 	}); err != nil {
-		log.Fatal().Err(err).Msg("Failed to delete users.")
+		return cmd.WrapError(err, "Failed to delete users.")
 	}
 
 	format.DisplaySuccess(cmd.RootArgs.Format)
+	return nil
 }
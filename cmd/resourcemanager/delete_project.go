@@ -39,7 +39,7 @@ var (
 	deleteProjectCmd = &cobra.Command{
 		Use:   "project",
 		Short: "Delete a project the authenticated user has access to",
-		Run:   deleteProjectCmdRun,
+		RunE:  deleteProjectCmdRun,
 	}
 	deleteProjectArgs struct {
 		organizationID string
@@ -54,26 +54,38 @@ func init() {
 	f.StringVarP(&deleteProjectArgs.organizationID, "organization-id", "o", cmd.DefaultOrganization(), "Identifier of the organization")
 }
 
-func deleteProjectCmdRun(c *cobra.Command, args []string) {
+func deleteProjectCmdRun(c *cobra.Command, args []string) error {
 	// Validate arguments
 	log := cmd.CLILog
 	cargs := deleteProjectArgs
 	projectID, argsUsed := cmd.OptOption("project-id", cargs.projectID, args, 0)
-	cmd.MustCheckNumberOfArgs(args, argsUsed)
+	if err := cmd.CheckNumberOfArgs(args, argsUsed); err != nil {
+		return err
+	}
 
 	// Connect
-	conn := cmd.MustDialAPI()
+	conn, err := cmd.DialAPI()
+	if err != nil {
+		return err
+	}
 	rmc := rm.NewResourceManagerServiceClient(conn)
-	ctx := cmd.ContextWithToken()
+	ctx, err := cmd.ContextWithToken()
+	if err != nil {
+		return err
+	}
 
 	// Fetch project
-	item := selection.MustSelectProject(ctx, log, projectID, cargs.organizationID, rmc)
+	item, err := selection.SelectProject(ctx, log, projectID, cargs.organizationID, rmc)
+	if err != nil {
+		return cmd.WrapError(err, "Failed to get project")
+	}
 
 	// Delete project
 	if _, err := rmc.DeleteProject(ctx, &common.IDOptions{Id: item.GetId()}); err != nil {
-		log.Fatal().Err(err).Msg("Failed to delete project")
+		return cmd.WrapError(err, "Failed to delete project")
 	}
 
 	// Show result
 	fmt.Println("Deleted project!")
+	return nil
 }
@@ -23,8 +23,6 @@
 package rm
 
 import (
-	"fmt"
-
 	"github.com/spf13/cobra"
 
 	rm "github.com/arangodb-managed/apis/resourcemanager/v1"
@@ -39,7 +37,7 @@ var (
 	getOrganizationCmd = &cobra.Command{
 		Use:   "organization",
 		Short: "Get an organization the authenticated user is a member of",
-		Run:   getOrganizationCmdRun,
+		RunE:  getOrganizationCmdRun,
 	}
 	getOrganizationArgs struct {
 		organizationID string
@@ -52,21 +50,32 @@ func init() {
 	f.StringVarP(&getOrganizationArgs.organizationID, "organization-id", "o", cmd.DefaultOrganization(), "Identifier of the organization")
 }
 
-func getOrganizationCmdRun(c *cobra.Command, args []string) {
+func getOrganizationCmdRun(c *cobra.Command, args []string) error {
 	// Validate arguments
 	log := cmd.CLILog
 	cargs := getOrganizationArgs
 	organizationID, argsUsed := cmd.OptOption("organization-id", cargs.organizationID, args, 0)
-	cmd.MustCheckNumberOfArgs(args, argsUsed)
+	if err := cmd.CheckNumberOfArgs(args, argsUsed); err != nil {
+		return err
+	}
 
 	// Connect
-	conn := cmd.MustDialAPI()
+	conn, err := cmd.DialAPI()
+	if err != nil {
+		return err
+	}
 	rmc := rm.NewResourceManagerServiceClient(conn)
-	ctx := cmd.ContextWithToken()
+	ctx, err := cmd.ContextWithToken()
+	if err != nil {
+		return err
+	}
 
 	// Fetch organization
-	item := selection.MustSelectOrganization(ctx, log, organizationID, rmc)
+	item, err := selection.SelectOrganization(ctx, log, organizationID, rmc)
+	if err != nil {
+		return cmd.WrapError(err, "Failed to get organization")
+	}
 
 	// Show result
-	fmt.Println(format.Organization(item, cmd.RootArgs.Format))
+	return cmd.ShowResult(format.Organization(item, cmd.RootArgs.Format))
 }
@@ -23,8 +23,6 @@
 package rm
 
 import (
-	"fmt"
-
 	"github.com/spf13/cobra"
 
 	iam "github.com/arangodb-managed/apis/iam/v1"
@@ -40,7 +38,7 @@ var (
 	getOrganizationInviteCmd = &cobra.Command{
 		Use:   "invite",
 		Short: "Get an organization invite the authenticated user has access to",
-		Run:   getOrganizationInviteCmdRun,
+		RunE:  getOrganizationInviteCmdRun,
 	}
 	getOrganizationInviteArgs struct {
 		organizationID string
@@ -55,22 +53,33 @@ func init() {
 	f.StringVarP(&getOrganizationInviteArgs.organizationID, "organization-id", "o", cmd.DefaultOrganization(), "Identifier of the organization")
 }
 
-func getOrganizationInviteCmdRun(c *cobra.Command, args []string) {
+func getOrganizationInviteCmdRun(c *cobra.Command, args []string) error {
 	// Validate arguments
 	log := cmd.CLILog
 	cargs := getOrganizationInviteArgs
 	inviteID, argsUsed := cmd.OptOption("invite-id", cargs.inviteID, args, 0)
-	cmd.MustCheckNumberOfArgs(args, argsUsed)
+	if err := cmd.CheckNumberOfArgs(args, argsUsed); err != nil {
+		return err
+	}
 
 	// Connect
-	conn := cmd.MustDialAPI()
+	conn, err := cmd.DialAPI()
+	if err != nil {
+		return err
+	}
 	iamc := iam.NewIAMServiceClient(conn)
 	rmc := rm.NewResourceManagerServiceClient(conn)
-	ctx := cmd.ContextWithToken()
+	ctx, err := cmd.ContextWithToken()
+	if err != nil {
+		return err
+	}
 
 	// Fetch organization invite
-	item := selection.MustSelectOrganizationInvite(ctx, log, inviteID, cargs.organizationID, rmc)
+	item, err := selection.SelectOrganizationInvite(ctx, log, inviteID, cargs.organizationID, rmc)
+	if err != nil {
+		return cmd.WrapError(err, "Failed to get organization invite")
+	}
 
 	// Show result
-	fmt.Println(format.OrganizationInvite(ctx, item, iamc, cmd.RootArgs.Format))
+	return cmd.ShowResult(format.OrganizationInvite(ctx, item, iamc, cmd.RootArgs.Format))
 }
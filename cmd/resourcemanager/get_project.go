@@ -23,8 +23,6 @@
 package rm
 
 import (
-	"fmt"
-
 	"github.com/spf13/cobra"
 
 	rm "github.com/arangodb-managed/apis/resourcemanager/v1"
@@ -39,7 +37,7 @@ var (
 	getProjectCmd = &cobra.Command{
 		Use:   "project",
 		Short: "Get a project the authenticated user has access to",
-		Run:   getProjectCmdRun,
+		RunE:  getProjectCmdRun,
 	}
 	getProjectArgs struct {
 		organizationID string
@@ -54,21 +52,32 @@ func init() {
 	f.StringVarP(&getProjectArgs.organizationID, "organization-id", "o", cmd.DefaultOrganization(), "Identifier of the organization")
 }
 
-func getProjectCmdRun(c *cobra.Command, args []string) {
+func getProjectCmdRun(c *cobra.Command, args []string) error {
 	// Validate arguments
 	log := cmd.CLILog
 	cargs := getProjectArgs
 	projectID, argsUsed := cmd.OptOption("project-id", cargs.projectID, args, 0)
-	cmd.MustCheckNumberOfArgs(args, argsUsed)
+	if err := cmd.CheckNumberOfArgs(args, argsUsed); err != nil {
+		return err
+	}
 
 	// Connect
-	conn := cmd.MustDialAPI()
+	conn, err := cmd.DialAPI()
+	if err != nil {
+		return err
+	}
 	rmc := rm.NewResourceManagerServiceClient(conn)
-	ctx := cmd.ContextWithToken()
+	ctx, err := cmd.ContextWithToken()
+	if err != nil {
+		return err
+	}
 
 	// Fetch project
-	item := selection.MustSelectProject(ctx, log, projectID, cargs.organizationID, rmc)
+	item, err := selection.SelectProject(ctx, log, projectID, cargs.organizationID, rmc)
+	if err != nil {
+		return cmd.WrapError(err, "Failed to get project")
+	}
 
 	// Show result
-	fmt.Println(format.Project(item, cmd.RootArgs.Format))
+	return cmd.ShowResult(format.Project(item, cmd.RootArgs.Format))
 }
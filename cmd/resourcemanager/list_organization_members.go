@@ -23,8 +23,6 @@
 package rm
 
 import (
-	"fmt"
-
 	"github.com/spf13/cobra"
 
 	common "github.com/arangodb-managed/apis/common/v1"
@@ -41,7 +39,7 @@ var (
 	listOrganizationMembersCmd = &cobra.Command{
 		Use:   "members",
 		Short: "List members of an organization the authenticated user is a member of",
-		Run:   listOrganizationMembersCmdRun,
+		RunE:  listOrganizationMembersCmdRun,
 	}
 	listOrganizationMembersArgs struct {
 		organizationID string
@@ -54,27 +52,38 @@ func init() {
 	f.StringVarP(&listOrganizationMembersArgs.organizationID, "organization-id", "o", cmd.DefaultOrganization(), "Identifier of the organization")
 }
 
-func listOrganizationMembersCmdRun(c *cobra.Command, args []string) {
+func listOrganizationMembersCmdRun(c *cobra.Command, args []string) error {
 	// Validate arguments
 	log := cmd.CLILog
 	cargs := listOrganizationMembersArgs
 	organizationID, argsUsed := cmd.OptOption("organization-id", cargs.organizationID, args, 0)
-	cmd.MustCheckNumberOfArgs(args, argsUsed)
+	if err := cmd.CheckNumberOfArgs(args, argsUsed); err != nil {
+		return err
+	}
 
 	// Connect
-	conn := cmd.MustDialAPI()
+	conn, err := cmd.DialAPI()
+	if err != nil {
+		return err
+	}
 	iamc := iam.NewIAMServiceClient(conn)
 	rmc := rm.NewResourceManagerServiceClient(conn)
-	ctx := cmd.ContextWithToken()
+	ctx, err := cmd.ContextWithToken()
+	if err != nil {
+		return err
+	}
 
 	// Fetch organization
-	org := selection.MustSelectOrganization(ctx, log, organizationID, rmc)
+	org, err := selection.SelectOrganization(ctx, log, organizationID, rmc)
+	if err != nil {
+		return cmd.WrapError(err, "Failed to get organization")
+	}
 
 	list, err := rmc.ListOrganizationMembers(ctx, &common.ListOptions{ContextId: org.GetId()})
 	if err != nil {
-		log.Fatal().Err(err).Msg("Failed to list organization members")
+		return cmd.WrapError(err, "Failed to list organization members")
 	}
 
 	// Show result
-	fmt.Println(format.OrganizationMemberList(ctx, list.GetItems(), iamc, cmd.RootArgs.Format))
+	return cmd.ShowResult(format.OrganizationMemberList(ctx, list.GetItems(), iamc, cmd.RootArgs.Format))
 }
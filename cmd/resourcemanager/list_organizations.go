@@ -23,8 +23,6 @@
 package rm
 
 import (
-	"fmt"
-
 	"github.com/spf13/cobra"
 
 	common "github.com/arangodb-managed/apis/common/v1"
@@ -39,7 +37,7 @@ var (
 	listOrganizationsCmd = &cobra.Command{
 		Use:   "organizations",
 		Short: "List all organizations the authenticated user is a member of",
-		Run:   listOrganizationsCmdRun,
+		RunE:  listOrganizationsCmdRun,
 	}
 )
 
@@ -47,22 +45,29 @@ func init() {
 	cmd.ListCmd.AddCommand(listOrganizationsCmd)
 }
 
-func listOrganizationsCmdRun(c *cobra.Command, args []string) {
+func listOrganizationsCmdRun(c *cobra.Command, args []string) error {
 	// Validate arguments
-	log := cmd.CLILog
-	cmd.MustCheckNumberOfArgs(args, 0)
+	if err := cmd.CheckNumberOfArgs(args, 0); err != nil {
+		return err
+	}
 
 	// Connect
-	conn := cmd.MustDialAPI()
+	conn, err := cmd.DialAPI()
+	if err != nil {
+		return err
+	}
 	rmc := rm.NewResourceManagerServiceClient(conn)
-	ctx := cmd.ContextWithToken()
+	ctx, err := cmd.ContextWithToken()
+	if err != nil {
+		return err
+	}
 
 	// Fetch organizations
 	list, err := rmc.ListOrganizations(ctx, &common.ListOptions{})
 	if err != nil {
-		log.Fatal().Err(err).Msg("Failed to list organizations")
+		return cmd.WrapError(err, "Failed to list organizations")
 	}
 
 	// Show result
-	fmt.Println(format.OrganizationList(list.Items, cmd.RootArgs.Format))
+	return cmd.ShowResult(format.OrganizationList(list.Items, cmd.RootArgs.Format))
 }
@@ -23,8 +23,6 @@
 package rm
 
 import (
-	"fmt"
-
 	"github.com/spf13/cobra"
 
 	common "github.com/arangodb-managed/apis/common/v1"
@@ -40,7 +38,7 @@ var (
 	listProjectsCmd = &cobra.Command{
 		Use:   "projects",
 		Short: "List all projects of the given organization",
-		Run:   listProjectsCmdRun,
+		RunE:  listProjectsCmdRun,
 	}
 	listProjectsArgs struct {
 		organizationID string
@@ -53,27 +51,38 @@ func init() {
 	f.StringVarP(&listProjectsArgs.organizationID, "organization-id", "o", cmd.DefaultOrganization(), "Identifier of the organization")
 }
 
-func listProjectsCmdRun(c *cobra.Command, args []string) {
+func listProjectsCmdRun(c *cobra.Command, args []string) error {
 	// Validate arguments
 	log := cmd.CLILog
 	cargs := listProjectsArgs
 	organizationID, argsUsed := cmd.OptOption("organization-id", cargs.organizationID, args, 0)
-	cmd.MustCheckNumberOfArgs(args, argsUsed)
+	if err := cmd.CheckNumberOfArgs(args, argsUsed); err != nil {
+		return err
+	}
 
 	// Connect
-	conn := cmd.MustDialAPI()
+	conn, err := cmd.DialAPI()
+	if err != nil {
+		return err
+	}
 	rmc := rm.NewResourceManagerServiceClient(conn)
-	ctx := cmd.ContextWithToken()
+	ctx, err := cmd.ContextWithToken()
+	if err != nil {
+		return err
+	}
 
 	// Fetch organization
-	org := selection.MustSelectOrganization(ctx, log, organizationID, rmc)
+	org, err := selection.SelectOrganization(ctx, log, organizationID, rmc)
+	if err != nil {
+		return cmd.WrapError(err, "Failed to get organization")
+	}
 
 	// Fetch projects in organization
 	list, err := rmc.ListProjects(ctx, &common.ListOptions{ContextId: org.GetId()})
 	if err != nil {
-		log.Fatal().Err(err).Msg("Failed to list projects")
+		return cmd.WrapError(err, "Failed to list projects")
 	}
 
 	// Show result
-	fmt.Println(format.ProjectList(list.Items, cmd.RootArgs.Format))
+	return cmd.ShowResult(format.ProjectList(list.Items, cmd.RootArgs.Format))
 }
@@ -40,7 +40,7 @@ var (
 	rejectOrganizationInviteCmd = &cobra.Command{
 		Use:   "invite",
 		Short: "Reject an organization invite the authenticated user has access to",
-		Run:   rejectOrganizationInviteCmdRun,
+		RunE:  rejectOrganizationInviteCmdRun,
 	}
 	rejectOrganizationInviteArgs struct {
 		organizationID string
@@ -55,27 +55,39 @@ func init() {
 	f.StringVarP(&rejectOrganizationInviteArgs.organizationID, "organization-id", "o", cmd.DefaultOrganization(), "Identifier of the organization")
 }
 
-func rejectOrganizationInviteCmdRun(c *cobra.Command, args []string) {
+func rejectOrganizationInviteCmdRun(c *cobra.Command, args []string) error {
 	// Validate arguments
 	log := cmd.CLILog
 	cargs := rejectOrganizationInviteArgs
 	inviteID, argsUsed := cmd.OptOption("invite-id", cargs.inviteID, args, 0)
-	cmd.MustCheckNumberOfArgs(args, argsUsed)
+	if err := cmd.CheckNumberOfArgs(args, argsUsed); err != nil {
+		return err
+	}
 
 	// Connect
-	conn := cmd.MustDialAPI()
+	conn, err := cmd.DialAPI()
+	if err != nil {
+		return err
+	}
 	rmc := rm.NewResourceManagerServiceClient(conn)
-	ctx := cmd.ContextWithToken()
+	ctx, err := cmd.ContextWithToken()
+	if err != nil {
+		return err
+	}
 
 	// Fetch invite
-	invite := selection.MustSelectOrganizationInvite(ctx, log, inviteID, cargs.organizationID, rmc)
+	invite, err := selection.SelectOrganizationInvite(ctx, log, inviteID, cargs.organizationID, rmc)
+	if err != nil {
+		return cmd.WrapError(err, "Failed to get organization invite")
+	}
 
 	// Reject invite
 	if _, err := rmc.RejectOrganizationInvite(ctx, &common.IDOptions{Id: invite.GetId()}); err != nil {
-		log.Fatal().Err(err).Msg("Failed to reject organization invite")
+		return cmd.WrapError(err, "Failed to reject organization invite")
 	}
 
 	// Show result
 	format.DisplaySuccess(cmd.RootArgs.Format)
 	fmt.Println("You have rejected the invite.")
+	return nil
 }
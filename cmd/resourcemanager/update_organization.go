@@ -39,7 +39,7 @@ var (
 	updateOrganizationCmd = &cobra.Command{
 		Use:   "organization",
 		Short: "Update an organization the authenticated user has access to",
-		Run:   updateOrganizationCmdRun,
+		RunE:  updateOrganizationCmdRun,
 	}
 	updateOrganizationArgs struct {
 		organizationID string
@@ -56,20 +56,31 @@ func init() {
 	f.StringVar(&updateOrganizationArgs.description, "description", "", "Description of the organization")
 }
 
-func updateOrganizationCmdRun(c *cobra.Command, args []string) {
+func updateOrganizationCmdRun(c *cobra.Command, args []string) error {
 	// Validate arguments
 	log := cmd.CLILog
 	cargs := updateOrganizationArgs
 	organizationID, argsUsed := cmd.OptOption("organization-id", cargs.organizationID, args, 0)
-	cmd.MustCheckNumberOfArgs(args, argsUsed)
+	if err := cmd.CheckNumberOfArgs(args, argsUsed); err != nil {
+		return err
+	}
 
 	// Connect
-	conn := cmd.MustDialAPI()
+	conn, err := cmd.DialAPI()
+	if err != nil {
+		return err
+	}
 	rmc := rm.NewResourceManagerServiceClient(conn)
-	ctx := cmd.ContextWithToken()
+	ctx, err := cmd.ContextWithToken()
+	if err != nil {
+		return err
+	}
 
 	// Fetch organization
-	item := selection.MustSelectOrganization(ctx, log, organizationID, rmc)
+	item, err := selection.SelectOrganization(ctx, log, organizationID, rmc)
+	if err != nil {
+		return cmd.WrapError(err, "Failed to get organization")
+	}
 
 	// Set changes
 	f := c.Flags()
@@ -88,11 +99,14 @@ func updateOrganizationCmdRun(c *cobra.Command, args []string) {
 		// Update project
 		updated, err := rmc.UpdateOrganization(ctx, item)
 		if err != nil {
-			log.Fatal().Err(err).Msg("Failed to update organization")
+			return cmd.WrapError(err, "Failed to update organization")
 		}
 
 		// Show result
 		fmt.Println("Updated organization!")
-		fmt.Println(format.Organization(updated, cmd.RootArgs.Format))
+		if err := cmd.ShowResult(format.Organization(updated, cmd.RootArgs.Format)); err != nil {
+			return err
+		}
 	}
+	return nil
 }
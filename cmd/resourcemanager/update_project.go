@@ -39,7 +39,7 @@ var (
 	updateProjectCmd = &cobra.Command{
 		Use:   "project",
 		Short: "Update a project the authenticated user has access to",
-		Run:   updateProjectCmdRun,
+		RunE:  updateProjectCmdRun,
 	}
 	updateProjectArgs struct {
 		projectID      string
@@ -58,20 +58,31 @@ func init() {
 	f.StringVar(&updateProjectArgs.description, "description", "", "Description of the project")
 }
 
-func updateProjectCmdRun(c *cobra.Command, args []string) {
+func updateProjectCmdRun(c *cobra.Command, args []string) error {
 	// Validate arguments
 	log := cmd.CLILog
 	cargs := updateProjectArgs
 	projectID, argsUsed := cmd.OptOption("project-id", cargs.projectID, args, 0)
-	cmd.MustCheckNumberOfArgs(args, argsUsed)
+	if err := cmd.CheckNumberOfArgs(args, argsUsed); err != nil {
+		return err
+	}
 
 	// Connect
-	conn := cmd.MustDialAPI()
+	conn, err := cmd.DialAPI()
+	if err != nil {
+		return err
+	}
 	rmc := rm.NewResourceManagerServiceClient(conn)
-	ctx := cmd.ContextWithToken()
+	ctx, err := cmd.ContextWithToken()
+	if err != nil {
+		return err
+	}
 
 	// Fetch project
-	item := selection.MustSelectProject(ctx, log, projectID, cargs.organizationID, rmc)
+	item, err := selection.SelectProject(ctx, log, projectID, cargs.organizationID, rmc)
+	if err != nil {
+		return cmd.WrapError(err, "Failed to get project")
+	}
 
 	// Set changes
 	f := c.Flags()
@@ -90,11 +101,14 @@ func updateProjectCmdRun(c *cobra.Command, args []string) {
 		// Update project
 		updated, err := rmc.UpdateProject(ctx, item)
 		if err != nil {
-			log.Fatal().Err(err).Msg("Failed to update project")
+			return cmd.WrapError(err, "Failed to update project")
 		}
 
 		// Show result
 		fmt.Println("Updated project!")
-		fmt.Println(format.Project(updated, cmd.RootArgs.Format))
+		if err := cmd.ShowResult(format.Project(updated, cmd.RootArgs.Format)); err != nil {
+			return err
+		}
 	}
+	return nil
 }
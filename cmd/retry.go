@@ -0,0 +1,189 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Ewout Prangsma
+//
+
+package cmd
+
+import (
+	"context"
+	"math/rand"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// Delay before the first retry of an API call.
+	retryInitialBackoff = 250 * time.Millisecond
+	// Maximum delay between retries of an API call.
+	retryMaxBackoff = 5 * time.Second
+)
+
+// isIdempotent returns true if the API method with given full name
+// (/package.Service/Method) can safely be retried.
+func isIdempotent(fullMethod string) bool {
+	method := fullMethod[strings.LastIndex(fullMethod, "/")+1:]
+	return strings.HasPrefix(method, "Get") || strings.HasPrefix(method, "List")
+}
+
+// IsRetryable returns true if an API call that failed with given error
+// can be retried with the given (parent) context.
+func IsRetryable(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		// Cancelled or overall deadline exceeded
+		return false
+	}
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return true
+	}
+	return false
+}
+
+// retryBackoff returns the jittered exponential delay before the given retry (1...).
+func retryBackoff(retry int) time.Duration {
+	backoff := retryInitialBackoff
+	for i := 1; i < retry && backoff < retryMaxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > retryMaxBackoff {
+		backoff = retryMaxBackoff
+	}
+	// Pick a random delay in [backoff/2, backoff)
+	return backoff/2 + time.Duration(rand.Int63n(int64(backoff/2)))
+}
+
+// waitForRetry waits for the backoff of the given retry, or until the context is done.
+func waitForRetry(ctx context.Context, fullMethod string, retry int, err error) error {
+	backoff := retryBackoff(retry)
+	CLILog.Debug().
+		Str("method", fullMethod).
+		Int("retry", retry).
+		Str("backoff", backoff.String()).
+		Str("error", err.Error()).
+		Msg("API call failed, retrying")
+	select {
+	case <-time.After(backoff):
+		return nil
+	case <-ctx.Done():
+		return err
+	}
+}
+
+// retryUnaryInterceptor returns a client interceptor that applies the given
+// timeout to every attempt of a unary API call and retries idempotent calls
+// at most the given number of times.
+func retryUnaryInterceptor(timeout time.Duration, retries int) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		maxRetries := 0
+		if isIdempotent(method) {
+			maxRetries = retries
+		}
+		for retry := 0; ; retry++ {
+			attemptCtx, cancel := ctx, context.CancelFunc(func() {})
+			if timeout > 0 {
+				attemptCtx, cancel = context.WithTimeout(ctx, timeout)
+			}
+			err := invoker(attemptCtx, method, req, reply, cc, opts...)
+			cancel()
+			if err == nil || retry >= maxRetries || !IsRetryable(ctx, err) {
+				return err
+			}
+			if err := waitForRetry(ctx, method, retry+1, err); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// retryStreamInterceptor returns a client interceptor that retries
+// idempotent server streaming API calls at most the given number of times,
+// as long as no message has been received.
+func retryStreamInterceptor(retries int) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		stream, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil || desc.ClientStreams || !isIdempotent(method) || retries == 0 {
+			return stream, err
+		}
+		return &retryClientStream{
+			ClientStream: stream,
+			ctx:          ctx,
+			desc:         desc,
+			cc:           cc,
+			method:       method,
+			streamer:     streamer,
+			opts:         opts,
+			retries:      retries,
+		}, nil
+	}
+}
+
+// retryClientStream is a server streaming call that is restarted when
+// receiving the first message fails with a retryable error.
+type retryClientStream struct {
+	grpc.ClientStream
+	ctx      context.Context
+	desc     *grpc.StreamDesc
+	cc       *grpc.ClientConn
+	method   string
+	streamer grpc.Streamer
+	opts     []grpc.CallOption
+	retries  int
+	req      interface{}
+	received bool
+}
+
+// SendMsg sends the request and remembers it for a restart.
+func (s *retryClientStream) SendMsg(m interface{}) error {
+	s.req = m
+	return s.ClientStream.SendMsg(m)
+}
+
+// RecvMsg receives the next message, restarting the call when needed.
+func (s *retryClientStream) RecvMsg(m interface{}) error {
+	for retry := 1; ; retry++ {
+		err := s.ClientStream.RecvMsg(m)
+		if err == nil {
+			s.received = true
+			return nil
+		}
+		if s.received || s.req == nil || retry > s.retries || !IsRetryable(s.ctx, err) {
+			return err
+		}
+		if err := waitForRetry(s.ctx, s.method, retry, err); err != nil {
+			return err
+		}
+		stream, err := s.streamer(s.ctx, s.desc, s.cc, s.method, s.opts...)
+		if err != nil {
+			return err
+		}
+		if err := stream.SendMsg(s.req); err != nil {
+			return err
+		}
+		if err := stream.CloseSend(); err != nil {
+			return err
+		}
+		s.ClientStream = stream
+	}
+}
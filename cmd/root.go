@@ -23,9 +23,21 @@
 package cmd
 
 import (
+	"bufio"
 	"context"
 	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/rs/zerolog"
 	"github.com/spf13/cobra"
@@ -36,42 +48,112 @@ import (
 	"github.com/arangodb-managed/apis/common/auth"
 
 	"github.com/arangodb-managed/oasisctl/pkg/format"
+	"github.com/arangodb-managed/oasisctl/pkg/util"
 )
 
 var (
 	// RootCmd is the root (and only) command of this service
 	RootCmd = &cobra.Command{
-		Use:              "oasisctl",
-		Short:            "ArangoDB Oasis",
-		Long:             "ArangoDB Oasis. The Managed Cloud for ArangoDB",
-		Run:              ShowUsage,
-		PersistentPreRun: rootCmdPersistentPreRun,
+		Use:               "oasisctl",
+		Short:             "ArangoDB Oasis",
+		Long:              "ArangoDB Oasis. The Managed Cloud for ArangoDB",
+		Run:               ShowUsage,
+		PersistentPreRunE: rootCmdPersistentPreRun,
+		// Errors are shown by HandleError
+		SilenceErrors: true,
+		SilenceUsage:  true,
 	}
 
-	CLILog = zerolog.New(zerolog.ConsoleWriter{
+	CLILog = newLogger(zerolog.ConsoleWriter{
 		Out:     os.Stderr,
 		NoColor: !supportsColor(),
-	}).With().Timestamp().Logger()
+	}).Level(zerolog.InfoLevel)
 	RootArgs struct {
-		Token    string
-		endpoint string
-		Format   format.Options
+		Token      string
+		endpoint   string
+		connection connectionArgs
+		Format     format.Options
+		Profile    string
+		debug      bool
+		trace      bool
+		logFile    string
 	}
 )
 
 const (
 	// Prefix of all environment variables
-	envKeyPrefix  = "OASIS_"
-	apiPortSuffix = ":443"
+	envKeyPrefix = "OASIS_"
+	apiPort      = "443"
 )
 
 func init() {
+	RootCmd.SetFlagErrorFunc(func(c *cobra.Command, err error) error {
+		return UsageError("%s", err)
+	})
 	f := RootCmd.PersistentFlags()
 	// Persistent flags
 	defaultEndpoint := envOrDefault("ENDPOINT", "api.cloud.arangodb.com")
 	f.StringVar(&RootArgs.Token, "token", "", "Token used to authenticate at ArangoDB Oasis")
-	f.StringVar(&RootArgs.endpoint, "endpoint", defaultEndpoint, "API endpoint of the ArangoDB Oasis")
-	f.StringVar(&RootArgs.Format.Format, "format", DefaultFormat(), "Output format (table|json)")
+	f.StringVar(&RootArgs.endpoint, "endpoint", defaultEndpoint, "API endpoint of the ArangoDB Oasis (host or host:port)")
+	defaultPlaintext, _ := strconv.ParseBool(envOrDefault("PLAINTEXT", "false"))
+	defaultInsecureSkipVerify, _ := strconv.ParseBool(envOrDefault("INSECURE_SKIP_VERIFY", "false"))
+	f.BoolVar(&RootArgs.connection.plaintext, "plaintext", defaultPlaintext, "Connect to the API endpoint without TLS (for testing only)")
+	f.StringVar(&RootArgs.connection.caCert, "ca-cert", envOrDefault("CA_CERT", ""), "Path of a PEM file with CA certificates used to verify the API endpoint (instead of the system CA certificates)")
+	f.BoolVar(&RootArgs.connection.insecureSkipVerify, "insecure-skip-verify", defaultInsecureSkipVerify, "Do not verify the TLS certificate of the API endpoint (insecure)")
+	f.StringVar(&RootArgs.connection.clientCert, "client-cert", envOrDefault("CLIENT_CERT", ""), "Path of a PEM file with a client certificate used to authenticate the TLS connection")
+	f.StringVar(&RootArgs.connection.clientKey, "client-key", envOrDefault("CLIENT_KEY", ""), "Path of a PEM file with the private key of the client certificate")
+	defaultRequestTimeout, err := util.ParseDuration(envOrDefault("REQUEST_TIMEOUT", "30s"))
+	if err != nil {
+		defaultRequestTimeout = 30 * time.Second
+	}
+	defaultRetries, err := strconv.Atoi(envOrDefault("RETRIES", "3"))
+	if err != nil {
+		defaultRetries = 3
+	}
+	DurationVar(f, &RootArgs.connection.requestTimeout, "request-timeout", defaultRequestTimeout, "Timeout of every API call (0 for no timeout)")
+	f.IntVar(&RootArgs.connection.retries, "retries", defaultRetries, "Number of times a failed Get & List API call is retried")
+	f.StringVar(&RootArgs.connection.proxy, "proxy", envOrDefault("PROXY", ""), "URL of an HTTP proxy used to connect to the API endpoint (default taken from HTTPS_PROXY & NO_PROXY)")
+	f.StringVar(&RootArgs.Format.Format, "format", DefaultFormat(), "Output format ("+strings.Join(format.Formats(), "|")+")")
+	f.StringSliceVar(&RootArgs.Format.Columns, "columns", nil, "Comma separated list of columns (fields) to show")
+	f.BoolVar(&RootArgs.Format.NoHeaders, "no-headers", false, "Do not show the header of lists")
+	f.StringVar(&RootArgs.Format.SortBy, "sort-by", "", "Sort lists by the value of this column (e.g. created-at)")
+	f.StringVar(&RootArgs.Format.Filter, "filter", "", "Only show list items matching this expression (e.g. 'paused=true && version<3.7')")
+	f.StringVar(&RootArgs.Profile, "profile", envOrDefault("PROFILE", ""), "Name of the configuration profile to use")
+	defaultDebug, _ := strconv.ParseBool(envOrDefault("DEBUG", "false"))
+	defaultTrace, _ := strconv.ParseBool(envOrDefault("TRACE", "false"))
+	f.BoolVar(&RootArgs.debug, "debug", defaultDebug, "Log debug information, including the method, status code & latency of every API call")
+	f.BoolVar(&RootArgs.trace, "trace", defaultTrace, "Like --debug, also logging the requests & responses of every API call (with secrets redacted)")
+	f.StringVar(&RootArgs.logFile, "log-file", envOrDefault("LOG_FILE", ""), "Write the debug log to this file instead of stderr")
+}
+
+// newLogger returns a logger that writes to the given writer.
+func newLogger(w io.Writer) zerolog.Logger {
+	return zerolog.New(w).With().Timestamp().Logger()
+}
+
+// configureLogging configures CLILog for the --debug, --trace & --log-file flags.
+func configureLogging() error {
+	level := zerolog.InfoLevel
+	if RootArgs.debug || RootArgs.trace {
+		level = zerolog.DebugLevel
+	}
+	if RootArgs.logFile == "" {
+		CLILog = CLILog.Level(level)
+		return nil
+	}
+	f, err := os.OpenFile(RootArgs.logFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return WrapError(err, "Failed to open --log-file")
+	}
+	// The log file gets all messages, stderr only informational messages and up.
+	CLILog = newLogger(zerolog.MultiLevelWriter(
+		zerolog.ConsoleWriter{Out: f, NoColor: true},
+		levelFilterWriter{
+			Writer:   zerolog.ConsoleWriter{Out: os.Stderr, NoColor: !supportsColor()},
+			minLevel: zerolog.InfoLevel,
+		},
+	)).Level(level)
+	return nil
 }
 
 // ShowUsage shows usage of the given command on stdout.
@@ -81,11 +163,49 @@ func ShowUsage(cmd *cobra.Command, args []string) {
 
 // Called before actual command run.
 // This function is used to hide a default token (from environment variable)
-// from the usage output.
-func rootCmdPersistentPreRun(cmd *cobra.Command, args []string) {
+// from the usage output and to apply the defaults of the selected profile.
+func rootCmdPersistentPreRun(cmd *cobra.Command, args []string) error {
+	if err := configureLogging(); err != nil {
+		return err
+	}
+	cfg, err := LoadConfig()
+	if err != nil {
+		return err
+	}
+	profile := cfg.Profile(CurrentProfileName(cfg))
+	if profile == nil && RootArgs.Profile != "" && !hasParent(cmd, ConfigCmd) {
+		return UsageError("Profile '%s' not found", RootArgs.Profile)
+	}
+	if err := applyProfile(cmd, profile); err != nil {
+		return err
+	}
+	if err := RootArgs.Format.Validate(); err != nil {
+		return UsageError("%s", err)
+	}
+	if err := setupWatch(cmd); err != nil {
+		return err
+	}
 	if RootArgs.Token == "" {
 		RootArgs.Token = envOrDefault("TOKEN", "")
 	}
+	if RootArgs.Token == "" {
+		token, err := profile.GetToken()
+		if err != nil {
+			return WrapError(err, "Failed to load token of profile")
+		}
+		RootArgs.Token = token
+	}
+	return nil
+}
+
+// hasParent returns true if the given command is a (grand)child of the given parent.
+func hasParent(cmd, parent *cobra.Command) bool {
+	for p := cmd.Parent(); p != nil; p = p.Parent() {
+		if p == parent {
+			return true
+		}
+	}
+	return false
 }
 
 // envOrDefault returns the value from an environment value with given key
@@ -97,37 +217,211 @@ func envOrDefault(envKeySuffix string, defaultValue string) string {
 	return defaultValue
 }
 
-// MustDialAPI dials the ArangoDB Oasis API
-func MustDialAPI() *grpc.ClientConn {
+// apiConn is the connection returned by DialAPI.
+var apiConn *grpc.ClientConn
+
+// DialAPI dials the ArangoDB Oasis API.
+// The connection is shared by all calls (e.g. repeated polls in --watch mode).
+func DialAPI() (*grpc.ClientConn, error) {
+	if apiConn != nil {
+		return apiConn, nil
+	}
+	opts, err := RootArgs.connection.dialOptions()
+	if err != nil {
+		return nil, err
+	}
 	// Set up a connection to the server.
-	tc := credentials.NewTLS(&tls.Config{})
-	conn, err := grpc.Dial(RootArgs.endpoint+apiPortSuffix, grpc.WithTransportCredentials(tc))
+	conn, err := grpc.Dial(endpointAddress(RootArgs.endpoint), opts...)
 	if err != nil {
-		CLILog.Fatal().Err(err).Msg("Failed to connect to ArangoDB Oasis API")
+		return nil, WrapError(err, "Failed to connect to ArangoDB Oasis API")
+	}
+	apiConn = conn
+	return conn, nil
+}
+
+// connectionArgs holds the settings of the connection to the API.
+type connectionArgs struct {
+	plaintext          bool
+	caCert             string
+	insecureSkipVerify bool
+	clientCert         string
+	clientKey          string
+	proxy              string
+	requestTimeout     time.Duration
+	retries            int
+}
+
+// dialOptions returns the gRPC dial options for the connection settings.
+func (a connectionArgs) dialOptions() ([]grpc.DialOption, error) {
+	if a.requestTimeout < 0 {
+		return nil, UsageError("--request-timeout cannot be negative")
+	}
+	if a.retries < 0 {
+		return nil, UsageError("--retries cannot be negative")
+	}
+	opts := []grpc.DialOption{
+		grpc.WithChainUnaryInterceptor(retryUnaryInterceptor(a.requestTimeout, a.retries)),
+		grpc.WithChainStreamInterceptor(retryStreamInterceptor(a.retries)),
+	}
+	if RootArgs.debug || RootArgs.trace {
+		// Log every attempt of an API call
+		opts = append(opts,
+			grpc.WithChainUnaryInterceptor(traceUnaryInterceptor(RootArgs.trace)),
+			grpc.WithChainStreamInterceptor(traceStreamInterceptor(RootArgs.trace)),
+		)
+	}
+	if a.plaintext {
+		if a.caCert != "" || a.insecureSkipVerify || a.clientCert != "" || a.clientKey != "" {
+			return nil, UsageError("--plaintext cannot be combined with --ca-cert, --insecure-skip-verify, --client-cert or --client-key")
+		}
+		opts = append(opts, grpc.WithInsecure())
+	} else {
+		tlsConfig, err := a.tlsConfig()
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)))
+	}
+	if a.proxy != "" {
+		proxyURL, err := url.Parse(a.proxy)
+		if err != nil || proxyURL.Host == "" || (proxyURL.Scheme != "http" && proxyURL.Scheme != "https") {
+			return nil, UsageError("Invalid --proxy '%s', expected a URL like http://proxy.example.com:3128", a.proxy)
+		}
+		opts = append(opts, grpc.WithContextDialer(proxyDialer(proxyURL)))
+	}
+	// Without --proxy, gRPC uses the proxy from the HTTPS_PROXY & NO_PROXY
+	// environment variables.
+	return opts, nil
+}
+
+// tlsConfig returns the TLS configuration for the connection settings.
+func (a connectionArgs) tlsConfig() (*tls.Config, error) {
+	config := &tls.Config{
+		InsecureSkipVerify: a.insecureSkipVerify,
+	}
+	if a.caCert != "" {
+		pem, err := ioutil.ReadFile(a.caCert)
+		if err != nil {
+			return nil, WrapError(err, "Failed to read --ca-cert")
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, UsageError("No PEM encoded certificates found in --ca-cert '%s'", a.caCert)
+		}
+		config.RootCAs = pool
+	}
+	if a.clientCert != "" || a.clientKey != "" {
+		if a.clientCert == "" || a.clientKey == "" {
+			return nil, UsageError("--client-cert and --client-key must be used together")
+		}
+		cert, err := tls.LoadX509KeyPair(a.clientCert, a.clientKey)
+		if err != nil {
+			return nil, WrapError(err, "Failed to load client certificate")
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+	return config, nil
+}
+
+// proxyDialer returns a dialer that connects to the given address
+// through an HTTP CONNECT tunnel of the given proxy.
+func proxyDialer(proxyURL *url.URL) func(context.Context, string) (net.Conn, error) {
+	return func(ctx context.Context, address string) (net.Conn, error) {
+		proxyAddress := proxyURL.Host
+		if proxyURL.Port() == "" {
+			proxyAddress = net.JoinHostPort(proxyURL.Hostname(), map[string]string{"http": "80", "https": "443"}[proxyURL.Scheme])
+		}
+		conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", proxyAddress)
+		if err != nil {
+			return nil, err
+		}
+		if proxyURL.Scheme == "https" {
+			conn = tls.Client(conn, &tls.Config{ServerName: proxyURL.Hostname()})
+		}
+		if deadline, ok := ctx.Deadline(); ok {
+			conn.SetDeadline(deadline)
+			defer conn.SetDeadline(time.Time{})
+		}
+		req := &http.Request{
+			Method: http.MethodConnect,
+			URL:    &url.URL{Host: address},
+			Host:   address,
+			Header: make(http.Header),
+		}
+		if user := proxyURL.User; user != nil {
+			password, _ := user.Password()
+			auth := base64.StdEncoding.EncodeToString([]byte(user.Username() + ":" + password))
+			req.Header.Set("Proxy-Authorization", "Basic "+auth)
+		}
+		if err := req.Write(conn); err != nil {
+			conn.Close()
+			return nil, err
+		}
+		r := bufio.NewReader(conn)
+		resp, err := http.ReadResponse(r, req)
+		if err != nil {
+			conn.Close()
+			return nil, err
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			conn.Close()
+			return nil, fmt.Errorf("Proxy %s refused to connect to %s: %s", proxyURL.Host, address, resp.Status)
+		}
+		if r.Buffered() > 0 {
+			// The API spoke first, keep what has been read already.
+			return &bufferedConn{Conn: conn, r: r}, nil
+		}
+		return conn, nil
+	}
+}
+
+// bufferedConn is a connection of which some data has already been read.
+type bufferedConn struct {
+	net.Conn
+	r *bufio.Reader
+}
+
+func (c *bufferedConn) Read(b []byte) (int, error) {
+	return c.r.Read(b)
+}
+
+// endpointAddress returns the given endpoint with the default
+// API port appended, if it does not contain a port.
+func endpointAddress(endpoint string) string {
+	if _, _, err := net.SplitHostPort(endpoint); err == nil {
+		return endpoint
 	}
-	return conn
+	return net.JoinHostPort(endpoint, apiPort)
 }
 
 // ContextWithToken returns a context with access token in it.
-func ContextWithToken() context.Context {
+// If no token is given, the token stored by `oasisctl login` is used.
+func ContextWithToken() (context.Context, error) {
+	if RootArgs.Token == "" {
+		token, err := tokenFromCredentials()
+		if err != nil {
+			return nil, err
+		}
+		RootArgs.Token = token
+	}
 	if RootArgs.Token == "" {
-		CLILog.Fatal().Msg("--token missing")
+		return nil, &commandError{msg: "--token missing, use 'oasisctl login' or pass --token", exitCode: ExitCodeUnauthenticated}
 	}
-	return auth.WithAccessToken(context.Background(), RootArgs.Token)
+	return auth.WithAccessToken(context.Background(), RootArgs.Token), nil
 }
 
 // ReqOption returns given value if not empty.
-// Fails with clear error message when not set.
-// Returns: option-value, number-of-args-used(0|argIndex+1)
-func ReqOption(key, value string, args []string, argIndex int) (string, int) {
+// Returns an error with clear message when not set.
+// Returns: option-value, number-of-args-used(0|argIndex+1), error
+func ReqOption(key, value string, args []string, argIndex int) (string, int, error) {
 	if value != "" {
-		return value, 0
+		return value, 0, nil
 	}
 	if len(args) > argIndex {
-		return args[argIndex], argIndex + 1
+		return args[argIndex], argIndex + 1, nil
 	}
-	CLILog.Fatal().Msgf("--%s missing", key)
-	return "", 0
+	return "", 0, UsageError("--%s missing", key)
 }
 
 // OptOption returns given value if not empty.
@@ -142,16 +436,17 @@ func OptOption(key, value string, args []string, argIndex int) (string, int) {
 	return "", 0
 }
 
-// MustCheckNumberOfArgs compares the number of arguments with the expected
+// CheckNumberOfArgs compares the number of arguments with the expected
 // number of arguments.
-// If there is a difference a fatal error is raised.
-func MustCheckNumberOfArgs(args []string, expectedNumberOfArgs int) {
+// If there is a difference an error is returned.
+func CheckNumberOfArgs(args []string, expectedNumberOfArgs int) error {
 	if len(args) > expectedNumberOfArgs {
-		CLILog.Fatal().Msg("Too many arguments")
+		return UsageError("Too many arguments")
 	}
 	if len(args) < expectedNumberOfArgs {
-		CLILog.Fatal().Msg("Too few arguments")
+		return UsageError("Too few arguments")
 	}
+	return nil
 }
 
 // InitCommand adds the given command to the given parent and called the flag initialization
@@ -163,3 +458,17 @@ func InitCommand(parent, cmd *cobra.Command, flagInit func(c *cobra.Command, f *
 	flagInit(cmd, cmd.Flags())
 	return cmd
 }
+
+// ShowResult prints the given formatted result.
+// Nothing is printed for an empty result (e.g. an empty list with --no-headers).
+// A formatting error (e.g. a --format template that fails on the result)
+// is returned as usage error.
+func ShowResult(result string, err error) error {
+	if err != nil {
+		return UsageError("%s", err)
+	}
+	if result != "" {
+		fmt.Println(result)
+	}
+	return nil
+}
@@ -23,7 +23,6 @@
 package security
 
 import (
-	"fmt"
 	"sort"
 
 	"github.com/spf13/cobra"
@@ -58,21 +57,35 @@ func init() {
 			f.StringVarP(&cargs.projectID, "project-id", "p", cmd.DefaultProject(), "Identifier of the project to create the IP whitelist in")
 			f.StringSliceVar(&cargs.cidrRanges, "cidr-range", nil, "List of CIDR ranges from which deployments are accessible")
 
-			c.Run = func(c *cobra.Command, args []string) {
+			c.RunE = func(c *cobra.Command, args []string) error {
 				// Validate arguments
 				log := cmd.CLILog
-				name, argsUsed := cmd.ReqOption("name", cargs.name, args, 0)
+				name, argsUsed, err := cmd.ReqOption("name", cargs.name, args, 0)
+				if err != nil {
+					return err
+				}
 				description := cargs.description
-				cmd.MustCheckNumberOfArgs(args, argsUsed)
+				if err := cmd.CheckNumberOfArgs(args, argsUsed); err != nil {
+					return err
+				}
 
 				// Connect
-				conn := cmd.MustDialAPI()
+				conn, err := cmd.DialAPI()
+				if err != nil {
+					return err
+				}
 				securityc := security.NewSecurityServiceClient(conn)
 				rmc := rm.NewResourceManagerServiceClient(conn)
-				ctx := cmd.ContextWithToken()
+				ctx, err := cmd.ContextWithToken()
+				if err != nil {
+					return err
+				}
 
 				// Fetch project
-				project := selection.MustSelectProject(ctx, log, cargs.projectID, cargs.organizationID, rmc)
+				project, err := selection.SelectProject(ctx, log, cargs.projectID, cargs.organizationID, rmc)
+				if err != nil {
+					return cmd.WrapError(err, "Failed to get project")
+				}
 
 				// Create IP whitelist
 				sort.Strings(cargs.cidrRanges)
@@ -83,12 +96,12 @@ func init() {
 					CidrRanges:  cargs.cidrRanges,
 				})
 				if err != nil {
-					log.Fatal().Err(err).Msg("Failed to create IP whitelist")
+					return cmd.WrapError(err, "Failed to create IP whitelist")
 				}
 
 				// Show result
 				format.DisplaySuccess(cmd.RootArgs.Format)
-				fmt.Println(format.IPWhitelist(result, cmd.RootArgs.Format))
+				return cmd.ShowResult(format.IPWhitelist(result, cmd.RootArgs.Format))
 			}
 		},
 	)
@@ -62,20 +62,31 @@ func init() {
 			f.StringSliceVar(&cargs.addCidrRanges, "add-cidr-range", nil, "List of CIDR ranges to add to the IP whitelist")
 			f.StringSliceVar(&cargs.removeCidrRanges, "remove-cidr-range", nil, "List of CIDR ranges to remove from the IP whitelist")
 
-			c.Run = func(c *cobra.Command, args []string) {
+			c.RunE = func(c *cobra.Command, args []string) error {
 				// Validate arguments
 				log := cmd.CLILog
 				ipwhitelistID, argsUsed := cmd.OptOption("ipwhitelist-id", cargs.ipwhitelistID, args, 0)
-				cmd.MustCheckNumberOfArgs(args, argsUsed)
+				if err := cmd.CheckNumberOfArgs(args, argsUsed); err != nil {
+					return err
+				}
 
 				// Connect
-				conn := cmd.MustDialAPI()
+				conn, err := cmd.DialAPI()
+				if err != nil {
+					return err
+				}
 				securityc := security.NewSecurityServiceClient(conn)
 				rmc := rm.NewResourceManagerServiceClient(conn)
-				ctx := cmd.ContextWithToken()
+				ctx, err := cmd.ContextWithToken()
+				if err != nil {
+					return err
+				}
 
 				// Fetch IP whitelist
-				item := selection.MustSelectIPWhitelist(ctx, log, ipwhitelistID, cargs.projectID, cargs.organizationID, securityc, rmc)
+				item, err := selection.SelectIPWhitelist(ctx, log, ipwhitelistID, cargs.projectID, cargs.organizationID, securityc, rmc)
+				if err != nil {
+					return cmd.WrapError(err, "Failed to get IP whitelist")
+				}
 
 				// Set changes
 				f := c.Flags()
@@ -120,13 +131,16 @@ func init() {
 					// Update IP whitelist
 					updated, err := securityc.UpdateIPWhitelist(ctx, item)
 					if err != nil {
-						log.Fatal().Err(err).Msg("Failed to update IP whitelist")
+						return cmd.WrapError(err, "Failed to update IP whitelist")
 					}
 
 					// Show result
 					fmt.Println("Updated IP whitelist!")
-					fmt.Println(format.IPWhitelist(updated, cmd.RootArgs.Format))
+					if err := cmd.ShowResult(format.IPWhitelist(updated, cmd.RootArgs.Format)); err != nil {
+						return err
+					}
 				}
+				return nil
 			}
 		},
 	)
@@ -0,0 +1,208 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Ewout Prangsma
+//
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/gogo/protobuf/jsonpb"
+	"github.com/gogo/protobuf/proto"
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// Replacement of redacted values in traced messages.
+	redacted = "***"
+)
+
+// redactedFields contains (parts of) names of message fields that
+// are never logged.
+var redactedFields = []string{"password", "secret", "token", "private_key", "passphrase"}
+
+// levelFilterWriter is a log writer that drops messages below a minimum level.
+type levelFilterWriter struct {
+	io.Writer
+	minLevel zerolog.Level
+}
+
+// WriteLevel writes p when the given level is at least the minimum level.
+func (w levelFilterWriter) WriteLevel(level zerolog.Level, p []byte) (int, error) {
+	if level < w.minLevel {
+		return len(p), nil
+	}
+	return w.Write(p)
+}
+
+// traceUnaryInterceptor returns a client interceptor that logs every
+// unary API call. If withMessages is set, the (redacted) request & response
+// are logged as well.
+func traceUnaryInterceptor(withMessages bool) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		start := time.Now()
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		e := CLILog.Debug().
+			Str("method", method).
+			Str("code", status.Code(err).String()).
+			Str("latency", time.Since(start).String())
+		if withMessages {
+			e = e.RawJSON("request", traceMessage(req))
+			if err == nil {
+				e = e.RawJSON("response", traceMessage(reply))
+			}
+		}
+		if err != nil {
+			e = e.Str("error", err.Error())
+		}
+		e.Msg("API call")
+		return err
+	}
+}
+
+// traceStreamInterceptor returns a client interceptor that logs every
+// streaming API call. If withMessages is set, the (redacted) messages
+// are logged as well.
+func traceStreamInterceptor(withMessages bool) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		start := time.Now()
+		stream, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			CLILog.Debug().
+				Str("method", method).
+				Str("code", status.Code(err).String()).
+				Str("latency", time.Since(start).String()).
+				Str("error", err.Error()).
+				Msg("API stream")
+			return nil, err
+		}
+		return &traceClientStream{
+			ClientStream: stream,
+			method:       method,
+			start:        start,
+			withMessages: withMessages,
+		}, nil
+	}
+}
+
+// traceClientStream is a streaming call that logs its messages & result.
+type traceClientStream struct {
+	grpc.ClientStream
+	method       string
+	start        time.Time
+	withMessages bool
+	messages     int
+}
+
+// SendMsg sends a message and logs it when messages are traced.
+func (s *traceClientStream) SendMsg(m interface{}) error {
+	err := s.ClientStream.SendMsg(m)
+	if s.withMessages {
+		CLILog.Debug().
+			Str("method", s.method).
+			RawJSON("request", traceMessage(m)).
+			Msg("API stream send")
+	}
+	return err
+}
+
+// RecvMsg receives a message and logs it when messages are traced.
+// The end of the stream is always logged.
+func (s *traceClientStream) RecvMsg(m interface{}) error {
+	err := s.ClientStream.RecvMsg(m)
+	if err == nil {
+		s.messages++
+		if s.withMessages {
+			CLILog.Debug().
+				Str("method", s.method).
+				RawJSON("response", traceMessage(m)).
+				Msg("API stream receive")
+		}
+		return nil
+	}
+	e := CLILog.Debug().
+		Str("method", s.method).
+		Int("messages", s.messages).
+		Str("latency", time.Since(s.start).String())
+	if err == io.EOF {
+		e = e.Str("code", "OK")
+	} else {
+		e = e.Str("code", status.Code(err).String()).Str("error", err.Error())
+	}
+	e.Msg("API stream")
+	return err
+}
+
+// traceMessage returns the given message as JSON with the values of
+// secret fields redacted.
+func traceMessage(m interface{}) []byte {
+	msg, ok := m.(proto.Message)
+	if !ok {
+		return []byte("null")
+	}
+	encoded, err := (&jsonpb.Marshaler{OrigName: true}).MarshalToString(msg)
+	if err != nil {
+		return []byte(`"<` + err.Error() + `>"`)
+	}
+	var v interface{}
+	if err := json.Unmarshal([]byte(encoded), &v); err != nil {
+		return []byte(encoded)
+	}
+	result, _ := json.Marshal(redact(v))
+	return result
+}
+
+// redact replaces the values of secret fields in the given decoded JSON value.
+func redact(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			if isRedactedField(key) {
+				v[key] = redacted
+			} else {
+				v[key] = redact(value)
+			}
+		}
+	case []interface{}:
+		for i, value := range v {
+			v[i] = redact(value)
+		}
+	}
+	return v
+}
+
+// isRedactedField returns true if the value of the field with given name
+// must not be logged.
+func isRedactedField(name string) bool {
+	name = strings.ToLower(name)
+	for _, f := range redactedFields {
+		if strings.Contains(name, f) {
+			return true
+		}
+	}
+	return false
+}
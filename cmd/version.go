@@ -23,8 +23,6 @@
 package cmd
 
 import (
-	"fmt"
-
 	"github.com/coreos/go-semver/semver"
 	"github.com/spf13/cobra"
 
@@ -37,7 +35,7 @@ var (
 	versionCmd = &cobra.Command{
 		Use:   "version",
 		Short: "Show the current version of this tool",
-		Run:   runVersionCmd,
+		RunE:  runVersionCmd,
 	}
 )
 
@@ -51,6 +49,6 @@ func SetVersion(releaseVersion string) {
 }
 
 // Run the service
-func runVersionCmd(cmd *cobra.Command, args []string) {
-	fmt.Println(format.CLIVersion(currentVersion.String(), RootArgs.Format))
+func runVersionCmd(cmd *cobra.Command, args []string) error {
+	return ShowResult(format.CLIVersion(currentVersion.String(), RootArgs.Format))
 }
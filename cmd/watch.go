@@ -0,0 +1,126 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Ewout Prangsma
+//
+
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/arangodb-managed/oasisctl/pkg/format"
+)
+
+const (
+	// Terminal escape code that clears the screen and moves the cursor home.
+	clearScreen = "\x1b[H\x1b[2J"
+)
+
+var (
+	watchArgs struct {
+		watch    bool
+		interval time.Duration
+	}
+)
+
+func init() {
+	for _, c := range []*cobra.Command{GetCmd, ListCmd} {
+		f := c.PersistentFlags()
+		f.BoolVar(&watchArgs.watch, "watch", false, "Keep polling and show changes until interrupted")
+		DurationVar(f, &watchArgs.interval, "interval", 2*time.Second, "Time between polls in --watch mode")
+	}
+}
+
+// setupWatch replaces the run function of the given command with a
+// function that runs it repeatedly, when --watch is set.
+func setupWatch(cmd *cobra.Command) error {
+	if !watchArgs.watch || cmd.RunE == nil {
+		return nil
+	}
+	if watchArgs.interval <= 0 {
+		return UsageError("--interval must be positive")
+	}
+	run := cmd.RunE
+	cmd.RunE = func(c *cobra.Command, args []string) error {
+		return runWatch(c, args, run)
+	}
+	return nil
+}
+
+// runWatch runs the given command every --interval until interrupted or
+// until it fails.
+// On a terminal, tables are redrawn in place with changed values highlighted.
+// In json format, only new & changed objects are shown (one per line).
+// In other formats, the output is shown when it changed.
+func runWatch(c *cobra.Command, args []string, run func(*cobra.Command, []string) error) error {
+	formatName := RootArgs.Format.Format
+	redraw := formatName == "table" && StdoutIsTerminal()
+	RootArgs.Format.Watch = format.NewWatch(redraw && UseColor())
+	title := fmt.Sprintf("Every %s: oasisctl %s", watchArgs.interval, strings.Join(os.Args[1:], " "))
+	lastOutput := ""
+	for poll := 0; ; poll++ {
+		output, err := captureStdout(func() error { return run(c, args) })
+		if err != nil {
+			return err
+		}
+		switch {
+		case redraw:
+			fmt.Printf("%s%s  %s\n\n%s", clearScreen, title, time.Now().Format("15:04:05"), output)
+		case formatName == "json":
+			if output = strings.TrimSpace(output); output != "" {
+				fmt.Println(output)
+			}
+		case output != lastOutput:
+			if poll > 0 {
+				fmt.Println()
+			}
+			fmt.Print(output)
+			lastOutput = output
+		}
+		time.Sleep(watchArgs.interval)
+	}
+}
+
+// captureStdout calls the given function and returns everything it
+// writes to the standard output.
+func captureStdout(f func() error) (string, error) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		return "", WrapError(err, "Failed to capture output")
+	}
+	defer r.Close()
+	output := make(chan string)
+	go func() {
+		raw, _ := ioutil.ReadAll(r)
+		output <- string(raw)
+	}()
+	stdout := os.Stdout
+	os.Stdout = w
+	err = f()
+	os.Stdout = stdout
+	w.Close()
+	return <-output, err
+}
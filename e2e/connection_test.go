@@ -0,0 +1,203 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Ewout Prangsma
+//
+
+package e2e
+
+import (
+	"bufio"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/arangodb-managed/oasisctl/pkg/fakeapi"
+)
+
+// certificate is a generated certificate with its private key.
+type certificate struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	der  []byte
+}
+
+// newCertificate creates a certificate signed by the given parent
+// (or a self-signed CA certificate when parent is nil).
+func newCertificate(t *testing.T, template *x509.Certificate, parent *certificate) certificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	template.SerialNumber = big.NewInt(time.Now().UnixNano())
+	template.NotBefore = time.Now().Add(-time.Hour)
+	template.NotAfter = time.Now().Add(time.Hour)
+	signerCert, signerKey := template, key
+	if parent != nil {
+		signerCert, signerKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, signerCert, &key.PublicKey, signerKey)
+	if err != nil {
+		t.Fatalf("Failed to create certificate: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("Failed to parse certificate: %v", err)
+	}
+	return certificate{cert: cert, key: key, der: der}
+}
+
+// writePEM writes the certificate & key of c as PEM files in dir and
+// returns their paths.
+func (c certificate) writePEM(t *testing.T, dir, name string) (certPath, keyPath string) {
+	t.Helper()
+	keyDER, err := x509.MarshalECPrivateKey(c.key)
+	if err != nil {
+		t.Fatalf("Failed to marshal key: %v", err)
+	}
+	certPath = filepath.Join(dir, name+".crt")
+	keyPath = filepath.Join(dir, name+".key")
+	if err := ioutil.WriteFile(certPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: c.der}), 0600); err != nil {
+		t.Fatalf("Failed to write certificate: %v", err)
+	}
+	if err := ioutil.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600); err != nil {
+		t.Fatalf("Failed to write key: %v", err)
+	}
+	return certPath, keyPath
+}
+
+func TestTLSConnection(t *testing.T) {
+	dir, err := ioutil.TempDir("", "oasisctl-e2e-tls")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	ca := newCertificate(t, &x509.Certificate{
+		Subject:               pkix.Name{CommonName: "e2e CA"},
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}, nil)
+	serverCert := newCertificate(t, &x509.Certificate{
+		Subject:     pkix.Name{CommonName: "127.0.0.1"},
+		IPAddresses: []net.IP{net.ParseIP("127.0.0.1")},
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}, &ca)
+	clientCert := newCertificate(t, &x509.Certificate{
+		Subject:     pkix.Name{CommonName: "e2e client"},
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}, &ca)
+	caPath, _ := ca.writePEM(t, dir, "ca")
+	clientCertPath, clientKeyPath := clientCert.writePEM(t, dir, "client")
+
+	pool := x509.NewCertPool()
+	pool.AddCert(ca.cert)
+	tlsServer := fakeapi.New()
+	if err := tlsServer.StartTLS("127.0.0.1:0", &tls.Config{
+		Certificates: []tls.Certificate{{Certificate: [][]byte{serverCert.der}, PrivateKey: serverCert.key}},
+		ClientCAs:    pool,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+	}); err != nil {
+		t.Fatalf("Failed to start fake API with TLS: %v", err)
+	}
+	defer tlsServer.Stop()
+
+	env := []string{"OASIS_ENDPOINT=" + tlsServer.Address(), "OASIS_PLAINTEXT=false"}
+	clientArgs := []string{"--client-cert", clientCertPath, "--client-key", clientKeyPath}
+	tests := []struct {
+		name     string
+		args     []string
+		exitCode int
+	}{
+		{"ca-cert and client certificate", append([]string{"--ca-cert", caPath}, clientArgs...), 0},
+		{"insecure-skip-verify and client certificate", append([]string{"--insecure-skip-verify"}, clientArgs...), 0},
+		{"unknown CA", clientArgs, 7},
+		{"missing client certificate", []string{"--ca-cert", caPath}, 7},
+		{"client certificate without key", []string{"--ca-cert", caPath, "--client-cert", clientCertPath}, 3},
+		{"plaintext with ca-cert", []string{"--plaintext", "--ca-cert", caPath}, 3},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := runWithEnv(t, env, append([]string{"list", "organizations"}, test.args...)...)
+			if r.exitCode != test.exitCode {
+				t.Errorf("Expected exit code %d, got %d (%s)", test.exitCode, r.exitCode, r.stderr)
+			}
+		})
+	}
+}
+
+func TestProxy(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer lis.Close()
+	var tunnels int32
+	go func() {
+		for {
+			conn, err := lis.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				req, err := http.ReadRequest(bufio.NewReader(conn))
+				if err != nil || req.Method != http.MethodConnect {
+					return
+				}
+				upstream, err := net.Dial("tcp", req.Host)
+				if err != nil {
+					io.WriteString(conn, "HTTP/1.1 502 Bad Gateway\r\n\r\n")
+					return
+				}
+				defer upstream.Close()
+				atomic.AddInt32(&tunnels, 1)
+				io.WriteString(conn, "HTTP/1.1 200 OK\r\n\r\n")
+				go io.Copy(upstream, conn)
+				io.Copy(conn, upstream)
+			}()
+		}
+	}()
+
+	if r := run(t, "list", "organizations", "--proxy", "http://"+lis.Addr().String()); r.exitCode != 0 {
+		t.Fatalf("Failed to list organizations through proxy: %s", r.stderr)
+	}
+	if atomic.LoadInt32(&tunnels) == 0 {
+		t.Error("Expected a connection through the proxy")
+	}
+	if r := run(t, "list", "organizations", "--proxy", "not a url"); r.exitCode != 3 {
+		t.Errorf("Expected exit code 3 for invalid --proxy, got %d (%s)", r.exitCode, r.stderr)
+	}
+}
@@ -0,0 +1,957 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Ewout Prangsma
+//
+
+package e2e
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	backup "github.com/arangodb-managed/apis/backup/v1"
+	data "github.com/arangodb-managed/apis/data/v1"
+
+	"github.com/arangodb-managed/oasisctl/pkg/fakeapi"
+)
+
+var (
+	// binary is the path of the oasisctl binary under test
+	binary string
+	// server is the fake API used by all tests
+	server *fakeapi.Server
+	// homeDir is the (empty) home directory used when running oasisctl
+	homeDir string
+)
+
+// TestMain builds oasisctl and starts a fake API for all tests.
+func TestMain(m *testing.M) {
+	os.Exit(func() int {
+		dir, err := ioutil.TempDir("", "oasisctl-e2e")
+		if err != nil {
+			fmt.Println(err)
+			return 1
+		}
+		defer os.RemoveAll(dir)
+		binary = filepath.Join(dir, "oasisctl")
+		homeDir = filepath.Join(dir, "home")
+		build := exec.Command("go", "build", "-o", binary, "github.com/arangodb-managed/oasisctl")
+		build.Stdout, build.Stderr = os.Stdout, os.Stderr
+		if err := build.Run(); err != nil {
+			fmt.Printf("Failed to build oasisctl: %v\n", err)
+			return 1
+		}
+		server = fakeapi.New()
+		if err := server.Start("127.0.0.1:0"); err != nil {
+			fmt.Printf("Failed to start fake API: %v\n", err)
+			return 1
+		}
+		defer server.Stop()
+		return m.Run()
+	}())
+}
+
+// result is the outcome of running oasisctl.
+type result struct {
+	stdout   string
+	stderr   string
+	exitCode int
+}
+
+// run oasisctl with given arguments against the fake API.
+func run(t *testing.T, args ...string) result {
+	t.Helper()
+	return runWithEnv(t, nil, args...)
+}
+
+// runWithEnv runs oasisctl with given arguments against the fake API,
+// with additional (or overridden) environment variables.
+func runWithEnv(t *testing.T, env []string, args ...string) result {
+	t.Helper()
+	cmd := exec.Command(binary, args...)
+	cmd.Env = append([]string{
+		"HOME=" + homeDir,
+		"XDG_CONFIG_HOME=" + filepath.Join(homeDir, ".config"),
+		"OASIS_ENDPOINT=" + server.Address(),
+		"OASIS_PLAINTEXT=true",
+		"OASIS_TOKEN=" + fakeapi.DefaultToken,
+	}, env...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	err := cmd.Run()
+	exitCode := 0
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		exitCode = exitErr.ExitCode()
+	} else if err != nil {
+		t.Fatalf("Failed to run oasisctl: %v", err)
+	}
+	return result{stdout: stdout.String(), stderr: stderr.String(), exitCode: exitCode}
+}
+
+// mustRunJSON runs oasisctl with JSON output, expects success and
+// decodes the output.
+func mustRunJSON(t *testing.T, v interface{}, args ...string) {
+	t.Helper()
+	r := run(t, append(args, "--format", "json")...)
+	if r.exitCode != 0 {
+		t.Fatalf("oasisctl %s failed with exit code %d: %s", strings.Join(args, " "), r.exitCode, r.stderr)
+	}
+	if err := json.Unmarshal([]byte(r.stdout), v); err != nil {
+		t.Fatalf("Failed to decode output of oasisctl %s: %v\n%s", strings.Join(args, " "), err, r.stdout)
+	}
+}
+
+// mustCreate runs a create command and returns the ID of the created object.
+func mustCreate(t *testing.T, args ...string) string {
+	t.Helper()
+	var obj map[string]interface{}
+	mustRunJSON(t, &obj, append([]string{"create"}, args...)...)
+	id, _ := obj["id"].(string)
+	if id == "" {
+		t.Fatalf("No ID in output of oasisctl create %s: %v", strings.Join(args, " "), obj)
+	}
+	return id
+}
+
+func TestDeploymentLifecycle(t *testing.T) {
+	orgID := mustCreate(t, "organization", "--name", "e2e-org")
+	projectID := mustCreate(t, "project", "--name", "e2e-project", "-o", orgID)
+	cacertID := mustCreate(t, "cacertificate", "--name", "e2e-cert", "-o", orgID, "-p", projectID)
+	deploymentID := mustCreate(t, "deployment", "--name", "e2e-deployment", "--region-id", "fake-region",
+		"-o", orgID, "-p", projectID, "-c", cacertID)
+
+	var deployment map[string]interface{}
+	mustRunJSON(t, &deployment, "get", "deployment", "-o", orgID, "-p", projectID, "-d", deploymentID)
+	if deployment["name"] != "e2e-deployment" {
+		t.Errorf("Expected deployment name e2e-deployment, got %v", deployment["name"])
+	}
+
+	var deployments []map[string]interface{}
+	mustRunJSON(t, &deployments, "list", "deployments", "-o", orgID, "-p", projectID)
+	if len(deployments) != 1 || deployments[0]["id"] != deploymentID {
+		t.Errorf("Expected 1 deployment with ID %s, got %v", deploymentID, deployments)
+	}
+
+	backupID := mustCreate(t, "backup", "--name", "e2e-backup", "--deployment-id", deploymentID)
+	var backups []map[string]interface{}
+	mustRunJSON(t, &backups, "list", "backups", "--deployment-id", deploymentID)
+	if len(backups) != 1 || backups[0]["id"] != backupID {
+		t.Errorf("Expected 1 backup with ID %s, got %v", backupID, backups)
+	}
+
+	if r := run(t, "delete", "deployment", "-o", orgID, "-p", projectID, "-d", deploymentID); r.exitCode != 0 {
+		t.Fatalf("Failed to delete deployment: %s", r.stderr)
+	}
+	mustRunJSON(t, &deployments, "list", "deployments", "-o", orgID, "-p", projectID)
+	if len(deployments) != 0 {
+		t.Errorf("Expected no deployments, got %v", deployments)
+	}
+}
+
+func TestExitCodes(t *testing.T) {
+	mustCreate(t, "organization", "--name", "e2e-exit-codes")
+	manifest := writeManifest(t, "e2e-exit-codes", "365d")
+	tests := []struct {
+		name     string
+		args     []string
+		exitCode int
+	}{
+		{"not found", []string{"get", "organization", "-o", "does-not-exist"}, 6},
+		{"unauthenticated", []string{"get", "organization", "-o", "does-not-exist", "--token", "invalid"}, 4},
+		{"unknown flag", []string{"list", "organizations", "--no-such-flag"}, 3},
+		{"too many arguments", []string{"get", "organization", "a", "b"}, 3},
+		{"invalid format", []string{"list", "organizations", "--format", "xml"}, 3},
+		{"invalid sort-by", []string{"list", "organizations", "--sort-by", "Name"}, 3},
+		{"invalid filter", []string{"list", "organizations", "--filter", "name=x &&"}, 3},
+		{"failing template", []string{"version", "--format", "go-template={{.version.foo}}"}, 3},
+		{"drift", []string{"diff", "-f", manifest}, 8},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if r := run(t, test.args...); r.exitCode != test.exitCode {
+				t.Errorf("Expected exit code %d, got %d (%s)", test.exitCode, r.exitCode, r.stderr)
+			}
+		})
+	}
+}
+
+func TestJSONError(t *testing.T) {
+	r := run(t, "get", "organization", "-o", "does-not-exist", "--format", "json")
+	// The JSON object is the last output on stderr
+	stderr := r.stderr
+	if idx := strings.LastIndex(stderr, "\n{\n"); idx >= 0 {
+		stderr = stderr[idx+1:]
+	}
+	var e struct {
+		Error    string `json:"error"`
+		Code     string `json:"code"`
+		ExitCode int    `json:"exit-code"`
+	}
+	if err := json.Unmarshal([]byte(stderr), &e); err != nil {
+		t.Fatalf("Expected JSON error on stderr, got %q: %v", r.stderr, err)
+	}
+	if e.Code != "NotFound" || e.ExitCode != 6 || r.exitCode != 6 {
+		t.Errorf("Unexpected error %+v (exit code %d)", e, r.exitCode)
+	}
+}
+
+func TestRetries(t *testing.T) {
+	orgID := mustCreate(t, "organization", "--name", "e2e-retries")
+	unavailable := status.Error(codes.Unavailable, "try again")
+
+	server.FailNext("GetOrganization", unavailable, unavailable)
+	if r := run(t, "get", "organization", "-o", orgID); r.exitCode != 0 {
+		t.Errorf("Expected get organization to succeed after retries, got exit code %d (%s)", r.exitCode, r.stderr)
+	}
+
+	server.FailNext("GetOrganization", unavailable, unavailable)
+	if r := run(t, "get", "organization", "-o", orgID, "--retries", "1"); r.exitCode != 7 {
+		t.Errorf("Expected exit code 7 when retries are exhausted, got %d (%s)", r.exitCode, r.stderr)
+	}
+
+	server.FailNext("CreateProject", unavailable)
+	if r := run(t, "create", "project", "--name", "e2e-not-retried", "-o", orgID); r.exitCode != 7 {
+		t.Errorf("Expected create project not to be retried, got exit code %d (%s)", r.exitCode, r.stderr)
+	}
+}
+
+func TestTrace(t *testing.T) {
+	dir, err := ioutil.TempDir("", "oasisctl-e2e-trace")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	logFile := filepath.Join(dir, "trace.log")
+	var key map[string]interface{}
+	mustRunJSON(t, &key, "create", "apikey", "--trace", "--log-file", logFile)
+	secret, _ := key["secret"].(string)
+	if secret == "" {
+		t.Fatalf("No secret in output: %v", key)
+	}
+	raw, err := ioutil.ReadFile(logFile)
+	if err != nil {
+		t.Fatalf("Failed to read log file: %v", err)
+	}
+	trace := string(raw)
+	for _, expected := range []string{"/arangodb.cloud.iam.v1.IAMService/CreateAPIKey", "code=OK", "latency=", `"secret":"***"`} {
+		if !strings.Contains(trace, expected) {
+			t.Errorf("Expected %q in trace:\n%s", expected, trace)
+		}
+	}
+	if strings.Contains(trace, secret) {
+		t.Errorf("Secret %q not redacted in trace:\n%s", secret, trace)
+	}
+
+	if r := run(t, "list", "organizations"); strings.Contains(r.stderr, "DBG") {
+		t.Errorf("Expected no debug output without --debug, got:\n%s", r.stderr)
+	}
+	if r := run(t, "list", "organizations", "--debug"); !strings.Contains(r.stderr, "ListOrganizations") {
+		t.Errorf("Expected API call in debug output, got:\n%s", r.stderr)
+	}
+}
+
+func TestWatch(t *testing.T) {
+	orgID := mustCreate(t, "organization", "--name", "e2e-watch")
+
+	cmd := exec.Command(binary, "get", "organization", "-o", orgID, "--watch", "--interval", "100ms", "--format", "json")
+	cmd.Env = []string{
+		"HOME=" + homeDir,
+		"OASIS_ENDPOINT=" + server.Address(),
+		"OASIS_PLAINTEXT=true",
+		"OASIS_TOKEN=" + fakeapi.DefaultToken,
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cmd.Start(); err != nil {
+		t.Fatalf("Failed to start oasisctl: %v", err)
+	}
+	defer cmd.Process.Kill()
+	lines := make(chan map[string]interface{})
+	go func() {
+		scanner := bufio.NewScanner(stdout)
+		for scanner.Scan() {
+			var obj map[string]interface{}
+			if err := json.Unmarshal(scanner.Bytes(), &obj); err == nil {
+				lines <- obj
+			}
+		}
+		close(lines)
+	}()
+	next := func() map[string]interface{} {
+		select {
+		case obj := <-lines:
+			return obj
+		case <-time.After(10 * time.Second):
+			t.Fatal("Timeout waiting for watch output")
+			return nil
+		}
+	}
+
+	if obj := next(); obj["name"] != "e2e-watch" {
+		t.Errorf("Expected organization in first poll, got %v", obj)
+	}
+	if r := run(t, "update", "organization", "-o", orgID, "--name", "e2e-watch-renamed"); r.exitCode != 0 {
+		t.Fatalf("Failed to update organization: %s", r.stderr)
+	}
+	if obj := next(); obj["name"] != "e2e-watch-renamed" {
+		t.Errorf("Expected renamed organization after update, got %v", obj)
+	}
+}
+
+func TestWaitDeployment(t *testing.T) {
+	orgID := mustCreate(t, "organization", "--name", "e2e-wait")
+	projectID := mustCreate(t, "project", "--name", "e2e-wait", "-o", orgID)
+	cacertID := mustCreate(t, "cacertificate", "--name", "e2e-wait", "-o", orgID, "-p", projectID)
+	var ids []string
+	for _, name := range []string{"e2e-wait-1", "e2e-wait-2"} {
+		ids = append(ids, mustCreate(t, "deployment", "--name", name, "--region-id", "fake-region",
+			"-o", orgID, "-p", projectID, "-c", cacertID))
+	}
+
+	// Make the second deployment ready after a while
+	server.UpdateDeployment(ids[1], func(d *data.Deployment) {
+		d.Status.Ready = false
+		d.Status.Description = "Bootstrapping"
+	})
+	go func() {
+		time.Sleep(1500 * time.Millisecond)
+		server.UpdateDeployment(ids[1], func(d *data.Deployment) {
+			d.Status.Ready = true
+			d.Status.Description = "Ready"
+		})
+	}()
+	var results []map[string]interface{}
+	mustRunJSON(t, &results, "wait", "deployment", ids[0], ids[1], "--for", "ready,servers-ok")
+	if len(results) != 2 || results[0]["reached"] != "true" || results[1]["reached"] != "true" {
+		t.Fatalf("Expected both deployments to be ready, got %v", results)
+	}
+	if d, _ := results[1]["duration-seconds"].(float64); d < 1 {
+		t.Errorf("Expected second deployment to take more than 1s, got %v", results[1])
+	}
+
+	// Wait for all deployments of the project
+	mustRunJSON(t, &results, "wait", "deployment", "--all", "-o", orgID, "-p", projectID, "--for", "bootstrapped,upgraded")
+	if len(results) != 2 {
+		t.Errorf("Expected 2 deployments, got %v", results)
+	}
+
+	// Timeout, independent of the timeout of API calls
+	r := run(t, "wait", "deployment", ids[0], "--for", "paused", "--request-timeout", "5s", "-t", "1s", "--format", "json")
+	if r.exitCode != 1 || !strings.Contains(r.stdout, `"reached": "false"`) {
+		t.Errorf("Expected timeout with exit code 1, got %d:\n%s\n%s", r.exitCode, r.stdout, r.stderr)
+	}
+	r = run(t, "wait", "deployment", ids[0], "--for", "paused", "--request-timeout", "-1s", "--timeout", "1s")
+	if r.exitCode != 3 || !strings.Contains(r.stderr, "--request-timeout cannot be negative") {
+		t.Errorf("Expected usage error for negative request timeout, got %d (%s)", r.exitCode, r.stderr)
+	}
+
+	// Deleted
+	if r := run(t, "delete", "deployment", "-d", ids[0]); r.exitCode != 0 {
+		t.Fatalf("Failed to delete deployment: %s", r.stderr)
+	}
+	mustRunJSON(t, &results, "wait", "deployment", ids[0], "--for", "deleted")
+	if len(results) != 1 || results[0]["reached"] != "true" {
+		t.Errorf("Expected deployment to be deleted, got %v", results)
+	}
+
+	// Select the only deployment of the project when none is given
+	if r := run(t, "wait", "deployment", "-o", orgID, "-p", projectID); r.exitCode != 0 || !strings.HasPrefix(r.stdout, "Deployment ready\n") || !strings.Contains(r.stdout, ids[1]) {
+		t.Errorf("Expected the only deployment to be ready, got %d:\n%s\n%s", r.exitCode, r.stdout, r.stderr)
+	}
+
+	// Invalid condition
+	if r := run(t, "wait", "deployment", ids[1], "--for", "happy"); r.exitCode != 3 {
+		t.Errorf("Expected exit code 3 for unknown condition, got %d (%s)", r.exitCode, r.stderr)
+	}
+}
+
+func TestRestoreBackup(t *testing.T) {
+	orgID := mustCreate(t, "organization", "--name", "e2e-restore")
+	projectID := mustCreate(t, "project", "--name", "e2e-restore", "-o", orgID)
+	cacertID := mustCreate(t, "cacertificate", "--name", "e2e-restore", "-o", orgID, "-p", projectID)
+	deploymentID := mustCreate(t, "deployment", "--name", "e2e-restore", "--region-id", "fake-region",
+		"-o", orgID, "-p", projectID, "-c", cacertID)
+	backupID := mustCreate(t, "backup", "--name", "e2e-restore", "--deployment-id", deploymentID)
+
+	if r := run(t, "restore", "backup", "--id", backupID); r.exitCode != 3 || !strings.Contains(r.stderr, "--yes") {
+		t.Errorf("Expected restore without --yes to fail in non-interactive mode, got %d (%s)", r.exitCode, r.stderr)
+	}
+	r := run(t, "restore", "backup", "--id", backupID, "--yes", "--wait")
+	if r.exitCode != 0 || !strings.Contains(r.stdout, "Backup restored successfully") {
+		t.Fatalf("Expected restore to succeed, got %d (%s)", r.exitCode, r.stderr)
+	}
+	var deployment map[string]interface{}
+	mustRunJSON(t, &deployment, "get", "deployment", "-d", deploymentID, "-o", orgID, "-p", projectID)
+
+	server.UpdateDeployment(deploymentID, func(d *data.Deployment) {
+		d.Servers = &data.Deployment_ServersSpec{Dbservers: 3}
+	})
+	server.UpdateBackup(backupID, func(b *backup.Backup) {
+		b.DeploymentInfo.Servers = &data.Deployment_ServersSpec{Dbservers: 99}
+	})
+	if r := run(t, "restore", "backup", "--id", backupID, "--yes"); r.exitCode != 1 || !strings.Contains(r.stderr, "DB-Servers") {
+		t.Errorf("Expected restore of backup with other number of DB-Servers to fail, got %d (%s)", r.exitCode, r.stderr)
+	}
+
+	// Backups without servers or model spec cannot be checked for DB-Servers
+	server.UpdateBackup(backupID, func(b *backup.Backup) {
+		b.DeploymentInfo.Servers = nil
+		b.DeploymentInfo.Model = nil
+	})
+	if r := run(t, "restore", "backup", "--id", backupID, "--yes"); r.exitCode != 1 || !strings.Contains(r.stderr, "no recorded number of DB-Servers") {
+		t.Errorf("Expected restore of backup without servers spec to fail, got %d (%s)", r.exitCode, r.stderr)
+	}
+
+	// Deployments without servers or model spec are restored with a warning
+	server.UpdateBackup(backupID, func(b *backup.Backup) {
+		b.DeploymentInfo.Servers = &data.Deployment_ServersSpec{Dbservers: 3}
+	})
+	server.UpdateDeployment(deploymentID, func(d *data.Deployment) {
+		d.Servers = nil
+		d.Model = nil
+	})
+	if r := run(t, "restore", "backup", "--id", backupID, "--yes"); r.exitCode != 0 || !strings.Contains(r.stderr, "skipping DB-Server check") {
+		t.Errorf("Expected restore into deployment without servers spec to succeed with a warning, got %d (%s)", r.exitCode, r.stderr)
+	}
+	if r := run(t, "restore", "backup", "--id", backupID); r.exitCode != 3 || !strings.Contains(r.stderr, "number of DB-Servers of the deployment is unknown") {
+		t.Errorf("Expected confirmation to mention the skipped DB-Server check, got %d (%s)", r.exitCode, r.stderr)
+	}
+
+	server.UpdateBackup(backupID, func(b *backup.Backup) {
+		b.Status.UploadOnly = true
+		b.Status.Available = false
+	})
+	if r := run(t, "restore", "backup", "--id", backupID, "--yes"); r.exitCode != 1 || !strings.Contains(r.stderr, "download it first") {
+		t.Errorf("Expected restore of upload-only backup to fail, got %d (%s)", r.exitCode, r.stderr)
+	}
+	server.UpdateBackup(backupID, func(b *backup.Backup) {
+		b.Status.UploadOnly = false
+		b.Status.State = "Create"
+	})
+	if r := run(t, "restore", "backup", "--id", backupID, "--yes"); r.exitCode != 1 || !strings.Contains(r.stderr, "not available for restore (state Create)") {
+		t.Errorf("Expected restore of unavailable backup to fail, got %d (%s)", r.exitCode, r.stderr)
+	}
+}
+
+func TestBackupPolicyLifecycle(t *testing.T) {
+	orgID := mustCreate(t, "organization", "--name", "e2e-policy")
+	projectID := mustCreate(t, "project", "--name", "e2e-policy", "-o", orgID)
+	cacertID := mustCreate(t, "cacertificate", "--name", "e2e-policy", "-o", orgID, "-p", projectID)
+	deploymentID := mustCreate(t, "deployment", "--name", "e2e-policy", "--region-id", "fake-region",
+		"-o", orgID, "-p", projectID, "-c", cacertID)
+
+	if r := run(t, "create", "backup", "policy", "--name", "nightly", "--deployment-id", deploymentID); r.exitCode != 3 {
+		t.Errorf("Expected create without schedule to fail with usage error, got %d (%s)", r.exitCode, r.stderr)
+	}
+	if r := run(t, "create", "backup", "policy", "--name", "nightly", "--deployment-id", deploymentID,
+		"--schedule-type", "hourly"); r.exitCode != 3 || !strings.Contains(r.stderr, "--every-interval-hours") {
+		t.Errorf("Expected hourly schedule without interval to fail, got %d (%s)", r.exitCode, r.stderr)
+	}
+	policyID := mustCreate(t, "backup", "policy", "--name", "nightly", "--deployment-id", deploymentID,
+		"--schedule-type", "daily", "--days", "mon,fri", "--time", "02:30", "--retention-period", "168h",
+		"--email-notification", "failure-only")
+
+	var policy map[string]interface{}
+	mustRunJSON(t, &policy, "get", "backup", "policy", "--id", "nightly", "--deployment-id", deploymentID)
+	if policy["id"] != policyID || policy["schedule"] != "Daily at 02:30 UTC on Mon,Fri" ||
+		policy["retention-period"] != "168h0m0s" || policy["email-notification"] != "FailureOnly" {
+		t.Errorf("Unexpected backup policy: %v", policy)
+	}
+
+	if r := run(t, "update", "backup", "policy", "--id", policyID, "--paused", "--time", "04:00"); r.exitCode != 0 {
+		t.Fatalf("Expected update to succeed, got %d (%s)", r.exitCode, r.stderr)
+	}
+	mustRunJSON(t, &policy, "get", "backup", "policy", "--id", policyID)
+	if policy["paused"] != true || policy["schedule"] != "Daily at 04:00 UTC on Mon,Fri" {
+		t.Errorf("Unexpected updated backup policy: %v", policy)
+	}
+
+	var list []map[string]interface{}
+	mustRunJSON(t, &list, "list", "backup", "policies", "--deployment-id", deploymentID)
+	if len(list) != 1 || list[0]["id"] != policyID {
+		t.Errorf("Expected 1 backup policy, got %v", list)
+	}
+
+	if r := run(t, "delete", "backup", "policy", "--id", policyID); r.exitCode != 0 {
+		t.Fatalf("Expected delete to succeed, got %d (%s)", r.exitCode, r.stderr)
+	}
+	mustRunJSON(t, &list, "list", "backup", "policies", "--deployment-id", deploymentID)
+	if len(list) != 0 {
+		t.Errorf("Expected no backup policies after delete, got %v", list)
+	}
+}
+
+func TestWaitBackup(t *testing.T) {
+	orgID := mustCreate(t, "organization", "--name", "e2e-wait-backup")
+	projectID := mustCreate(t, "project", "--name", "e2e-wait-backup", "-o", orgID)
+	cacertID := mustCreate(t, "cacertificate", "--name", "e2e-wait-backup", "-o", orgID, "-p", projectID)
+	deploymentID := mustCreate(t, "deployment", "--name", "e2e-wait-backup", "--region-id", "fake-region",
+		"-o", orgID, "-p", projectID, "-c", cacertID)
+
+	var b map[string]interface{}
+	mustRunJSON(t, &b, "create", "backup", "--name", "e2e-wait-backup", "--deployment-id", deploymentID, "--upload", "--wait")
+	if b["state"] != "Ready" || b["uploaded"] != true {
+		t.Errorf("Expected ready & uploaded backup, got %v", b)
+	}
+	backupID := b["id"].(string)
+
+	// Wait for the upload to complete
+	server.UpdateBackup(backupID, func(b *backup.Backup) {
+		b.Status.State = "Uploading"
+		b.Status.UploadStatus.Uploaded = false
+	})
+	go func() {
+		time.Sleep(time.Second * 2)
+		server.UpdateBackup(backupID, func(b *backup.Backup) {
+			b.Status.State = "Ready"
+			b.Status.UploadStatus.Uploaded = true
+		})
+	}()
+	start := time.Now()
+	mustRunJSON(t, &b, "wait", "backup", "--id", backupID)
+	if time.Since(start) < time.Second*2 || b["uploaded"] != true {
+		t.Errorf("Expected wait to return after upload completed, got %v after %s", b, time.Since(start))
+	}
+
+	// Timeout
+	server.UpdateBackup(backupID, func(b *backup.Backup) { b.Status.State = "Pending" })
+	if r := run(t, "wait", "backup", "--id", backupID, "--timeout", "1s"); r.exitCode != 1 || !strings.Contains(r.stderr, "not ready") {
+		t.Errorf("Expected timeout, got %d (%s)", r.exitCode, r.stderr)
+	}
+
+	// Failure
+	server.UpdateBackup(backupID, func(b *backup.Backup) {
+		b.Status.State = "UploadError"
+		b.Status.Message = "bucket not writable"
+	})
+	if r := run(t, "wait", "backup", "--id", backupID); r.exitCode != 1 || !strings.Contains(r.stderr, "bucket not writable") {
+		t.Errorf("Expected failure with status message, got %d (%s)", r.exitCode, r.stderr)
+	}
+}
+
+func TestDownloadBackup(t *testing.T) {
+	orgID := mustCreate(t, "organization", "--name", "e2e-download")
+	projectID := mustCreate(t, "project", "--name", "e2e-download", "-o", orgID)
+	cacertID := mustCreate(t, "cacertificate", "--name", "e2e-download", "-o", orgID, "-p", projectID)
+	deploymentID := mustCreate(t, "deployment", "--name", "e2e-download", "--region-id", "fake-region",
+		"-o", orgID, "-p", projectID, "-c", cacertID)
+	localID := mustCreate(t, "backup", "--name", "e2e-local", "--deployment-id", deploymentID)
+	uploadedID := mustCreate(t, "backup", "--name", "e2e-uploaded", "--deployment-id", deploymentID, "--upload")
+
+	if r := run(t, "backup", "download", "--id", localID); r.exitCode == 0 {
+		t.Errorf("Expected download of backup that is not uploaded to fail")
+	}
+
+	var status map[string]interface{}
+	mustRunJSON(t, &status, "backup", "download", "--id", uploadedID, "--wait")
+	if status["downloaded"] != true || status["deployment-id"] != deploymentID || status["revision"] != float64(1) {
+		t.Errorf("Expected downloaded backup, got %v", status)
+	}
+	mustRunJSON(t, &status, "backup", "download", "--id", uploadedID)
+	if status["revision"] != float64(2) {
+		t.Errorf("Expected second download revision, got %v", status)
+	}
+}
+
+func TestPruneBackups(t *testing.T) {
+	orgID := mustCreate(t, "organization", "--name", "e2e-prune")
+	projectID := mustCreate(t, "project", "--name", "e2e-prune", "-o", orgID)
+	cacertID := mustCreate(t, "cacertificate", "--name", "e2e-prune", "-o", orgID, "-p", projectID)
+	deploymentID := mustCreate(t, "deployment", "--name", "e2e-prune", "--region-id", "fake-region",
+		"-o", orgID, "-p", projectID, "-c", cacertID)
+
+	// Create backups at noon (UTC) of given days ago
+	noon := time.Now().UTC().Truncate(24 * time.Hour).Add(12 * time.Hour)
+	ids := make(map[string]string)
+	for _, x := range []struct {
+		name   string
+		age    time.Duration
+		policy string
+	}{
+		{"today", 0, ""},
+		{"yesterday", 24 * time.Hour, ""},
+		{"yesterday-early", 25 * time.Hour, ""},
+		{"2-days", 48 * time.Hour, ""},
+		{"10-days", 240 * time.Hour, ""},
+		{"40-days", 960 * time.Hour, ""},
+		{"policy", 960 * time.Hour, "bp1"},
+	} {
+		id := mustCreate(t, "backup", "--name", x.name, "--deployment-id", deploymentID)
+		createdAt := noon.Add(-x.age)
+		server.UpdateBackup(id, func(b *backup.Backup) {
+			b.CreatedAt = &types.Timestamp{Seconds: createdAt.Unix()}
+			b.BackupPolicyId = x.policy
+			b.AutoDeletedAt = nil
+		})
+		ids[x.name] = id
+	}
+	backupNames := func() []string {
+		var list []map[string]interface{}
+		mustRunJSON(t, &list, "list", "backups", "--deployment-id", deploymentID)
+		var names []string
+		for _, x := range list {
+			names = append(names, x["name"].(string))
+		}
+		sort.Strings(names)
+		return names
+	}
+
+	if r := run(t, "prune", "backups", "--deployment-id", deploymentID); r.exitCode != 3 {
+		t.Errorf("Expected prune without retention to fail with usage error, got %d (%s)", r.exitCode, r.stderr)
+	}
+
+	// Dry run (default)
+	var plan []map[string]interface{}
+	mustRunJSON(t, &plan, "prune", "backups", "--deployment-id", deploymentID, "--keep-last", "1", "--keep-daily", "3")
+	actions := make(map[string]string)
+	for _, x := range plan {
+		actions[x["name"].(string)] = x["action"].(string)
+	}
+	expected := map[string]string{
+		"today":           "keep",
+		"yesterday":       "keep",
+		"yesterday-early": "delete",
+		"2-days":          "keep",
+		"10-days":         "delete",
+		"40-days":         "delete",
+	}
+	if !reflect.DeepEqual(actions, expected) {
+		t.Errorf("Unexpected prune plan %v, expected %v", actions, expected)
+	}
+	if names := backupNames(); len(names) != 7 {
+		t.Errorf("Expected dry run to keep all backups, got %v", names)
+	}
+
+	// Prune
+	if r := run(t, "prune", "backups", "--deployment-id", deploymentID, "--keep-last", "1", "--keep-daily", "3", "--dry-run=false"); r.exitCode != 3 {
+		t.Errorf("Expected prune without --yes to fail in non-interactive mode, got %d (%s)", r.exitCode, r.stderr)
+	}
+	mustRunJSON(t, &plan, "prune", "backups", "--deployment-id", deploymentID, "--keep-last", "1", "--keep-daily", "3", "--dry-run=false", "--yes")
+	if names := backupNames(); !reflect.DeepEqual(names, []string{"2-days", "policy", "today", "yesterday"}) {
+		t.Errorf("Unexpected backups after prune: %v", names)
+	}
+
+	// Schedule automatic deletion
+	mustRunJSON(t, &plan, "prune", "backups", "--deployment-id", deploymentID, "--older-than", "36h",
+		"--auto-delete-after", "1d", "--dry-run=false", "--yes")
+	for _, x := range plan {
+		if expired := x["name"] == "2-days"; (x["action"] == "expired") != expired || (x["auto-deleted-at"] != "-") != expired {
+			t.Errorf("Unexpected prune result %v", x)
+		}
+		if x["name"] == "today" && x["reason"] != "newer than 1d12h" {
+			t.Errorf("Expected reason to show --older-than in days, got %v", x)
+		}
+	}
+}
+
+func TestTimeFlags(t *testing.T) {
+	orgID := mustCreate(t, "organization", "--name", "e2e-time")
+	projectID := mustCreate(t, "project", "--name", "e2e-time", "-o", orgID)
+	cacertID := mustCreate(t, "cacertificate", "--name", "e2e-time", "-o", orgID, "-p", projectID)
+	deploymentID := mustCreate(t, "deployment", "--name", "e2e-time", "--region-id", "fake-region",
+		"-o", orgID, "-p", projectID, "-c", cacertID)
+
+	var b map[string]interface{}
+	mustRunJSON(t, &b, "create", "backup", "--name", "e2e-time", "--deployment-id", deploymentID, "--auto-deleted-at", "2d")
+	autoDeletedAt, err := time.Parse(time.RFC3339, b["auto-deleted-at"].(string))
+	if err != nil || autoDeletedAt.Sub(time.Now()) < 47*time.Hour || autoDeletedAt.Sub(time.Now()) > 49*time.Hour {
+		t.Errorf("Expected backup to be deleted in 2 days, got %v (%v)", b["auto-deleted-at"], err)
+	}
+	backupID := b["id"].(string)
+
+	// Plain number of hours is still supported
+	if r := run(t, "update", "backup", "--backup-id", backupID, "--auto-deleted-at", "12"); r.exitCode != 0 {
+		t.Errorf("Expected update with hours to succeed, got %d (%s)", r.exitCode, r.stderr)
+	}
+	if r := run(t, "update", "backup", "--backup-id", backupID, "--name", "renamed"); r.exitCode != 0 {
+		t.Errorf("Expected update without --auto-deleted-at to succeed, got %d (%s)", r.exitCode, r.stderr)
+	}
+
+	var list []map[string]interface{}
+	mustRunJSON(t, &list, "list", "backups", "--deployment-id", deploymentID, "--from", "1h", "--to", "tomorrow")
+	if len(list) != 1 {
+		t.Errorf("Expected 1 backup created in the last hour, got %v", list)
+	}
+	mustRunJSON(t, &list, "list", "backups", "--deployment-id", deploymentID, "--to", "yesterday")
+	if len(list) != 0 {
+		t.Errorf("Expected no backups created before yesterday, got %v", list)
+	}
+
+	for _, args := range [][]string{
+		{"list", "backups", "--deployment-id", deploymentID, "--from", "soon"},
+		{"logs", "--deployment-id", deploymentID, "--start", "soon"},
+		{"create", "backup", "--name", "e2e-time", "--deployment-id", deploymentID, "--auto-deleted-at", "soon"},
+	} {
+		if r := run(t, args...); r.exitCode != 3 || !strings.Contains(r.stderr, "invalid time 'soon'") {
+			t.Errorf("Expected invalid time error for %v, got %d (%s)", args, r.exitCode, r.stderr)
+		}
+	}
+	if r := run(t, "wait", "backup", "--id", backupID, "--timeout", "soon"); r.exitCode != 3 || !strings.Contains(r.stderr, "invalid duration 'soon'") {
+		t.Errorf("Expected invalid duration error, got %d (%s)", r.exitCode, r.stderr)
+	}
+}
+
+func TestLogsFollow(t *testing.T) {
+	orgID := mustCreate(t, "organization", "--name", "e2e-logs")
+	projectID := mustCreate(t, "project", "--name", "e2e-logs", "-o", orgID)
+	cacertID := mustCreate(t, "cacertificate", "--name", "e2e-logs", "-o", orgID, "-p", projectID)
+	deploymentID := mustCreate(t, "deployment", "--name", "e2e-logs", "--region-id", "fake-region",
+		"-o", orgID, "-p", projectID, "-c", cacertID)
+	start := time.Now().Add(-10 * time.Minute)
+	server.AddDeploymentLogs(deploymentID,
+		fakeapi.LogLine{Timestamp: start, Message: "first"},
+		fakeapi.LogLine{Timestamp: start, Message: "second"},
+		fakeapi.LogLine{Timestamp: start.Add(time.Minute), Message: "third"},
+	)
+
+	if r := run(t, "logs", "-d", deploymentID, "--follow", "--end", "1m"); r.exitCode != 3 {
+		t.Errorf("Expected --follow with --end to fail with usage error, got %d (%s)", r.exitCode, r.stderr)
+	}
+
+	cmd := exec.Command(binary, "logs", "-d", deploymentID, "--follow", "--start", "1h")
+	cmd.Env = []string{
+		"HOME=" + homeDir,
+		"OASIS_ENDPOINT=" + server.Address(),
+		"OASIS_PLAINTEXT=true",
+		"OASIS_TOKEN=" + fakeapi.DefaultToken,
+		"OASIS_RETRIES=0",
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cmd.Start(); err != nil {
+		t.Fatalf("Failed to start oasisctl: %v", err)
+	}
+	defer cmd.Process.Kill()
+	lines := make(chan string)
+	go func() {
+		scanner := bufio.NewScanner(stdout)
+		for scanner.Scan() {
+			lines <- scanner.Text()
+		}
+		close(lines)
+	}()
+	next := func() string {
+		select {
+		case line := <-lines:
+			return line
+		case <-time.After(10 * time.Second):
+			t.Fatal("Timeout waiting for log lines")
+			return ""
+		}
+	}
+
+	for _, expected := range []string{"first", "second", "third"} {
+		if line := next(); !strings.HasSuffix(line, " "+expected) {
+			t.Errorf("Expected log line '%s', got '%s'", expected, line)
+		}
+	}
+	// New lines are shown once, also after a failing poll
+	server.FailNext("GetDeploymentLogs", status.Error(codes.Unavailable, "fake unavailable"))
+	server.AddDeploymentLogs(deploymentID,
+		fakeapi.LogLine{Timestamp: start.Add(time.Minute), Message: "fourth"},
+		fakeapi.LogLine{Timestamp: start.Add(2 * time.Minute), Message: "fifth"},
+	)
+	for _, expected := range []string{"fourth", "fifth"} {
+		if line := next(); !strings.HasSuffix(line, " "+expected) {
+			t.Errorf("Expected log line '%s', got '%s'", expected, line)
+		}
+	}
+
+	// Interrupt
+	cmd.Process.Signal(os.Interrupt)
+	for line := range lines {
+		t.Errorf("Unexpected log line '%s'", line)
+	}
+	if err := cmd.Wait(); err != nil {
+		t.Errorf("Expected clean exit after interrupt, got %v", err)
+	}
+
+	// Errors that are not transient are not retried
+	server.FailNext("GetDeploymentLogs", status.Error(codes.PermissionDenied, "fake permission denied"))
+	r := run(t, "logs", "-d", deploymentID, "--follow")
+	if r.exitCode != 5 || !strings.Contains(r.stderr, "Failed to fetch deployment logs") {
+		t.Errorf("Expected follow to fail with exit code 5, got %d (%s)", r.exitCode, r.stderr)
+	}
+}
+
+func TestLoginStoresExplicitTokenOnly(t *testing.T) {
+	credentials := filepath.Join(t.TempDir(), "credentials.yaml")
+	env := []string{"OASIS_CREDENTIALS=" + credentials}
+
+	// The token from OASIS_TOKEN must not be stored
+	if r := runWithEnv(t, env, "login"); r.exitCode != 3 {
+		t.Fatalf("Expected exit code 3, got %d (%s)", r.exitCode, r.stderr)
+	}
+	if _, err := os.Stat(credentials); !os.IsNotExist(err) {
+		t.Fatalf("Expected no credentials file, got %v", err)
+	}
+
+	// An explicitly given token is stored
+	if r := runWithEnv(t, env, "login", "--token", "explicit-token"); r.exitCode != 0 {
+		t.Fatalf("Login failed: %s", r.stderr)
+	}
+	content, err := ioutil.ReadFile(credentials)
+	if err != nil {
+		t.Fatalf("Failed to read credentials: %v", err)
+	}
+	if !strings.Contains(string(content), "explicit-token") {
+		t.Errorf("Expected stored token, got %s", content)
+	}
+}
+
+// writeManifest writes a manifest for the organization with given name
+// to a temporary file and returns its path.
+func writeManifest(t *testing.T, orgName, cacertLifetime string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "manifest.yaml")
+	content := fmt.Sprintf(`organization: %s
+groups:
+- name: operators
+roles:
+- name: viewer
+  permissions: [data.deployment.get]
+projects:
+- name: production
+  policy:
+    bindings:
+    - role: viewer
+      group: operators
+  cacertificates:
+  - name: main
+    lifetime: %s
+  ipwhitelists:
+  - name: office
+    cidr-ranges: ["1.2.3.0/24"]
+  deployments:
+  - name: main
+    region: fake-region
+    version: 3.6.4
+    cacertificate: main
+    ipwhitelist: office
+    model: oneshard
+    node-size-id: c4-a4
+    node-disk-size: 20
+`, orgName, cacertLifetime)
+	if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write manifest: %v", err)
+	}
+	return path
+}
+
+func TestApplyIsIdempotent(t *testing.T) {
+	orgID := mustCreate(t, "organization", "--name", "e2e-apply")
+	path := writeManifest(t, "e2e-apply", "365d")
+
+	if r := run(t, "apply", "-f", path); r.exitCode != 0 || !strings.Contains(r.stdout, "Applied 7 change(s)") {
+		t.Fatalf("Expected apply to create all resources, got %d (%s%s)", r.exitCode, r.stdout, r.stderr)
+	}
+	if r := run(t, "apply", "-f", path); r.exitCode != 0 || strings.TrimSpace(r.stdout) != "No changes" {
+		t.Errorf("Expected second apply to make no changes, got %d (%s%s)", r.exitCode, r.stdout, r.stderr)
+	}
+
+	// Fields that are not set in the manifest are left untouched
+	if r := run(t, "update", "cacertificate", "-o", orgID, "-p", "production", "-c", "main",
+		"--use-well-known-certificate"); r.exitCode != 0 {
+		t.Fatalf("Failed to update CA certificate: %s", r.stderr)
+	}
+	if r := run(t, "apply", "-f", path); r.exitCode != 0 || strings.TrimSpace(r.stdout) != "No changes" {
+		t.Errorf("Expected apply to keep use-well-known-certificate, got %d (%s%s)", r.exitCode, r.stdout, r.stderr)
+	}
+
+	// A deleted group is created again
+	if r := run(t, "delete", "group", "-o", orgID, "-g", "operators"); r.exitCode != 0 {
+		t.Fatalf("Failed to delete group: %s", r.stderr)
+	}
+	if r := run(t, "apply", "-f", path); r.exitCode != 0 || !strings.Contains(r.stdout, "+ group operators") {
+		t.Errorf("Expected apply to create deleted group, got %d (%s%s)", r.exitCode, r.stdout, r.stderr)
+	}
+}
+
+func TestDiffDetectsDrift(t *testing.T) {
+	orgID := mustCreate(t, "organization", "--name", "e2e-diff")
+	path := writeManifest(t, "e2e-diff", "365d")
+
+	if r := run(t, "diff", "-f", path); r.exitCode != 8 || !strings.Contains(r.stdout, "+ deployment production/main") {
+		t.Errorf("Expected diff to exit with code 8 before apply, got %d (%s%s)", r.exitCode, r.stdout, r.stderr)
+	}
+	if r := run(t, "apply", "-f", path); r.exitCode != 0 {
+		t.Fatalf("Failed to apply manifest: %s", r.stderr)
+	}
+	if r := run(t, "diff", "-f", path); r.exitCode != 0 || strings.TrimSpace(r.stdout) != "No changes" {
+		t.Errorf("Expected no drift after apply, got %d (%s%s)", r.exitCode, r.stdout, r.stderr)
+	}
+
+	// A dry-run update that does not change anything reports no changes
+	deployment := []string{"-o", orgID, "-p", "production", "-d", "main"}
+	if r := run(t, append([]string{"update", "deployment", "--version", "3.6.4", "--dry-run"}, deployment...)...); r.exitCode != 0 || strings.TrimSpace(r.stdout) != "No changes" {
+		t.Errorf("Expected dry-run without real changes to report no changes, got %d (%s%s)", r.exitCode, r.stdout, r.stderr)
+	}
+	if r := run(t, append([]string{"update", "deployment", "--version", "3.7.0"}, deployment...)...); r.exitCode != 0 {
+		t.Fatalf("Failed to update deployment: %s", r.stderr)
+	}
+	r := run(t, "diff", "-f", path, "--no-color")
+	if r.exitCode != 8 || !strings.Contains(r.stdout, "-     version: 3.7.0") || !strings.Contains(r.stdout, "+     version: 3.6.4") {
+		t.Errorf("Expected diff to exit with code 8 on drift, got %d (%s%s)", r.exitCode, r.stdout, r.stderr)
+	}
+}
+
+func TestExportRoundTrip(t *testing.T) {
+	orgID := mustCreate(t, "organization", "--name", "e2e-export")
+	if r := run(t, "apply", "-f", writeManifest(t, "e2e-export", "8760h")); r.exitCode != 0 {
+		t.Fatalf("Failed to apply manifest: %s", r.stderr)
+	}
+
+	path := filepath.Join(t.TempDir(), "exported.yaml")
+	if r := run(t, "export", "-o", orgID, "--output", path); r.exitCode != 0 {
+		t.Fatalf("Failed to export organization: %s", r.stderr)
+	}
+	exported, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read exported manifest: %v", err)
+	}
+	if !strings.Contains(string(exported), "lifetime: 365d") {
+		t.Errorf("Expected CA certificate lifetime of 365d, got:\n%s", exported)
+	}
+	if r := run(t, "diff", "-f", path); r.exitCode != 0 {
+		t.Errorf("Expected exported manifest to match, got %d (%s%s)", r.exitCode, r.stdout, r.stderr)
+	}
+	if r := run(t, "apply", "-f", path); r.exitCode != 0 || strings.TrimSpace(r.stdout) != "No changes" {
+		t.Errorf("Expected apply of exported manifest to make no changes, got %d (%s%s)", r.exitCode, r.stdout, r.stderr)
+	}
+}
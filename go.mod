@@ -1,6 +1,6 @@
 module github.com/arangodb-managed/oasisctl
 
-go 1.12
+go 1.13
 
 replace github.com/golang/lint => golang.org/x/lint v0.0.0-20181026193005-c67002cb31c3
 
@@ -20,7 +20,7 @@ require (
 	golang.org/x/text v0.3.2 // indirect
 	google.golang.org/genproto v0.0.0-20190502173448-54afdca5d873 // indirect
 	google.golang.org/grpc v1.21.1
-	gopkg.in/yaml.v2 v2.2.8 // indirect
+	gopkg.in/yaml.v2 v2.2.8
 )
 
 replace github.com/coreos/prometheus-operator => github.com/coreos/prometheus-operator v0.31.1
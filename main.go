@@ -23,7 +23,7 @@
 package main
 
 import (
-	"log"
+	"os"
 
 	_ "github.com/gogo/protobuf/types"
 
@@ -43,6 +43,6 @@ func init() {
 
 func main() {
 	if err := cmd.RootCmd.Execute(); err != nil {
-		log.Fatalf("%v\n", err)
+		os.Exit(cmd.HandleError(err))
 	}
 }
@@ -0,0 +1,256 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Ewout Prangsma
+//
+
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// DefaultProfileName is the name of the profile used when no profile is selected.
+	DefaultProfileName = "default"
+
+	// Keys of the values that can be stored in a profile.
+	KeyEndpoint     = "endpoint"
+	KeyToken        = "token"
+	KeyTokenFile    = "token-file"
+	KeyFormat       = "format"
+	KeyOrganization = "organization"
+	KeyProject      = "project"
+	KeyDeployment   = "deployment"
+	KeyRegion       = "region"
+	KeyProvider     = "provider"
+	// Keys of the connection settings.
+	KeyCACert             = "ca-cert"
+	KeyClientCert         = "client-cert"
+	KeyClientKey          = "client-key"
+	KeyInsecureSkipVerify = "insecure-skip-verify"
+	KeyPlaintext          = "plaintext"
+	KeyProxy              = "proxy"
+)
+
+// Config holds the content of the oasisctl configuration file.
+type Config struct {
+	// Name of the profile that is used when no --profile is given.
+	CurrentProfile string `yaml:"current-profile,omitempty"`
+	// Named profiles
+	Profiles map[string]*Profile `yaml:"profiles,omitempty"`
+}
+
+// Profile holds a named set of default values.
+type Profile struct {
+	Endpoint     string `yaml:"endpoint,omitempty"`
+	Token        string `yaml:"token,omitempty"`
+	TokenFile    string `yaml:"token-file,omitempty"`
+	Format       string `yaml:"format,omitempty"`
+	Organization string `yaml:"organization,omitempty"`
+	Project      string `yaml:"project,omitempty"`
+	Deployment   string `yaml:"deployment,omitempty"`
+	Region       string `yaml:"region,omitempty"`
+	Provider     string `yaml:"provider,omitempty"`
+	// Connection settings
+	CACert             string `yaml:"ca-cert,omitempty"`
+	ClientCert         string `yaml:"client-cert,omitempty"`
+	ClientKey          string `yaml:"client-key,omitempty"`
+	InsecureSkipVerify string `yaml:"insecure-skip-verify,omitempty"`
+	Plaintext          string `yaml:"plaintext,omitempty"`
+	Proxy              string `yaml:"proxy,omitempty"`
+}
+
+// DefaultPath returns the default location of the configuration file.
+func DefaultPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "oasisctl", "config.yaml"), nil
+}
+
+// Load the configuration from the file with given path.
+// If the file does not exist, an empty configuration is returned.
+func Load(path string) (*Config, error) {
+	cfg := &Config{}
+	raw, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return cfg, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if err := yaml.Unmarshal(raw, cfg); err != nil {
+		return nil, fmt.Errorf("Failed to parse %s: %s", path, err)
+	}
+	return cfg, nil
+}
+
+// Save the configuration to the file with given path.
+// The file may contain tokens, so it is only readable by the current user.
+func (c *Config) Save(path string) error {
+	raw, err := yaml.Marshal(c)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, raw, 0600)
+}
+
+// Profile returns the profile with given name or nil if no such profile exists.
+func (c *Config) Profile(name string) *Profile {
+	return c.Profiles[name]
+}
+
+// EnsureProfile returns the profile with given name, creating it when needed.
+func (c *Config) EnsureProfile(name string) *Profile {
+	if c.Profiles == nil {
+		c.Profiles = make(map[string]*Profile)
+	}
+	p, found := c.Profiles[name]
+	if !found {
+		p = &Profile{}
+		c.Profiles[name] = p
+	}
+	return p
+}
+
+// ProfileNames returns the sorted names of all profiles.
+func (c *Config) ProfileNames() []string {
+	result := make([]string, 0, len(c.Profiles))
+	for name := range c.Profiles {
+		result = append(result, name)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// Keys returns all keys that can be used in Get & Set.
+func Keys() []string {
+	return []string{
+		KeyEndpoint,
+		KeyToken,
+		KeyTokenFile,
+		KeyFormat,
+		KeyOrganization,
+		KeyProject,
+		KeyDeployment,
+		KeyRegion,
+		KeyProvider,
+		KeyCACert,
+		KeyClientCert,
+		KeyClientKey,
+		KeyInsecureSkipVerify,
+		KeyPlaintext,
+		KeyProxy,
+	}
+}
+
+// field returns a pointer to the field of the profile identified by given key.
+func (p *Profile) field(key string) (*string, error) {
+	switch key {
+	case KeyEndpoint:
+		return &p.Endpoint, nil
+	case KeyToken:
+		return &p.Token, nil
+	case KeyTokenFile:
+		return &p.TokenFile, nil
+	case KeyFormat:
+		return &p.Format, nil
+	case KeyOrganization:
+		return &p.Organization, nil
+	case KeyProject:
+		return &p.Project, nil
+	case KeyDeployment:
+		return &p.Deployment, nil
+	case KeyRegion:
+		return &p.Region, nil
+	case KeyProvider:
+		return &p.Provider, nil
+	case KeyCACert:
+		return &p.CACert, nil
+	case KeyClientCert:
+		return &p.ClientCert, nil
+	case KeyClientKey:
+		return &p.ClientKey, nil
+	case KeyInsecureSkipVerify:
+		return &p.InsecureSkipVerify, nil
+	case KeyPlaintext:
+		return &p.Plaintext, nil
+	case KeyProxy:
+		return &p.Proxy, nil
+	default:
+		return nil, fmt.Errorf("Unknown key '%s', expected one of: %s", key, strings.Join(Keys(), ", "))
+	}
+}
+
+// Get returns the value of the given key.
+// A nil profile has no values.
+func (p *Profile) Get(key string) (string, error) {
+	if p == nil {
+		if _, err := (&Profile{}).field(key); err != nil {
+			return "", err
+		}
+		return "", nil
+	}
+	f, err := p.field(key)
+	if err != nil {
+		return "", err
+	}
+	return *f, nil
+}
+
+// Set the value of the given key.
+// An empty value removes the key from the profile.
+func (p *Profile) Set(key, value string) error {
+	f, err := p.field(key)
+	if err != nil {
+		return err
+	}
+	*f = value
+	return nil
+}
+
+// GetToken returns the token of the profile, reading it from
+// the token file when no token is stored directly.
+func (p *Profile) GetToken() (string, error) {
+	if p == nil {
+		return "", nil
+	}
+	if p.Token != "" {
+		return p.Token, nil
+	}
+	if p.TokenFile == "" {
+		return "", nil
+	}
+	raw, err := ioutil.ReadFile(p.TokenFile)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(raw)), nil
+}
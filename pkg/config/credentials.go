@@ -0,0 +1,129 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Ewout Prangsma
+//
+
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Credentials holds the content of the oasisctl credentials file.
+type Credentials struct {
+	// Credentials per profile name
+	Profiles map[string]*ProfileCredentials `yaml:"profiles,omitempty"`
+}
+
+// ProfileCredentials holds the stored credentials of a single profile.
+type ProfileCredentials struct {
+	// Identifier of the API key used to (re-)authenticate
+	KeyID string `yaml:"key-id,omitempty"`
+	// Secret of the API key used to (re-)authenticate
+	KeySecret string `yaml:"key-secret,omitempty"`
+	// Last obtained token
+	Token string `yaml:"token,omitempty"`
+	// Time the token expires. Zero when unknown.
+	ExpiresAt time.Time `yaml:"expires-at,omitempty"`
+}
+
+// DefaultCredentialsPath returns the default location of the credentials file.
+func DefaultCredentialsPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "oasisctl", "credentials.yaml"), nil
+}
+
+// LoadCredentials loads the credentials from the file with given path.
+// If the file does not exist, empty credentials are returned.
+func LoadCredentials(path string) (*Credentials, error) {
+	creds := &Credentials{}
+	raw, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return creds, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if err := yaml.Unmarshal(raw, creds); err != nil {
+		return nil, fmt.Errorf("Failed to parse %s: %s", path, err)
+	}
+	return creds, nil
+}
+
+// Save the credentials to the file with given path.
+// The file is only readable by the current user.
+func (c *Credentials) Save(path string) error {
+	raw, err := yaml.Marshal(c)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(path, raw, 0600); err != nil {
+		return err
+	}
+	// WriteFile does not change the permissions of an existing file.
+	return os.Chmod(path, 0600)
+}
+
+// Profile returns the credentials of the profile with given name or nil if not found.
+func (c *Credentials) Profile(name string) *ProfileCredentials {
+	return c.Profiles[name]
+}
+
+// SetProfile stores the credentials of the profile with given name.
+// Passing nil removes the credentials of the profile.
+func (c *Credentials) SetProfile(name string, pc *ProfileCredentials) {
+	if pc == nil {
+		delete(c.Profiles, name)
+		return
+	}
+	if c.Profiles == nil {
+		c.Profiles = make(map[string]*ProfileCredentials)
+	}
+	c.Profiles[name] = pc
+}
+
+// CanRefresh returns true when the credentials contain an API key
+// that can be used to obtain a new token.
+func (pc *ProfileCredentials) CanRefresh() bool {
+	return pc.KeyID != "" && pc.KeySecret != ""
+}
+
+// NeedsRefresh returns true when there is no token or the token
+// expires within the given margin.
+func (pc *ProfileCredentials) NeedsRefresh(margin time.Duration) bool {
+	if pc.Token == "" {
+		return true
+	}
+	if pc.ExpiresAt.IsZero() {
+		return false
+	}
+	return time.Now().Add(margin).After(pc.ExpiresAt)
+}
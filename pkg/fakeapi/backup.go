@@ -0,0 +1,337 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Ewout Prangsma
+//
+
+package fakeapi
+
+import (
+	"context"
+
+	"github.com/gogo/protobuf/types"
+
+	backup "github.com/arangodb-managed/apis/backup/v1"
+	common "github.com/arangodb-managed/apis/common/v1"
+	data "github.com/arangodb-managed/apis/data/v1"
+)
+
+const (
+	// backupSize is the size (in bytes) of every backup.
+	backupSize = 1024 * 1024
+)
+
+// backupService implements the BackupService.
+type backupService struct {
+	backup.UnimplementedBackupServiceServer
+	s *Server
+}
+
+// GetAPIVersion returns the version of the API.
+func (x *backupService) GetAPIVersion(context.Context, *common.Empty) (*common.Version, error) {
+	return version(), nil
+}
+
+// IsBackupFeatureAvailable returns yes for all deployments.
+func (x *backupService) IsBackupFeatureAvailable(ctx context.Context, req *common.IDOptions) (*common.YesOrNo, error) {
+	return &common.YesOrNo{Result: true}, nil
+}
+
+// IsBackupUploadFeatureAvailable returns yes for all deployments.
+func (x *backupService) IsBackupUploadFeatureAvailable(ctx context.Context, req *common.IDOptions) (*common.YesOrNo, error) {
+	return &common.YesOrNo{Result: true}, nil
+}
+
+// ListBackupPolicies returns the backup policies of a deployment.
+func (x *backupService) ListBackupPolicies(ctx context.Context, req *backup.ListBackupPoliciesRequest) (*backup.BackupPolicyList, error) {
+	s := x.s
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if _, err := s.deployment(ctx, req.GetDeploymentId()); err != nil {
+		return nil, err
+	}
+	result := &backup.BackupPolicyList{}
+	for _, p := range s.backupPolicies {
+		if p.GetDeploymentId() == req.GetDeploymentId() && (!p.GetIsDeleted() || req.GetIncludeDeleted()) {
+			result.Items = append(result.Items, clone(p).(*backup.BackupPolicy))
+		}
+	}
+	return result, nil
+}
+
+// GetBackupPolicy returns the backup policy with given ID.
+func (x *backupService) GetBackupPolicy(ctx context.Context, req *common.IDOptions) (*backup.BackupPolicy, error) {
+	s := x.s
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	p, err := s.backupPolicy(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return clone(p).(*backup.BackupPolicy), nil
+}
+
+// CreateBackupPolicy creates a backup policy.
+func (x *backupService) CreateBackupPolicy(ctx context.Context, req *backup.BackupPolicy) (*backup.BackupPolicy, error) {
+	s := x.s
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	d, err := s.deployment(ctx, req.GetDeploymentId())
+	if err != nil {
+		return nil, err
+	}
+	if req.GetName() == "" {
+		return nil, common.InvalidArgument("Name missing")
+	}
+	if req.GetSchedule() == nil {
+		return nil, common.InvalidArgument("Schedule missing")
+	}
+	p := clone(req).(*backup.BackupPolicy)
+	p.Id = s.newID()
+	p.Url = d.GetUrl() + "/BackupPolicy/" + p.Id
+	p.CreatedAt = types.TimestampNow()
+	if p.GetEmailNotification() == "" {
+		p.EmailNotification = "Never"
+	}
+	s.backupPolicies[p.Id] = p
+	return clone(p).(*backup.BackupPolicy), nil
+}
+
+// UpdateBackupPolicy updates a backup policy.
+func (x *backupService) UpdateBackupPolicy(ctx context.Context, req *backup.BackupPolicy) (*backup.BackupPolicy, error) {
+	s := x.s
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	existing, err := s.backupPolicy(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	p := clone(req).(*backup.BackupPolicy)
+	// Restore read-only fields
+	p.Url = existing.GetUrl()
+	p.DeploymentId = existing.GetDeploymentId()
+	p.CreatedAt = existing.GetCreatedAt()
+	p.Status = existing.GetStatus()
+	s.backupPolicies[p.Id] = p
+	return clone(p).(*backup.BackupPolicy), nil
+}
+
+// DeleteBackupPolicy marks a backup policy as deleted.
+func (x *backupService) DeleteBackupPolicy(ctx context.Context, req *common.IDOptions) (*common.Empty, error) {
+	s := x.s
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	p, err := s.backupPolicy(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	p.IsDeleted = true
+	p.DeletedAt = types.TimestampNow()
+	return &common.Empty{}, nil
+}
+
+// ListBackups returns the backups of a deployment, optionally limited to a time range.
+func (x *backupService) ListBackups(ctx context.Context, req *backup.ListBackupsRequest) (*backup.BackupList, error) {
+	s := x.s
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if _, err := s.deployment(ctx, req.GetDeploymentId()); err != nil {
+		return nil, err
+	}
+	result := &backup.BackupList{}
+	for _, b := range s.backups {
+		if b.GetDeploymentId() != req.GetDeploymentId() {
+			continue
+		}
+		if from := req.GetFrom(); from != nil && b.GetCreatedAt().Compare(from) < 0 {
+			continue
+		}
+		if to := req.GetTo(); to != nil && b.GetCreatedAt().Compare(to) > 0 {
+			continue
+		}
+		result.Items = append(result.Items, clone(b).(*backup.Backup))
+	}
+	return result, nil
+}
+
+// GetBackup returns the backup with given ID.
+func (x *backupService) GetBackup(ctx context.Context, req *common.IDOptions) (*backup.Backup, error) {
+	s := x.s
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	b, err := s.backup(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return clone(b).(*backup.Backup), nil
+}
+
+// CreateBackup creates a backup that is ready (and uploaded if requested) immediately.
+func (x *backupService) CreateBackup(ctx context.Context, req *backup.Backup) (*backup.Backup, error) {
+	s := x.s
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	d, err := s.deployment(ctx, req.GetDeploymentId())
+	if err != nil {
+		return nil, err
+	}
+	if req.GetName() == "" {
+		return nil, common.InvalidArgument("Name missing")
+	}
+	b := clone(req).(*backup.Backup)
+	b.Id = s.newID()
+	b.Url = d.GetUrl() + "/Backup/" + b.Id
+	b.CreatedAt = types.TimestampNow()
+	b.DeploymentInfo = &backup.Backup_DeploymentInfo{
+		Version: d.GetVersion(),
+		Servers: clone(d.GetServers()).(*data.Deployment_ServersSpec),
+		Model:   clone(d.GetModel()).(*data.Deployment_ModelSpec),
+	}
+	b.Status = &backup.Backup_Status{
+		CreatedAt: b.CreatedAt,
+		Version:   d.GetVersion(),
+		State:     "Ready",
+		SizeBytes: backupSize,
+		Available: true,
+		Dbservers: d.GetServers().GetDbservers(),
+	}
+	if b.GetUpload() {
+		b.UploadUpdatedAt = b.CreatedAt
+		b.Status.UploadStatus = &backup.Backup_UploadStatus{
+			Uploaded:   true,
+			UploadedAt: b.CreatedAt,
+			SizeBytes:  backupSize,
+		}
+	}
+	s.backups[b.Id] = b
+	return clone(b).(*backup.Backup), nil
+}
+
+// UpdateBackup updates the name, description, upload and auto deletion of a backup.
+func (x *backupService) UpdateBackup(ctx context.Context, req *backup.Backup) (*backup.Backup, error) {
+	s := x.s
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	b, err := s.backup(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	b.Name = req.GetName()
+	b.Description = req.GetDescription()
+	b.AutoDeletedAt = req.GetAutoDeletedAt()
+	if req.GetUpload() && !b.GetUpload() {
+		b.Upload = true
+		b.UploadUpdatedAt = types.TimestampNow()
+		b.Status.UploadStatus = &backup.Backup_UploadStatus{
+			Uploaded:   true,
+			UploadedAt: b.UploadUpdatedAt,
+			SizeBytes:  backupSize,
+		}
+	}
+	return clone(b).(*backup.Backup), nil
+}
+
+// DownloadBackup downloads an uploaded backup to its deployment, which completes immediately.
+func (x *backupService) DownloadBackup(ctx context.Context, req *common.IDOptions) (*common.Empty, error) {
+	s := x.s
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	b, err := s.backup(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if !b.GetStatus().GetUploadStatus().GetUploaded() {
+		return nil, common.PreconditionFailed("Backup '%s' has not been uploaded", b.GetId())
+	}
+	now := types.TimestampNow()
+	revision := b.GetDownload().GetRevision() + 1
+	b.Download = &backup.Backup_DownloadSpec{Revision: revision, LastUpdatedAt: now}
+	b.Status.Available = true
+	b.Status.UploadOnly = false
+	b.Status.DownloadStatus = &backup.Backup_DownloadStatus{
+		Revision:     revision,
+		Downloaded:   true,
+		DownloadedAt: now,
+	}
+	return &common.Empty{}, nil
+}
+
+// RestoreBackup restores a backup into its deployment, which completes immediately.
+func (x *backupService) RestoreBackup(ctx context.Context, req *common.IDOptions) (*common.Empty, error) {
+	s := x.s
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	b, err := s.backup(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if !b.GetStatus().GetAvailable() {
+		return nil, common.PreconditionFailed("Backup '%s' is not available", b.GetId())
+	}
+	d := s.deployments[b.GetDeploymentId()]
+	revision := d.GetBackupRestore().GetRevision() + 1
+	d.BackupRestore = &data.Deployment_BackupRestoreSpec{
+		Revision:      revision,
+		LastUpdatedAt: types.TimestampNow(),
+		BackupId:      b.GetId(),
+	}
+	d.Status.BackupRestoreStatus = &data.Deployment_BackupRestoreStatus{
+		Revision: revision,
+		Status:   "Restored",
+	}
+	return &common.Empty{}, nil
+}
+
+// DeleteBackup removes a backup.
+func (x *backupService) DeleteBackup(ctx context.Context, req *common.IDOptions) (*common.Empty, error) {
+	s := x.s
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if _, err := s.backup(ctx, req.GetId()); err != nil {
+		return nil, err
+	}
+	delete(s.backups, req.GetId())
+	return &common.Empty{}, nil
+}
+
+// backup returns the backup with given ID, if the caller has access to it.
+// Requires the mutex to be locked.
+func (s *Server) backup(ctx context.Context, id string) (*backup.Backup, error) {
+	b, found := s.backups[id]
+	if !found {
+		return nil, notFound("Backup", id)
+	}
+	if _, err := s.deployment(ctx, b.GetDeploymentId()); err != nil {
+		return nil, notFound("Backup", id)
+	}
+	return b, nil
+}
+
+// backupPolicy returns the backup policy with given ID, if the caller has access to it.
+// Requires the mutex to be locked.
+func (s *Server) backupPolicy(ctx context.Context, id string) (*backup.BackupPolicy, error) {
+	p, found := s.backupPolicies[id]
+	if !found || p.GetIsDeleted() {
+		return nil, notFound("Backup policy", id)
+	}
+	if _, err := s.deployment(ctx, p.GetDeploymentId()); err != nil {
+		return nil, notFound("Backup policy", id)
+	}
+	return p, nil
+}
@@ -0,0 +1,184 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Ewout Prangsma
+//
+
+package fakeapi
+
+import (
+	"context"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+
+	common "github.com/arangodb-managed/apis/common/v1"
+	crypto "github.com/arangodb-managed/apis/crypto/v1"
+)
+
+const (
+	// defaultCertificateLifetime is the lifetime of CA certificates created without a lifetime.
+	defaultCertificateLifetime = 365 * 24 * time.Hour
+	// fakeCertificatePEM is the (fake) certificate of all CA certificates.
+	fakeCertificatePEM = "-----BEGIN CERTIFICATE-----\nZmFrZQ==\n-----END CERTIFICATE-----\n"
+)
+
+// cryptoService implements the CryptoService.
+type cryptoService struct {
+	crypto.UnimplementedCryptoServiceServer
+	s *Server
+}
+
+// GetAPIVersion returns the version of the API.
+func (x *cryptoService) GetAPIVersion(context.Context, *common.Empty) (*common.Version, error) {
+	return version(), nil
+}
+
+// ListCACertificates returns the CA certificates of a project.
+func (x *cryptoService) ListCACertificates(ctx context.Context, req *common.ListOptions) (*crypto.CACertificateList, error) {
+	s := x.s
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if _, err := s.project(ctx, req.GetContextId()); err != nil {
+		return nil, err
+	}
+	result := &crypto.CACertificateList{}
+	for _, c := range s.caCertificates {
+		if c.GetProjectId() == req.GetContextId() {
+			result.Items = append(result.Items, clone(c).(*crypto.CACertificate))
+		}
+	}
+	return result, nil
+}
+
+// GetCACertificate returns the CA certificate with given ID.
+func (x *cryptoService) GetCACertificate(ctx context.Context, req *common.IDOptions) (*crypto.CACertificate, error) {
+	s := x.s
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	c, err := s.caCertificate(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return clone(c).(*crypto.CACertificate), nil
+}
+
+// CreateCACertificate creates a CA certificate.
+// The first CA certificate of a project becomes its default.
+func (x *cryptoService) CreateCACertificate(ctx context.Context, req *crypto.CACertificate) (*crypto.CACertificate, error) {
+	s := x.s
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	p, err := s.project(ctx, req.GetProjectId())
+	if err != nil {
+		return nil, err
+	}
+	if req.GetName() == "" {
+		return nil, common.InvalidArgument("Name missing")
+	}
+	lifetime := defaultCertificateLifetime
+	if req.GetLifetime() != nil {
+		if lifetime, err = types.DurationFromProto(req.GetLifetime()); err != nil {
+			return nil, common.InvalidArgument("Invalid lifetime: %s", err)
+		}
+	}
+	now := time.Now()
+	c := clone(req).(*crypto.CACertificate)
+	c.Id = s.newID()
+	c.Url = p.GetUrl() + "/CACertificate/" + c.Id
+	c.Lifetime = types.DurationProto(lifetime)
+	c.CreatedAt, _ = types.TimestampProto(now)
+	c.ExpiresAt, _ = types.TimestampProto(now.Add(lifetime))
+	c.CertificatePem = fakeCertificatePEM
+	c.IsDefault = true
+	for _, x := range s.caCertificates {
+		if x.GetProjectId() == p.GetId() && x.GetIsDefault() {
+			c.IsDefault = false
+		}
+	}
+	s.caCertificates[c.Id] = c
+	return clone(c).(*crypto.CACertificate), nil
+}
+
+// UpdateCACertificate updates the name, description and use of a well known
+// certificate of a CA certificate.
+func (x *cryptoService) UpdateCACertificate(ctx context.Context, req *crypto.CACertificate) (*crypto.CACertificate, error) {
+	s := x.s
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	c, err := s.caCertificate(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	c.Name = req.GetName()
+	c.Description = req.GetDescription()
+	c.UseWellKnownCertificate = req.GetUseWellKnownCertificate()
+	return clone(c).(*crypto.CACertificate), nil
+}
+
+// DeleteCACertificate removes a CA certificate.
+func (x *cryptoService) DeleteCACertificate(ctx context.Context, req *common.IDOptions) (*common.Empty, error) {
+	s := x.s
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	c, err := s.caCertificate(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if c.GetIsDefault() {
+		return nil, common.PreconditionFailed("CA certificate '%s' is the default", req.GetId())
+	}
+	for _, d := range s.deployments {
+		if d.GetCertificates().GetCaCertificateId() == req.GetId() {
+			return nil, common.PreconditionFailed("CA certificate '%s' is in use", req.GetId())
+		}
+	}
+	delete(s.caCertificates, req.GetId())
+	return &common.Empty{}, nil
+}
+
+// SetDefaultCACertificate makes a CA certificate the default of its project.
+func (x *cryptoService) SetDefaultCACertificate(ctx context.Context, req *crypto.CACertificate) (*common.Empty, error) {
+	s := x.s
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	c, err := s.caCertificate(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	for _, x := range s.caCertificates {
+		if x.GetProjectId() == c.GetProjectId() {
+			x.IsDefault = x.GetId() == c.GetId()
+		}
+	}
+	return &common.Empty{}, nil
+}
+
+// caCertificate returns the CA certificate with given ID, if the caller has access to it.
+// Requires the mutex to be locked.
+func (s *Server) caCertificate(ctx context.Context, id string) (*crypto.CACertificate, error) {
+	c, found := s.caCertificates[id]
+	if !found {
+		return nil, notFound("CA certificate", id)
+	}
+	if _, err := s.project(ctx, c.GetProjectId()); err != nil {
+		return nil, notFound("CA certificate", id)
+	}
+	return c, nil
+}
@@ -0,0 +1,276 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Ewout Prangsma
+//
+
+package fakeapi
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gogo/protobuf/types"
+
+	common "github.com/arangodb-managed/apis/common/v1"
+	data "github.com/arangodb-managed/apis/data/v1"
+)
+
+var (
+	// versions contains the ArangoDB versions that can be used in deployments.
+	versions = []string{"3.5.5", "3.6.4", "3.7.0"}
+	// defaultVersion is the version used for deployments without a version.
+	defaultVersion = "3.6.4"
+	// nodeSizes contains the node sizes that can be used in deployments.
+	nodeSizes = []*data.NodeSize{
+		{Id: "a4", Name: "A4", MemorySize: 4, MinDiskSize: 10, MaxDiskSize: 100, CpuSize: "small", DiskSizes: []int32{10, 20, 50, 100}},
+		{Id: "a8", Name: "A8", MemorySize: 8, MinDiskSize: 20, MaxDiskSize: 200, CpuSize: "small", DiskSizes: []int32{20, 50, 100, 200}},
+		{Id: "a16", Name: "A16", MemorySize: 16, MinDiskSize: 40, MaxDiskSize: 400, CpuSize: "small", DiskSizes: []int32{40, 100, 200, 400}},
+	}
+	// cpuSizes contains the CPU sizes referenced by node sizes.
+	cpuSizes = []*data.CPUSize{
+		{Id: "small", Name: "Small"},
+	}
+)
+
+// dataService implements the DataService.
+type dataService struct {
+	data.UnimplementedDataServiceServer
+	s *Server
+}
+
+// GetAPIVersion returns the version of the API.
+func (x *dataService) GetAPIVersion(context.Context, *common.Empty) (*common.Version, error) {
+	return version(), nil
+}
+
+// ListDeployments returns the deployments of a project.
+func (x *dataService) ListDeployments(ctx context.Context, req *common.ListOptions) (*data.DeploymentList, error) {
+	s := x.s
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if _, err := s.project(ctx, req.GetContextId()); err != nil {
+		return nil, err
+	}
+	result := &data.DeploymentList{}
+	for _, d := range s.deployments {
+		if d.GetProjectId() == req.GetContextId() {
+			result.Items = append(result.Items, clone(d).(*data.Deployment))
+		}
+	}
+	return result, nil
+}
+
+// GetDeployment returns the deployment with given ID.
+func (x *dataService) GetDeployment(ctx context.Context, req *common.IDOptions) (*data.Deployment, error) {
+	s := x.s
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	d, err := s.deployment(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return clone(d).(*data.Deployment), nil
+}
+
+// CreateDeployment creates a deployment that is ready immediately.
+func (x *dataService) CreateDeployment(ctx context.Context, req *data.Deployment) (*data.Deployment, error) {
+	s := x.s
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	p, err := s.project(ctx, req.GetProjectId())
+	if err != nil {
+		return nil, err
+	}
+	if req.GetName() == "" {
+		return nil, common.InvalidArgument("Name missing")
+	}
+	if req.GetRegionId() == "" {
+		return nil, common.InvalidArgument("Region missing")
+	}
+	d := clone(req).(*data.Deployment)
+	d.Id = s.newID()
+	d.Url = p.GetUrl() + "/Deployment/" + d.Id
+	d.CreatedAt = types.TimestampNow()
+	d.CreatedById = s.userID(ctx)
+	if d.GetVersion() == "" {
+		d.Version = defaultVersion
+	}
+	if d.GetCertificates().GetCaCertificateId() == "" {
+		if d.Certificates == nil {
+			d.Certificates = &data.Deployment_CertificateSpec{}
+		}
+		for _, c := range s.caCertificates {
+			if c.GetProjectId() == p.GetId() && c.GetIsDefault() {
+				d.Certificates.CaCertificateId = c.GetId()
+			}
+		}
+	}
+	s.updateDeploymentStatus(d)
+	s.deployments[d.Id] = d
+	return clone(d).(*data.Deployment), nil
+}
+
+// UpdateDeployment updates a deployment.
+func (x *dataService) UpdateDeployment(ctx context.Context, req *data.Deployment) (*data.Deployment, error) {
+	s := x.s
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	existing, err := s.deployment(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	d := clone(req).(*data.Deployment)
+	// Restore read-only fields
+	d.Url = existing.GetUrl()
+	d.ProjectId = existing.GetProjectId()
+	d.RegionId = existing.GetRegionId()
+	d.CreatedAt = existing.GetCreatedAt()
+	d.CreatedById = existing.GetCreatedById()
+	d.IsPaused = existing.GetIsPaused()
+	d.LastPausedAt = existing.GetLastPausedAt()
+	d.LastResumedAt = existing.GetLastResumedAt()
+	d.Status = existing.GetStatus()
+	s.updateDeploymentStatus(d)
+	s.deployments[d.Id] = d
+	return clone(d).(*data.Deployment), nil
+}
+
+// DeleteDeployment removes a deployment.
+func (x *dataService) DeleteDeployment(ctx context.Context, req *common.IDOptions) (*common.Empty, error) {
+	s := x.s
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if _, err := s.deployment(ctx, req.GetId()); err != nil {
+		return nil, err
+	}
+	delete(s.deployments, req.GetId())
+	return &common.Empty{}, nil
+}
+
+// GetDeploymentCredentials returns the root credentials of a deployment.
+func (x *dataService) GetDeploymentCredentials(ctx context.Context, req *data.DeploymentCredentialsRequest) (*data.DeploymentCredentials, error) {
+	s := x.s
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if _, err := s.deployment(ctx, req.GetDeploymentId()); err != nil {
+		return nil, err
+	}
+	return &data.DeploymentCredentials{Username: "root", Password: "fake-password"}, nil
+}
+
+// ResumeDeployment resumes a paused deployment.
+func (x *dataService) ResumeDeployment(ctx context.Context, req *common.IDOptions) (*common.Empty, error) {
+	s := x.s
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	d, err := s.deployment(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if !d.GetIsPaused() {
+		return nil, common.PreconditionFailed("Deployment '%s' is not paused", d.GetId())
+	}
+	d.IsPaused = false
+	d.LastResumedAt = types.TimestampNow()
+	return &common.Empty{}, nil
+}
+
+// ListVersions returns the supported ArangoDB versions.
+func (x *dataService) ListVersions(ctx context.Context, req *data.ListVersionsRequest) (*data.VersionList, error) {
+	result := &data.VersionList{}
+	for _, v := range versions {
+		result.Items = append(result.Items, &data.Version{Version: v})
+	}
+	return result, nil
+}
+
+// GetDefaultVersion returns the default ArangoDB version.
+func (x *dataService) GetDefaultVersion(context.Context, *common.Empty) (*data.Version, error) {
+	return &data.Version{Version: defaultVersion}, nil
+}
+
+// ListNodeSizes returns the supported node sizes.
+func (x *dataService) ListNodeSizes(ctx context.Context, req *data.NodeSizesRequest) (*data.NodeSizeList, error) {
+	result := &data.NodeSizeList{}
+	for _, ns := range nodeSizes {
+		result.Items = append(result.Items, clone(ns).(*data.NodeSize))
+	}
+	return result, nil
+}
+
+// ListCPUSizes returns the supported CPU sizes.
+func (x *dataService) ListCPUSizes(ctx context.Context, req *data.ListCPUSizesRequest) (*data.CPUSizeList, error) {
+	result := &data.CPUSizeList{}
+	for _, cs := range cpuSizes {
+		result.Items = append(result.Items, clone(cs).(*data.CPUSize))
+	}
+	return result, nil
+}
+
+// deployment returns the deployment with given ID, if the caller has access to it.
+// Requires the mutex to be locked.
+func (s *Server) deployment(ctx context.Context, id string) (*data.Deployment, error) {
+	d, found := s.deployments[id]
+	if !found {
+		return nil, notFound("Deployment", id)
+	}
+	if _, err := s.project(ctx, d.GetProjectId()); err != nil {
+		return nil, notFound("Deployment", id)
+	}
+	return d, nil
+}
+
+// updateDeploymentStatus sets the status of the given deployment to a
+// ready state that matches its specification.
+// Requires the mutex to be locked.
+func (s *Server) updateDeploymentStatus(d *data.Deployment) {
+	coordinators, dbservers := int32(3), int32(3)
+	if x := d.GetServers(); x != nil {
+		coordinators, dbservers = x.GetCoordinators(), x.GetDbservers()
+	} else if x := d.GetModel(); x.GetNodeCount() > 0 {
+		coordinators, dbservers = x.GetNodeCount(), x.GetNodeCount()
+	}
+	if d.Status == nil {
+		d.Status = &data.Deployment_Status{
+			Endpoint:       fmt.Sprintf("https://%s.fake.arangodb.cloud:8529", d.GetId()),
+			Created:        true,
+			Ready:          true,
+			Bootstrapped:   true,
+			BootstrappedAt: types.TimestampNow(),
+		}
+	}
+	d.Status.ServerVersions = []string{d.GetVersion()}
+	d.Status.Servers = nil
+	addServers := func(serverType, prefix string, count int32) {
+		for i := int32(0); i < count; i++ {
+			d.Status.Servers = append(d.Status.Servers, &data.Deployment_ServerStatus{
+				Id:              fmt.Sprintf("%s-%d", prefix, i),
+				Type:            serverType,
+				CreatedAt:       d.GetCreatedAt(),
+				Ready:           true,
+				MemberOfCluster: true,
+				Ok:              true,
+				Version:         d.GetVersion(),
+			})
+		}
+	}
+	addServers("Coordinator", "crdn", coordinators)
+	addServers("DBServer", "prmr", dbservers)
+}
@@ -0,0 +1,559 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Ewout Prangsma
+//
+
+package fakeapi
+
+import (
+	"context"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+
+	common "github.com/arangodb-managed/apis/common/v1"
+	iam "github.com/arangodb-managed/apis/iam/v1"
+)
+
+const (
+	// defaultTokenTimeToLive is the lifetime of tokens created without a time to live.
+	defaultTokenTimeToLive = time.Hour
+)
+
+var (
+	// permissions contains all known permissions.
+	permissions = []string{
+		"backup.backup.create", "backup.backup.delete", "backup.backup.get", "backup.backup.list", "backup.backup.update",
+		"crypto.cacertificate.create", "crypto.cacertificate.delete", "crypto.cacertificate.get", "crypto.cacertificate.list", "crypto.cacertificate.update",
+		"data.deployment.create", "data.deployment.delete", "data.deployment.get", "data.deployment.list", "data.deployment.update",
+		"iam.group.create", "iam.group.delete", "iam.group.get", "iam.group.list", "iam.group.update",
+		"iam.policy.get", "iam.policy.update",
+		"iam.role.create", "iam.role.delete", "iam.role.get", "iam.role.list", "iam.role.update",
+		"resourcemanager.organization.delete", "resourcemanager.organization.get", "resourcemanager.organization.update",
+		"resourcemanager.project.create", "resourcemanager.project.delete", "resourcemanager.project.get", "resourcemanager.project.list", "resourcemanager.project.update",
+		"security.ipwhitelist.create", "security.ipwhitelist.delete", "security.ipwhitelist.get", "security.ipwhitelist.list", "security.ipwhitelist.update",
+	}
+	// predefinedRoles contains the roles that are available in every organization.
+	predefinedRoles = []*iam.Role{
+		{Name: "Organization Administrator", Description: "Full access to the organization", Permissions: permissions},
+		{Name: "Organization Viewer", Description: "Read-only access to the organization", Permissions: []string{"resourcemanager.organization.get", "resourcemanager.project.list"}},
+	}
+)
+
+// iamService implements the IAMService.
+type iamService struct {
+	iam.UnimplementedIAMServiceServer
+	s *Server
+}
+
+// GetAPIVersion returns the version of the API.
+func (x *iamService) GetAPIVersion(context.Context, *common.Empty) (*common.Version, error) {
+	return version(), nil
+}
+
+// GetThisUser returns the authenticated user.
+func (x *iamService) GetThisUser(ctx context.Context, req *common.Empty) (*iam.User, error) {
+	s := x.s
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return clone(s.users[s.userID(ctx)]).(*iam.User), nil
+}
+
+// GetUser returns the user with given ID.
+func (x *iamService) GetUser(ctx context.Context, req *common.IDOptions) (*iam.User, error) {
+	s := x.s
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	u, found := s.users[req.GetId()]
+	if !found {
+		return nil, notFound("User", req.GetId())
+	}
+	return clone(u).(*iam.User), nil
+}
+
+// ListGroups returns the groups of an organization.
+func (x *iamService) ListGroups(ctx context.Context, req *common.ListOptions) (*iam.GroupList, error) {
+	s := x.s
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if _, err := s.organization(ctx, req.GetContextId()); err != nil {
+		return nil, err
+	}
+	result := &iam.GroupList{}
+	for _, g := range s.groups {
+		if g.GetOrganizationId() == req.GetContextId() {
+			result.Items = append(result.Items, clone(g).(*iam.Group))
+		}
+	}
+	return result, nil
+}
+
+// GetGroup returns the group with given ID.
+func (x *iamService) GetGroup(ctx context.Context, req *common.IDOptions) (*iam.Group, error) {
+	s := x.s
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	g, err := s.group(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return clone(g).(*iam.Group), nil
+}
+
+// CreateGroup creates a group.
+func (x *iamService) CreateGroup(ctx context.Context, req *iam.Group) (*iam.Group, error) {
+	s := x.s
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	org, err := s.organization(ctx, req.GetOrganizationId())
+	if err != nil {
+		return nil, err
+	}
+	if req.GetName() == "" {
+		return nil, common.InvalidArgument("Name missing")
+	}
+	g := clone(req).(*iam.Group)
+	g.Id = s.newID()
+	g.Url = org.GetUrl() + "/Group/" + g.Id
+	g.CreatedAt = types.TimestampNow()
+	s.groups[g.Id] = g
+	return clone(g).(*iam.Group), nil
+}
+
+// UpdateGroup updates the name and description of a group.
+func (x *iamService) UpdateGroup(ctx context.Context, req *iam.Group) (*iam.Group, error) {
+	s := x.s
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	g, err := s.group(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	g.Name = req.GetName()
+	g.Description = req.GetDescription()
+	return clone(g).(*iam.Group), nil
+}
+
+// DeleteGroup marks a group as deleted.
+// Like the real API, deleted groups are still listed for a while.
+func (x *iamService) DeleteGroup(ctx context.Context, req *common.IDOptions) (*common.Empty, error) {
+	s := x.s
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	g, err := s.group(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	g.IsDeleted = true
+	g.DeletedAt = types.TimestampNow()
+	delete(s.groupMembers, req.GetId())
+	return &common.Empty{}, nil
+}
+
+// ListGroupMembers returns the IDs of the members of a group.
+func (x *iamService) ListGroupMembers(ctx context.Context, req *common.ListOptions) (*iam.GroupMemberList, error) {
+	s := x.s
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if _, err := s.group(ctx, req.GetContextId()); err != nil {
+		return nil, err
+	}
+	return &iam.GroupMemberList{Items: append([]string(nil), s.groupMembers[req.GetContextId()]...)}, nil
+}
+
+// AddGroupMembers adds users to a group.
+func (x *iamService) AddGroupMembers(ctx context.Context, req *iam.GroupMembersRequest) (*common.Empty, error) {
+	s := x.s
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if _, err := s.group(ctx, req.GetGroupId()); err != nil {
+		return nil, err
+	}
+	for _, id := range req.GetUserIds() {
+		if _, found := s.users[id]; !found {
+			return nil, notFound("User", id)
+		}
+		if !s.isGroupMember(req.GetGroupId(), id) {
+			s.groupMembers[req.GetGroupId()] = append(s.groupMembers[req.GetGroupId()], id)
+		}
+	}
+	return &common.Empty{}, nil
+}
+
+// DeleteGroupMembers removes users from a group.
+func (x *iamService) DeleteGroupMembers(ctx context.Context, req *iam.GroupMembersRequest) (*common.Empty, error) {
+	s := x.s
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if _, err := s.group(ctx, req.GetGroupId()); err != nil {
+		return nil, err
+	}
+	for _, id := range req.GetUserIds() {
+		members := s.groupMembers[req.GetGroupId()][:0]
+		for _, x := range s.groupMembers[req.GetGroupId()] {
+			if x != id {
+				members = append(members, x)
+			}
+		}
+		s.groupMembers[req.GetGroupId()] = members
+	}
+	return &common.Empty{}, nil
+}
+
+// IsMemberOfGroup checks if a user is a member of a group.
+func (x *iamService) IsMemberOfGroup(ctx context.Context, req *iam.IsMemberOfGroupRequest) (*common.YesOrNo, error) {
+	s := x.s
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if _, err := s.group(ctx, req.GetGroupId()); err != nil {
+		return nil, err
+	}
+	return &common.YesOrNo{Result: s.isGroupMember(req.GetGroupId(), req.GetUserId())}, nil
+}
+
+// ListRoles returns the predefined roles and the roles of an organization.
+func (x *iamService) ListRoles(ctx context.Context, req *common.ListOptions) (*iam.RoleList, error) {
+	s := x.s
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if _, err := s.organization(ctx, req.GetContextId()); err != nil {
+		return nil, err
+	}
+	result := &iam.RoleList{}
+	for _, r := range s.roles {
+		if r.GetIsPredefined() || r.GetOrganizationId() == req.GetContextId() {
+			result.Items = append(result.Items, clone(r).(*iam.Role))
+		}
+	}
+	return result, nil
+}
+
+// GetRole returns the role with given ID.
+func (x *iamService) GetRole(ctx context.Context, req *common.IDOptions) (*iam.Role, error) {
+	s := x.s
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	r, err := s.role(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return clone(r).(*iam.Role), nil
+}
+
+// CreateRole creates a custom role.
+func (x *iamService) CreateRole(ctx context.Context, req *iam.Role) (*iam.Role, error) {
+	s := x.s
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	org, err := s.organization(ctx, req.GetOrganizationId())
+	if err != nil {
+		return nil, err
+	}
+	if req.GetName() == "" {
+		return nil, common.InvalidArgument("Name missing")
+	}
+	r := clone(req).(*iam.Role)
+	r.Id = s.newID()
+	r.Url = org.GetUrl() + "/Role/" + r.Id
+	r.IsPredefined = false
+	r.CreatedAt = types.TimestampNow()
+	s.roles[r.Id] = r
+	return clone(r).(*iam.Role), nil
+}
+
+// UpdateRole updates a custom role.
+func (x *iamService) UpdateRole(ctx context.Context, req *iam.Role) (*iam.Role, error) {
+	s := x.s
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	r, err := s.role(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if r.GetIsPredefined() {
+		return nil, common.PermissionDenied("Predefined role '%s' cannot be changed", r.GetId())
+	}
+	r.Name = req.GetName()
+	r.Description = req.GetDescription()
+	r.Permissions = append([]string(nil), req.GetPermissions()...)
+	return clone(r).(*iam.Role), nil
+}
+
+// DeleteRole removes a custom role.
+func (x *iamService) DeleteRole(ctx context.Context, req *common.IDOptions) (*common.Empty, error) {
+	s := x.s
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	r, err := s.role(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if r.GetIsPredefined() {
+		return nil, common.PermissionDenied("Predefined role '%s' cannot be deleted", r.GetId())
+	}
+	delete(s.roles, req.GetId())
+	return &common.Empty{}, nil
+}
+
+// GetPolicy returns the policy of the resource with given URL.
+func (x *iamService) GetPolicy(ctx context.Context, req *common.URLOptions) (*iam.Policy, error) {
+	s := x.s
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return clone(s.policy(req.GetUrl())).(*iam.Policy), nil
+}
+
+// AddRoleBindings adds role bindings to the policy of a resource.
+func (x *iamService) AddRoleBindings(ctx context.Context, req *iam.RoleBindingsRequest) (*iam.Policy, error) {
+	s := x.s
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	p := s.policy(req.GetResourceUrl())
+	for _, b := range req.GetBindings() {
+		if _, found := s.roles[b.GetRoleId()]; !found {
+			return nil, notFound("Role", b.GetRoleId())
+		}
+		b = clone(b).(*iam.RoleBinding)
+		b.Id = s.newID()
+		p.Bindings = append(p.Bindings, b)
+	}
+	s.policies[p.GetResourceUrl()] = p
+	return clone(p).(*iam.Policy), nil
+}
+
+// DeleteRoleBindings removes role bindings from the policy of a resource.
+func (x *iamService) DeleteRoleBindings(ctx context.Context, req *iam.RoleBindingsRequest) (*iam.Policy, error) {
+	s := x.s
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	p := s.policy(req.GetResourceUrl())
+	for _, b := range req.GetBindings() {
+		bindings := p.Bindings[:0]
+		for _, x := range p.GetBindings() {
+			if x.GetMemberId() != b.GetMemberId() || x.GetRoleId() != b.GetRoleId() {
+				bindings = append(bindings, x)
+			}
+		}
+		p.Bindings = bindings
+	}
+	s.policies[p.GetResourceUrl()] = p
+	return clone(p).(*iam.Policy), nil
+}
+
+// GetEffectivePermissions returns all permissions; the caller has full access.
+func (x *iamService) GetEffectivePermissions(ctx context.Context, req *common.URLOptions) (*iam.PermissionList, error) {
+	return &iam.PermissionList{Items: append([]string(nil), permissions...)}, nil
+}
+
+// HasPermissions returns yes; the caller has full access.
+func (x *iamService) HasPermissions(ctx context.Context, req *iam.HasPermissionsRequest) (*common.YesOrNo, error) {
+	return &common.YesOrNo{Result: true}, nil
+}
+
+// ListPermissions returns all known permissions.
+func (x *iamService) ListPermissions(ctx context.Context, req *common.Empty) (*iam.PermissionList, error) {
+	return &iam.PermissionList{Items: append([]string(nil), permissions...)}, nil
+}
+
+// ListAPIKeys returns the API keys of the caller.
+func (x *iamService) ListAPIKeys(ctx context.Context, req *common.ListOptions) (*iam.APIKeyList, error) {
+	s := x.s
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	userID := s.userID(ctx)
+	result := &iam.APIKeyList{}
+	for _, k := range s.apiKeys {
+		if k.key.GetUserId() == userID {
+			result.Items = append(result.Items, clone(k.key).(*iam.APIKey))
+		}
+	}
+	return result, nil
+}
+
+// GetAPIKey returns the API key with given ID.
+func (x *iamService) GetAPIKey(ctx context.Context, req *common.IDOptions) (*iam.APIKey, error) {
+	s := x.s
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	k, err := s.apiKey(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return clone(k.key).(*iam.APIKey), nil
+}
+
+// CreateAPIKey creates an API key for the caller.
+func (x *iamService) CreateAPIKey(ctx context.Context, req *iam.CreateAPIKeyRequest) (*iam.APIKeySecret, error) {
+	s := x.s
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if orgID := req.GetOrganizationId(); orgID != "" {
+		if _, err := s.organization(ctx, orgID); err != nil {
+			return nil, err
+		}
+	}
+	key := &iam.APIKey{
+		Id:             s.newID(),
+		UserId:         s.userID(ctx),
+		OrganizationId: req.GetOrganizationId(),
+		IsReadonly:     req.GetReadonly(),
+		CreatedAt:      types.TimestampNow(),
+	}
+	key.Url = "/APIKey/" + key.Id
+	if ttl := req.GetTimeToLive(); ttl != nil {
+		d, err := types.DurationFromProto(ttl)
+		if err != nil {
+			return nil, common.InvalidArgument("Invalid time to live: %s", err)
+		}
+		key.ExpiresAt, _ = types.TimestampProto(time.Now().Add(d))
+	}
+	secret := "secret-" + s.newID()
+	s.apiKeys[key.Id] = &apiKey{key: key, secret: secret}
+	return &iam.APIKeySecret{Id: key.Id, Secret: secret}, nil
+}
+
+// RevokeAPIKey revokes an API key.
+func (x *iamService) RevokeAPIKey(ctx context.Context, req *common.IDOptions) (*common.Empty, error) {
+	s := x.s
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	k, err := s.apiKey(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	k.key.IsRevoked = true
+	k.key.RevokedAt = types.TimestampNow()
+	return &common.Empty{}, nil
+}
+
+// DeleteAPIKey removes an API key.
+func (x *iamService) DeleteAPIKey(ctx context.Context, req *common.IDOptions) (*common.Empty, error) {
+	s := x.s
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if _, err := s.apiKey(ctx, req.GetId()); err != nil {
+		return nil, err
+	}
+	delete(s.apiKeys, req.GetId())
+	return &common.Empty{}, nil
+}
+
+// AuthenticateAPIKey returns a new token for an API key.
+func (x *iamService) AuthenticateAPIKey(ctx context.Context, req *iam.AuthenticateAPIKeyRequest) (*iam.AuthenticateAPIKeyResponse, error) {
+	s := x.s
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	k, found := s.apiKeys[req.GetId()]
+	if !found || k.secret != req.GetSecret() || k.key.GetIsRevoked() {
+		return nil, common.Unauthenticated("Invalid API key")
+	}
+	ttl := types.DurationProto(defaultTokenTimeToLive)
+	if req.GetTimeToLive() != nil {
+		ttl = req.GetTimeToLive()
+	}
+	token := "token-" + s.newID()
+	s.tokens[token] = k.key.GetUserId()
+	return &iam.AuthenticateAPIKeyResponse{Token: token, TimeToLive: ttl}, nil
+}
+
+// RenewAPIKeyToken renews a token; tokens of the fake server never expire.
+func (x *iamService) RenewAPIKeyToken(ctx context.Context, req *iam.RenewAPIKeyTokenRequest) (*iam.RenewAPIKeyTokenResponse, error) {
+	s := x.s
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if _, found := s.tokens[req.GetToken()]; !found {
+		return nil, notFound("Token", req.GetToken())
+	}
+	ttl := types.DurationProto(defaultTokenTimeToLive)
+	if req.GetTimeToLive() != nil {
+		ttl = req.GetTimeToLive()
+	}
+	return &iam.RenewAPIKeyTokenResponse{TimeToLive: ttl}, nil
+}
+
+// RevokeAPIKeyToken revokes a token.
+func (x *iamService) RevokeAPIKeyToken(ctx context.Context, req *iam.RevokeAPIKeyTokenRequest) (*common.Empty, error) {
+	s := x.s
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if _, found := s.tokens[req.GetToken()]; !found {
+		return nil, notFound("Token", req.GetToken())
+	}
+	delete(s.tokens, req.GetToken())
+	return &common.Empty{}, nil
+}
+
+// isGroupMember returns true if the user with given ID is a member of the group with given ID.
+// Requires the mutex to be locked.
+func (s *Server) isGroupMember(groupID, userID string) bool {
+	for _, id := range s.groupMembers[groupID] {
+		if id == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// group returns the group with given ID, if the caller has access to it.
+// Requires the mutex to be locked.
+func (s *Server) group(ctx context.Context, id string) (*iam.Group, error) {
+	g, found := s.groups[id]
+	if !found {
+		return nil, notFound("Group", id)
+	}
+	if _, err := s.organization(ctx, g.GetOrganizationId()); err != nil {
+		return nil, notFound("Group", id)
+	}
+	return g, nil
+}
+
+// role returns the role with given ID, if the caller has access to it.
+// Requires the mutex to be locked.
+func (s *Server) role(ctx context.Context, id string) (*iam.Role, error) {
+	r, found := s.roles[id]
+	if !found {
+		return nil, notFound("Role", id)
+	}
+	if !r.GetIsPredefined() {
+		if _, err := s.organization(ctx, r.GetOrganizationId()); err != nil {
+			return nil, notFound("Role", id)
+		}
+	}
+	return r, nil
+}
+
+// policy returns the policy of the resource with given URL.
+// Requires the mutex to be locked.
+func (s *Server) policy(url string) *iam.Policy {
+	if p, found := s.policies[url]; found {
+		return p
+	}
+	return &iam.Policy{ResourceUrl: url}
+}
+
+// apiKey returns the API key with given ID, if it belongs to the caller.
+// Requires the mutex to be locked.
+func (s *Server) apiKey(ctx context.Context, id string) (*apiKey, error) {
+	k, found := s.apiKeys[id]
+	if !found || k.key.GetUserId() != s.userID(ctx) {
+		return nil, notFound("API key", id)
+	}
+	return k, nil
+}
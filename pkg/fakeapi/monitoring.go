@@ -0,0 +1,86 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Ewout Prangsma
+//
+
+package fakeapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+
+	common "github.com/arangodb-managed/apis/common/v1"
+	monitoring "github.com/arangodb-managed/apis/monitoring/v1"
+)
+
+// monitoringService implements the MonitoringService.
+type monitoringService struct {
+	monitoring.UnimplementedMonitoringServiceServer
+	s *Server
+}
+
+// GetAPIVersion returns the version of the API.
+func (x *monitoringService) GetAPIVersion(context.Context, *common.Empty) (*common.Version, error) {
+	return version(), nil
+}
+
+// GetDeploymentLogs sends the log lines of a deployment (added with
+// Server.AddDeploymentLogs) within the requested time range, as a single chunk.
+// Lines start with their timestamp in text format, or have a "time" field in json format.
+func (x *monitoringService) GetDeploymentLogs(req *monitoring.GetDeploymentLogsRequest, stream monitoring.MonitoringService_GetDeploymentLogsServer) error {
+	s := x.s
+	s.mutex.Lock()
+	if _, err := s.deployment(stream.Context(), req.GetDeploymentId()); err != nil {
+		s.mutex.Unlock()
+		return err
+	}
+	var buf bytes.Buffer
+	count := int32(0)
+	for _, l := range s.logs[req.GetDeploymentId()] {
+		ts, _ := types.TimestampProto(l.Timestamp)
+		if startAt := req.GetStartAt(); startAt != nil && ts.Compare(startAt) < 0 {
+			continue
+		}
+		if endAt := req.GetEndAt(); endAt != nil && ts.Compare(endAt) > 0 {
+			continue
+		}
+		if limit := req.GetLimit(); limit > 0 && count >= limit {
+			break
+		}
+		stamp := l.Timestamp.UTC().Format(time.RFC3339Nano)
+		if req.GetFormat() == "json" {
+			encoded, _ := json.Marshal(map[string]string{"time": stamp, "message": l.Message})
+			buf.Write(encoded)
+		} else {
+			buf.WriteString(stamp + " " + l.Message)
+		}
+		buf.WriteString("\n")
+		count++
+	}
+	s.mutex.Unlock()
+	if buf.Len() == 0 {
+		return nil
+	}
+	return stream.Send(&monitoring.DeploymentLogsChunk{Chunk: buf.Bytes()})
+}
@@ -0,0 +1,424 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Ewout Prangsma
+//
+
+package fakeapi
+
+import (
+	"context"
+
+	"github.com/gogo/protobuf/types"
+
+	common "github.com/arangodb-managed/apis/common/v1"
+	rm "github.com/arangodb-managed/apis/resourcemanager/v1"
+)
+
+// resourceManagerService implements the ResourceManagerService.
+type resourceManagerService struct {
+	rm.UnimplementedResourceManagerServiceServer
+	s *Server
+}
+
+// GetAPIVersion returns the version of the API.
+func (x *resourceManagerService) GetAPIVersion(context.Context, *common.Empty) (*common.Version, error) {
+	return version(), nil
+}
+
+// ListOrganizations returns the organizations the caller is a member of.
+func (x *resourceManagerService) ListOrganizations(ctx context.Context, req *common.ListOptions) (*rm.OrganizationList, error) {
+	s := x.s
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	userID := s.userID(ctx)
+	result := &rm.OrganizationList{}
+	for _, org := range s.organizations {
+		if s.isMember(org.GetId(), userID) {
+			result.Items = append(result.Items, clone(org).(*rm.Organization))
+		}
+	}
+	return result, nil
+}
+
+// GetOrganization returns the organization with given ID.
+func (x *resourceManagerService) GetOrganization(ctx context.Context, req *common.IDOptions) (*rm.Organization, error) {
+	s := x.s
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	org, err := s.organization(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return clone(org).(*rm.Organization), nil
+}
+
+// CreateOrganization creates an organization with the caller as owner.
+func (x *resourceManagerService) CreateOrganization(ctx context.Context, req *rm.Organization) (*rm.Organization, error) {
+	s := x.s
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if req.GetName() == "" {
+		return nil, common.InvalidArgument("Name missing")
+	}
+	org := clone(req).(*rm.Organization)
+	org.Id = s.newID()
+	org.Url = "/Organization/" + org.Id
+	org.CreatedAt = types.TimestampNow()
+	org.Tier = &rm.Tier{Id: "free", Name: "Free to try"}
+	s.organizations[org.Id] = org
+	s.members[org.Id] = []*rm.Member{{UserId: s.userID(ctx), Owner: true}}
+	return clone(org).(*rm.Organization), nil
+}
+
+// UpdateOrganization updates the name and description of an organization.
+func (x *resourceManagerService) UpdateOrganization(ctx context.Context, req *rm.Organization) (*rm.Organization, error) {
+	s := x.s
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	org, err := s.organization(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	org.Name = req.GetName()
+	org.Description = req.GetDescription()
+	return clone(org).(*rm.Organization), nil
+}
+
+// DeleteOrganization marks an organization as deleted.
+func (x *resourceManagerService) DeleteOrganization(ctx context.Context, req *common.IDOptions) (*common.Empty, error) {
+	s := x.s
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	org, err := s.organization(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	org.IsDeleted = true
+	org.DeletedAt = types.TimestampNow()
+	return &common.Empty{}, nil
+}
+
+// ListOrganizationMembers returns the members of an organization.
+func (x *resourceManagerService) ListOrganizationMembers(ctx context.Context, req *common.ListOptions) (*rm.MemberList, error) {
+	s := x.s
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if _, err := s.organization(ctx, req.GetContextId()); err != nil {
+		return nil, err
+	}
+	result := &rm.MemberList{}
+	for _, m := range s.members[req.GetContextId()] {
+		result.Items = append(result.Items, clone(m).(*rm.Member))
+	}
+	return result, nil
+}
+
+// AddOrganizationMembers adds members to an organization.
+func (x *resourceManagerService) AddOrganizationMembers(ctx context.Context, req *rm.OrganizationMembersRequest) (*common.Empty, error) {
+	s := x.s
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	orgID := req.GetOrganizationId()
+	if _, err := s.organization(ctx, orgID); err != nil {
+		return nil, err
+	}
+	for _, m := range req.GetMembers().GetItems() {
+		if _, found := s.users[m.GetUserId()]; !found {
+			return nil, notFound("User", m.GetUserId())
+		}
+		if !s.isMember(orgID, m.GetUserId()) {
+			s.members[orgID] = append(s.members[orgID], clone(m).(*rm.Member))
+		}
+	}
+	return &common.Empty{}, nil
+}
+
+// UpdateOrganizationMembers updates the ownership of members of an organization.
+func (x *resourceManagerService) UpdateOrganizationMembers(ctx context.Context, req *rm.OrganizationMembersRequest) (*common.Empty, error) {
+	s := x.s
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	orgID := req.GetOrganizationId()
+	if _, err := s.organization(ctx, orgID); err != nil {
+		return nil, err
+	}
+	for _, m := range req.GetMembers().GetItems() {
+		for _, existing := range s.members[orgID] {
+			if existing.GetUserId() == m.GetUserId() {
+				existing.Owner = m.GetOwner()
+			}
+		}
+	}
+	return &common.Empty{}, nil
+}
+
+// DeleteOrganizationMembers removes members from an organization.
+func (x *resourceManagerService) DeleteOrganizationMembers(ctx context.Context, req *rm.OrganizationMembersRequest) (*common.Empty, error) {
+	s := x.s
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	orgID := req.GetOrganizationId()
+	if _, err := s.organization(ctx, orgID); err != nil {
+		return nil, err
+	}
+	for _, m := range req.GetMembers().GetItems() {
+		members := s.members[orgID][:0]
+		for _, existing := range s.members[orgID] {
+			if existing.GetUserId() != m.GetUserId() {
+				members = append(members, existing)
+			}
+		}
+		s.members[orgID] = members
+	}
+	return &common.Empty{}, nil
+}
+
+// IsMemberOfOrganization checks if a user is a member of an organization.
+func (x *resourceManagerService) IsMemberOfOrganization(ctx context.Context, req *rm.IsMemberOfOrganizationRequest) (*rm.IsMemberOfOrganizationResponse, error) {
+	s := x.s
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	userID := req.GetUserId()
+	if userID == "" {
+		userID = s.userID(ctx)
+	}
+	return &rm.IsMemberOfOrganizationResponse{Member: s.isMember(req.GetOrganizationId(), userID)}, nil
+}
+
+// ListProjects returns the projects of an organization.
+func (x *resourceManagerService) ListProjects(ctx context.Context, req *common.ListOptions) (*rm.ProjectList, error) {
+	s := x.s
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if _, err := s.organization(ctx, req.GetContextId()); err != nil {
+		return nil, err
+	}
+	result := &rm.ProjectList{}
+	for _, p := range s.projects {
+		if p.GetOrganizationId() == req.GetContextId() {
+			result.Items = append(result.Items, clone(p).(*rm.Project))
+		}
+	}
+	return result, nil
+}
+
+// GetProject returns the project with given ID.
+func (x *resourceManagerService) GetProject(ctx context.Context, req *common.IDOptions) (*rm.Project, error) {
+	s := x.s
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	p, err := s.project(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return clone(p).(*rm.Project), nil
+}
+
+// CreateProject creates a project.
+func (x *resourceManagerService) CreateProject(ctx context.Context, req *rm.Project) (*rm.Project, error) {
+	s := x.s
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	org, err := s.organization(ctx, req.GetOrganizationId())
+	if err != nil {
+		return nil, err
+	}
+	if req.GetName() == "" {
+		return nil, common.InvalidArgument("Name missing")
+	}
+	p := clone(req).(*rm.Project)
+	p.Id = s.newID()
+	p.Url = org.GetUrl() + "/Project/" + p.Id
+	p.CreatedAt = types.TimestampNow()
+	s.projects[p.Id] = p
+	return clone(p).(*rm.Project), nil
+}
+
+// UpdateProject updates the name and description of a project.
+func (x *resourceManagerService) UpdateProject(ctx context.Context, req *rm.Project) (*rm.Project, error) {
+	s := x.s
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	p, err := s.project(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	p.Name = req.GetName()
+	p.Description = req.GetDescription()
+	return clone(p).(*rm.Project), nil
+}
+
+// DeleteProject removes a project.
+func (x *resourceManagerService) DeleteProject(ctx context.Context, req *common.IDOptions) (*common.Empty, error) {
+	s := x.s
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if _, err := s.project(ctx, req.GetId()); err != nil {
+		return nil, err
+	}
+	delete(s.projects, req.GetId())
+	return &common.Empty{}, nil
+}
+
+// ListOrganizationInvites returns the invites of an organization.
+func (x *resourceManagerService) ListOrganizationInvites(ctx context.Context, req *common.ListOptions) (*rm.OrganizationInviteList, error) {
+	s := x.s
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if _, err := s.organization(ctx, req.GetContextId()); err != nil {
+		return nil, err
+	}
+	result := &rm.OrganizationInviteList{}
+	for _, inv := range s.invites {
+		if inv.GetOrganizationId() == req.GetContextId() {
+			result.Items = append(result.Items, clone(inv).(*rm.OrganizationInvite))
+		}
+	}
+	return result, nil
+}
+
+// ListMyOrganizationInvites returns the invites for the caller.
+func (x *resourceManagerService) ListMyOrganizationInvites(ctx context.Context, req *common.ListOptions) (*rm.OrganizationInviteList, error) {
+	s := x.s
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	email := s.users[s.userID(ctx)].GetEmail()
+	result := &rm.OrganizationInviteList{}
+	for _, inv := range s.invites {
+		if inv.GetEmail() == email {
+			result.Items = append(result.Items, clone(inv).(*rm.OrganizationInvite))
+		}
+	}
+	return result, nil
+}
+
+// GetOrganizationInvite returns the invite with given ID.
+func (x *resourceManagerService) GetOrganizationInvite(ctx context.Context, req *common.IDOptions) (*rm.OrganizationInvite, error) {
+	s := x.s
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	inv, found := s.invites[req.GetId()]
+	if !found {
+		return nil, notFound("Organization invite", req.GetId())
+	}
+	return clone(inv).(*rm.OrganizationInvite), nil
+}
+
+// CreateOrganizationInvite creates an invite for an organization.
+func (x *resourceManagerService) CreateOrganizationInvite(ctx context.Context, req *rm.OrganizationInvite) (*rm.OrganizationInvite, error) {
+	s := x.s
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	org, err := s.organization(ctx, req.GetOrganizationId())
+	if err != nil {
+		return nil, err
+	}
+	if req.GetEmail() == "" {
+		return nil, common.InvalidArgument("Email missing")
+	}
+	inv := clone(req).(*rm.OrganizationInvite)
+	inv.Id = s.newID()
+	inv.Url = org.GetUrl() + "/OrganizationInvite/" + inv.Id
+	inv.CreatedAt = types.TimestampNow()
+	inv.CreatedById = s.userID(ctx)
+	inv.OrganizationName = org.GetName()
+	s.invites[inv.Id] = inv
+	return clone(inv).(*rm.OrganizationInvite), nil
+}
+
+// DeleteOrganizationInvite removes an invite.
+func (x *resourceManagerService) DeleteOrganizationInvite(ctx context.Context, req *common.IDOptions) (*common.Empty, error) {
+	s := x.s
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if _, found := s.invites[req.GetId()]; !found {
+		return nil, notFound("Organization invite", req.GetId())
+	}
+	delete(s.invites, req.GetId())
+	return &common.Empty{}, nil
+}
+
+// AcceptOrganizationInvite accepts an invite, making the caller a member.
+func (x *resourceManagerService) AcceptOrganizationInvite(ctx context.Context, req *common.IDOptions) (*common.Empty, error) {
+	s := x.s
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	inv, found := s.invites[req.GetId()]
+	if !found {
+		return nil, notFound("Organization invite", req.GetId())
+	}
+	userID := s.userID(ctx)
+	inv.Accepted = true
+	inv.AcceptedAt = types.TimestampNow()
+	inv.UserId = userID
+	if !s.isMember(inv.GetOrganizationId(), userID) {
+		s.members[inv.GetOrganizationId()] = append(s.members[inv.GetOrganizationId()], &rm.Member{UserId: userID})
+	}
+	return &common.Empty{}, nil
+}
+
+// RejectOrganizationInvite rejects an invite.
+func (x *resourceManagerService) RejectOrganizationInvite(ctx context.Context, req *common.IDOptions) (*common.Empty, error) {
+	s := x.s
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	inv, found := s.invites[req.GetId()]
+	if !found {
+		return nil, notFound("Organization invite", req.GetId())
+	}
+	inv.Rejected = true
+	inv.RejectedAt = types.TimestampNow()
+	return &common.Empty{}, nil
+}
+
+// isMember returns true if the user with given ID is a member of the
+// organization with given ID.
+// Requires the mutex to be locked.
+func (s *Server) isMember(orgID, userID string) bool {
+	for _, m := range s.members[orgID] {
+		if m.GetUserId() == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// organization returns the organization with given ID, if the caller is a member of it.
+// Requires the mutex to be locked.
+func (s *Server) organization(ctx context.Context, id string) (*rm.Organization, error) {
+	org, found := s.organizations[id]
+	if !found || !s.isMember(id, s.userID(ctx)) {
+		return nil, notFound("Organization", id)
+	}
+	return org, nil
+}
+
+// project returns the project with given ID, if the caller has access to it.
+// Requires the mutex to be locked.
+func (s *Server) project(ctx context.Context, id string) (*rm.Project, error) {
+	p, found := s.projects[id]
+	if !found {
+		return nil, notFound("Project", id)
+	}
+	if _, err := s.organization(ctx, p.GetOrganizationId()); err != nil {
+		return nil, notFound("Project", id)
+	}
+	return p, nil
+}
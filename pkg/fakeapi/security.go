@@ -0,0 +1,138 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Ewout Prangsma
+//
+
+package fakeapi
+
+import (
+	"context"
+
+	"github.com/gogo/protobuf/types"
+
+	common "github.com/arangodb-managed/apis/common/v1"
+	security "github.com/arangodb-managed/apis/security/v1"
+)
+
+// securityService implements the SecurityService.
+type securityService struct {
+	security.UnimplementedSecurityServiceServer
+	s *Server
+}
+
+// GetAPIVersion returns the version of the API.
+func (x *securityService) GetAPIVersion(context.Context, *common.Empty) (*common.Version, error) {
+	return version(), nil
+}
+
+// ListIPWhitelists returns the IP whitelists of a project.
+func (x *securityService) ListIPWhitelists(ctx context.Context, req *common.ListOptions) (*security.IPWhitelistList, error) {
+	s := x.s
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if _, err := s.project(ctx, req.GetContextId()); err != nil {
+		return nil, err
+	}
+	result := &security.IPWhitelistList{}
+	for _, w := range s.ipWhitelists {
+		if w.GetProjectId() == req.GetContextId() {
+			result.Items = append(result.Items, clone(w).(*security.IPWhitelist))
+		}
+	}
+	return result, nil
+}
+
+// GetIPWhitelist returns the IP whitelist with given ID.
+func (x *securityService) GetIPWhitelist(ctx context.Context, req *common.IDOptions) (*security.IPWhitelist, error) {
+	s := x.s
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	w, err := s.ipWhitelist(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return clone(w).(*security.IPWhitelist), nil
+}
+
+// CreateIPWhitelist creates an IP whitelist.
+func (x *securityService) CreateIPWhitelist(ctx context.Context, req *security.IPWhitelist) (*security.IPWhitelist, error) {
+	s := x.s
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	p, err := s.project(ctx, req.GetProjectId())
+	if err != nil {
+		return nil, err
+	}
+	if req.GetName() == "" {
+		return nil, common.InvalidArgument("Name missing")
+	}
+	w := clone(req).(*security.IPWhitelist)
+	w.Id = s.newID()
+	w.Url = p.GetUrl() + "/IPWhitelist/" + w.Id
+	w.CreatedAt = types.TimestampNow()
+	w.CreatedById = s.userID(ctx)
+	s.ipWhitelists[w.Id] = w
+	return clone(w).(*security.IPWhitelist), nil
+}
+
+// UpdateIPWhitelist updates the name, description and CIDR ranges of an IP whitelist.
+func (x *securityService) UpdateIPWhitelist(ctx context.Context, req *security.IPWhitelist) (*security.IPWhitelist, error) {
+	s := x.s
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	w, err := s.ipWhitelist(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	w.Name = req.GetName()
+	w.Description = req.GetDescription()
+	w.CidrRanges = append([]string(nil), req.GetCidrRanges()...)
+	return clone(w).(*security.IPWhitelist), nil
+}
+
+// DeleteIPWhitelist removes an IP whitelist.
+func (x *securityService) DeleteIPWhitelist(ctx context.Context, req *common.IDOptions) (*common.Empty, error) {
+	s := x.s
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if _, err := s.ipWhitelist(ctx, req.GetId()); err != nil {
+		return nil, err
+	}
+	for _, d := range s.deployments {
+		if d.GetIpwhitelistId() == req.GetId() {
+			return nil, common.PreconditionFailed("IP whitelist '%s' is in use", req.GetId())
+		}
+	}
+	delete(s.ipWhitelists, req.GetId())
+	return &common.Empty{}, nil
+}
+
+// ipWhitelist returns the IP whitelist with given ID, if the caller has access to it.
+// Requires the mutex to be locked.
+func (s *Server) ipWhitelist(ctx context.Context, id string) (*security.IPWhitelist, error) {
+	w, found := s.ipWhitelists[id]
+	if !found {
+		return nil, notFound("IP whitelist", id)
+	}
+	if _, err := s.project(ctx, w.GetProjectId()); err != nil {
+		return nil, notFound("IP whitelist", id)
+	}
+	return w, nil
+}
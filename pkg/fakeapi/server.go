@@ -0,0 +1,321 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Ewout Prangsma
+//
+
+package fakeapi
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/gogo/protobuf/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+
+	backup "github.com/arangodb-managed/apis/backup/v1"
+	"github.com/arangodb-managed/apis/common/auth"
+	common "github.com/arangodb-managed/apis/common/v1"
+	crypto "github.com/arangodb-managed/apis/crypto/v1"
+	data "github.com/arangodb-managed/apis/data/v1"
+	iam "github.com/arangodb-managed/apis/iam/v1"
+	monitoring "github.com/arangodb-managed/apis/monitoring/v1"
+	rm "github.com/arangodb-managed/apis/resourcemanager/v1"
+	security "github.com/arangodb-managed/apis/security/v1"
+)
+
+const (
+	// DefaultToken is the token that is accepted by every fake server.
+	DefaultToken = "fake-token"
+)
+
+// Server is an in-memory implementation of the ArangoDB Oasis API,
+// intended for testing oasisctl (and scripts using it) offline.
+// The server is served over plain (non-TLS) gRPC.
+type Server struct {
+	mutex      sync.Mutex
+	grpcServer *grpc.Server
+	listener   net.Listener
+	lastID     int
+
+	tokens         map[string]string // token -> user ID
+	user           *iam.User
+	users          map[string]*iam.User
+	organizations  map[string]*rm.Organization
+	members        map[string][]*rm.Member // organization ID -> members
+	invites        map[string]*rm.OrganizationInvite
+	projects       map[string]*rm.Project
+	deployments    map[string]*data.Deployment
+	backups        map[string]*backup.Backup
+	backupPolicies map[string]*backup.BackupPolicy
+	ipWhitelists   map[string]*security.IPWhitelist
+	caCertificates map[string]*crypto.CACertificate
+	groups         map[string]*iam.Group
+	groupMembers   map[string][]string // group ID -> user IDs
+	roles          map[string]*iam.Role
+	policies       map[string]*iam.Policy // resource URL -> policy
+	apiKeys        map[string]*apiKey
+	logs           map[string][]LogLine // deployment ID -> log lines
+	failures       map[string][]error   // method name -> errors of the next calls
+}
+
+// apiKey is an API key with its secret.
+type apiKey struct {
+	key    *iam.APIKey
+	secret string
+}
+
+// LogLine is a single line of a deployment log.
+type LogLine struct {
+	Timestamp time.Time
+	Message   string
+}
+
+// New creates a new fake server with a single user that authenticates
+// with DefaultToken.
+func New() *Server {
+	s := &Server{
+		tokens:         make(map[string]string),
+		users:          make(map[string]*iam.User),
+		organizations:  make(map[string]*rm.Organization),
+		members:        make(map[string][]*rm.Member),
+		invites:        make(map[string]*rm.OrganizationInvite),
+		projects:       make(map[string]*rm.Project),
+		deployments:    make(map[string]*data.Deployment),
+		backups:        make(map[string]*backup.Backup),
+		backupPolicies: make(map[string]*backup.BackupPolicy),
+		ipWhitelists:   make(map[string]*security.IPWhitelist),
+		caCertificates: make(map[string]*crypto.CACertificate),
+		groups:         make(map[string]*iam.Group),
+		groupMembers:   make(map[string][]string),
+		roles:          make(map[string]*iam.Role),
+		policies:       make(map[string]*iam.Policy),
+		apiKeys:        make(map[string]*apiKey),
+		logs:           make(map[string][]LogLine),
+		failures:       make(map[string][]error),
+	}
+	s.user = s.AddUser("user@example.com", "Fake User")
+	s.tokens[DefaultToken] = s.user.GetId()
+	for _, r := range predefinedRoles {
+		r.IsPredefined = true
+		r.Id = s.newID()
+		r.Url = "/Role/" + r.Id
+		r.CreatedAt = types.TimestampNow()
+		s.roles[r.Id] = r
+	}
+	return s
+}
+
+// Start listening on the given address (e.g. 127.0.0.1:0) and serve
+// requests in the background.
+func (s *Server) Start(address string) error {
+	return s.start(address)
+}
+
+// StartTLS starts serving the API on the given address using TLS
+// with the given configuration.
+func (s *Server) StartTLS(address string, config *tls.Config) error {
+	return s.start(address, grpc.Creds(credentials.NewTLS(config)))
+}
+
+// start serving the API on the given address with given additional options.
+func (s *Server) start(address string, opts ...grpc.ServerOption) error {
+	lis, err := net.Listen("tcp", address)
+	if err != nil {
+		return err
+	}
+	s.listener = lis
+	s.grpcServer = grpc.NewServer(append(opts,
+		grpc.UnaryInterceptor(s.authenticateUnary),
+		grpc.StreamInterceptor(s.authenticateStream),
+	)...)
+	backup.RegisterBackupServiceServer(s.grpcServer, &backupService{s: s})
+	crypto.RegisterCryptoServiceServer(s.grpcServer, &cryptoService{s: s})
+	data.RegisterDataServiceServer(s.grpcServer, &dataService{s: s})
+	iam.RegisterIAMServiceServer(s.grpcServer, &iamService{s: s})
+	monitoring.RegisterMonitoringServiceServer(s.grpcServer, &monitoringService{s: s})
+	rm.RegisterResourceManagerServiceServer(s.grpcServer, &resourceManagerService{s: s})
+	security.RegisterSecurityServiceServer(s.grpcServer, &securityService{s: s})
+	go s.grpcServer.Serve(lis)
+	return nil
+}
+
+// Address returns the address the server is listening on.
+func (s *Server) Address() string {
+	if s.listener == nil {
+		return ""
+	}
+	return s.listener.Addr().String()
+}
+
+// Stop the server.
+func (s *Server) Stop() {
+	if s.grpcServer != nil {
+		s.grpcServer.Stop()
+	}
+}
+
+// User returns the user that authenticates with DefaultToken.
+func (s *Server) User() *iam.User {
+	return s.user
+}
+
+// AddUser adds a user with given email address and name.
+func (s *Server) AddUser(email, name string) *iam.User {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	u := &iam.User{
+		Id:        s.newID(),
+		Email:     email,
+		Name:      name,
+		CreatedAt: types.TimestampNow(),
+	}
+	s.users[u.Id] = u
+	return u
+}
+
+// AddDeploymentLogs adds lines to the log of the deployment with given ID.
+func (s *Server) AddDeploymentLogs(deploymentID string, lines ...LogLine) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.logs[deploymentID] = append(s.logs[deploymentID], lines...)
+}
+
+// UpdateDeployment calls the given function with the deployment with given ID,
+// allowing tests to change its status.
+func (s *Server) UpdateDeployment(id string, update func(*data.Deployment)) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	d, found := s.deployments[id]
+	if !found {
+		return common.NotFound("Deployment '%s' not found", id)
+	}
+	update(d)
+	return nil
+}
+
+// UpdateBackup calls the given function with the backup with given ID,
+// allowing tests to change its status.
+func (s *Server) UpdateBackup(id string, update func(*backup.Backup)) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	b, found := s.backups[id]
+	if !found {
+		return common.NotFound("Backup '%s' not found", id)
+	}
+	update(b)
+	return nil
+}
+
+// newID returns a new unique identifier.
+// Requires the mutex to be locked.
+func (s *Server) newID() string {
+	s.lastID++
+	return fmt.Sprintf("fake%04d", s.lastID)
+}
+
+// authenticate returns the ID of the user authenticated by the given context.
+func (s *Server) authenticate(ctx context.Context) (string, error) {
+	token, found := auth.GetAccessToken(ctx)
+	if !found {
+		return "", common.Unauthenticated("No token")
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	userID, found := s.tokens[token]
+	if !found {
+		return "", common.Unauthenticated("Invalid token")
+	}
+	return userID, nil
+}
+
+// userID returns the ID of the user authenticated by the given context.
+// Requires the mutex to be locked.
+func (s *Server) userID(ctx context.Context) string {
+	token, _ := auth.GetAccessToken(ctx)
+	return s.tokens[token]
+}
+
+// FailNext makes the next calls of the method with given name
+// (e.g. GetOrganization) fail with the given errors, one per call.
+func (s *Server) FailNext(method string, errs ...error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.failures[method] = append(s.failures[method], errs...)
+}
+
+// nextFailure returns the error registered with FailNext for the method
+// with given full name, if any.
+func (s *Server) nextFailure(fullMethod string) error {
+	method := fullMethod[strings.LastIndex(fullMethod, "/")+1:]
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	errs := s.failures[method]
+	if len(errs) == 0 {
+		return nil
+	}
+	s.failures[method] = errs[1:]
+	return errs[0]
+}
+
+// authenticateUnary is a unary interceptor that rejects unauthenticated requests.
+func (s *Server) authenticateUnary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if err := s.nextFailure(info.FullMethod); err != nil {
+		return nil, err
+	}
+	if !strings.HasSuffix(info.FullMethod, "/AuthenticateAPIKey") {
+		if _, err := s.authenticate(ctx); err != nil {
+			return nil, err
+		}
+	}
+	return handler(ctx, req)
+}
+
+// authenticateStream is a stream interceptor that rejects unauthenticated requests.
+func (s *Server) authenticateStream(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := s.nextFailure(info.FullMethod); err != nil {
+		return err
+	}
+	if _, err := s.authenticate(ss.Context()); err != nil {
+		return err
+	}
+	return handler(srv, ss)
+}
+
+// version returns the API version.
+func version() *common.Version {
+	return &common.Version{Major: 1}
+}
+
+// clone returns a deep copy of the given message.
+func clone(msg proto.Message) proto.Message {
+	return proto.Clone(msg)
+}
+
+// notFound returns a NotFound error for the given kind of object.
+func notFound(kind, id string) error {
+	return common.NotFound("%s '%s' not found", kind, id)
+}
@@ -27,7 +27,7 @@ import (
 )
 
 // APIKey returns a single api key formatted for humans.
-func APIKey(x *iam.APIKey, opts Options) string {
+func APIKey(x *iam.APIKey, opts Options) (string, error) {
 	return formatObject(opts,
 		kv{"id", x.GetId()},
 		kv{"user-id", x.GetUserId()},
@@ -40,7 +40,7 @@ func APIKey(x *iam.APIKey, opts Options) string {
 }
 
 // APIKeyList returns a list of api keys formatted for humans.
-func APIKeyList(list []*iam.APIKey, opts Options) string {
+func APIKeyList(list []*iam.APIKey, opts Options) (string, error) {
 	return formatList(opts, list, func(i int) []kv {
 		x := list[i]
 		return []kv{
@@ -27,7 +27,7 @@ import (
 )
 
 // APIKeySecret returns a single api key secret formatted for humans.
-func APIKeySecret(x *iam.APIKeySecret, opts Options) string {
+func APIKeySecret(x *iam.APIKeySecret, opts Options) (string, error) {
 	return formatObject(opts,
 		kv{"id", x.GetId()},
 		kv{"secret", x.GetSecret()},
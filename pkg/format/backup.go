@@ -27,7 +27,7 @@ import (
 )
 
 // Backup returns a single backup formatted for humans.
-func Backup(x *backup.Backup, opts Options) string {
+func Backup(x *backup.Backup, opts Options) (string, error) {
 
 	data := []kv{
 		{"id", x.Id},
@@ -43,11 +43,11 @@ func Backup(x *backup.Backup, opts Options) string {
 		{"deleted-at", formatTime(opts, x.DeletedAt)},
 	}
 
-	if x.Status != nil {
-		data = append(data, kv{"state", x.Status.State}, kv{"uploaded", x.Status.GetUploadStatus().GetUploaded()})
+	if status := x.GetStatus(); status != nil {
+		data = append(data, kv{"state", status.GetState()}, kv{"uploaded", status.GetUploadStatus().GetUploaded()})
 	}
-	if x.DeploymentInfo.Servers != nil {
-		data = append(data, kv{"dbservers", x.DeploymentInfo.Servers.Dbservers})
+	if servers := x.GetDeploymentInfo().GetServers(); servers != nil {
+		data = append(data, kv{"dbservers", servers.GetDbservers()})
 	}
 	return formatObject(opts, data...)
 }
@@ -0,0 +1,47 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Ewout Prangsma
+//
+
+package format
+
+import (
+	backup "github.com/arangodb-managed/apis/backup/v1"
+)
+
+// BackupDownload returns the download status of a backup formatted for humans.
+func BackupDownload(x *backup.Backup, opts Options) (string, error) {
+	status := x.GetStatus()
+	downloadStatus := status.GetDownloadStatus()
+	return formatObject(opts,
+		kv{"id", x.GetId()},
+		kv{"name", x.GetName()},
+		kv{"deployment-id", x.GetDeploymentId()},
+		kv{"state", status.GetState()},
+		kv{"progress", status.GetProgress()},
+		kv{"message", status.GetMessage()},
+		kv{"revision", x.GetDownload().GetRevision()},
+		kv{"downloaded-revision", downloadStatus.GetRevision()},
+		kv{"downloaded", downloadStatus.GetDownloaded()},
+		kv{"available", status.GetAvailable()},
+		kv{"requested-at", formatTime(opts, x.GetDownload().GetLastUpdatedAt(), "-")},
+		kv{"downloaded-at", formatTime(opts, downloadStatus.GetDownloadedAt(), "-")},
+	)
+}
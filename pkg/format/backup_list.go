@@ -27,7 +27,7 @@ import (
 )
 
 // BackupList returns a list of backups for a deployment.
-func BackupList(list []*backup.Backup, opts Options) string {
+func BackupList(list []*backup.Backup, opts Options) (string, error) {
 	return formatList(opts, list, func(i int) []kv {
 		x := list[i]
 		return []kv{
@@ -0,0 +1,118 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Ewout Prangsma
+//
+
+package format
+
+import (
+	"fmt"
+	"strings"
+
+	backup "github.com/arangodb-managed/apis/backup/v1"
+)
+
+// BackupPolicy returns a single backup policy formatted for humans.
+func BackupPolicy(x *backup.BackupPolicy, opts Options) (string, error) {
+	return formatObject(opts,
+		kv{"id", x.GetId()},
+		kv{"name", x.GetName()},
+		kv{"description", x.GetDescription()},
+		kv{"deployment-id", x.GetDeploymentId()},
+		kv{"url", x.GetUrl()},
+		kv{"schedule", formatBackupPolicySchedule(x.GetSchedule())},
+		kv{"upload", x.GetUpload()},
+		kv{"retention-period", formatDuration(opts, x.GetRetentionPeriod(), "-")},
+		kv{"email-notification", x.GetEmailNotification()},
+		kv{"paused", x.GetIsPaused()},
+		kv{"deleted", x.GetIsDeleted()},
+		kv{"next-backup", formatTime(opts, x.GetStatus().GetNextBackup(), "-")},
+		kv{"message", x.GetStatus().GetMessage()},
+		kv{"created-at", formatTime(opts, x.GetCreatedAt())},
+		kv{"deleted-at", formatTime(opts, x.GetDeletedAt(), "-")},
+	)
+}
+
+// BackupPolicyList returns a list of backup policies formatted for humans.
+func BackupPolicyList(list []*backup.BackupPolicy, opts Options) (string, error) {
+	return formatList(opts, list, func(i int) []kv {
+		x := list[i]
+		return []kv{
+			{"id", x.GetId()},
+			{"name", x.GetName()},
+			{"deployment-id", x.GetDeploymentId()},
+			{"schedule", formatBackupPolicySchedule(x.GetSchedule())},
+			{"upload", x.GetUpload()},
+			{"retention-period", formatDuration(opts, x.GetRetentionPeriod(), "-")},
+			{"paused", x.GetIsPaused()},
+			{"deleted", x.GetIsDeleted()},
+			{"next-backup", formatTime(opts, x.GetStatus().GetNextBackup(), "-")},
+			{"created-at", formatTime(opts, x.GetCreatedAt())},
+		}
+	}, false)
+}
+
+// formatBackupPolicySchedule returns a one line description of the given schedule,
+// e.g. "Daily at 02:30 UTC on Mon,Fri".
+func formatBackupPolicySchedule(x *backup.BackupPolicy_Schedule) string {
+	switch x.GetScheduleType() {
+	case "Hourly":
+		return fmt.Sprintf("Hourly every %dh", x.GetHourlySchedule().GetScheduleEveryIntervalHours())
+	case "Daily":
+		s := x.GetDailySchedule()
+		days := []struct {
+			name    string
+			enabled bool
+		}{
+			{"Mon", s.GetMonday()},
+			{"Tue", s.GetTuesday()},
+			{"Wed", s.GetWednesday()},
+			{"Thu", s.GetThursday()},
+			{"Fri", s.GetFriday()},
+			{"Sat", s.GetSaturday()},
+			{"Sun", s.GetSunday()},
+		}
+		var enabled []string
+		for _, d := range days {
+			if d.enabled {
+				enabled = append(enabled, d.name)
+			}
+		}
+		result := "Daily at " + formatTimeOfDay(s.GetScheduleAt())
+		if len(enabled) > 0 && len(enabled) < len(days) {
+			result += " on " + strings.Join(enabled, ",")
+		}
+		return result
+	case "Monthly":
+		s := x.GetMonthlySchedule()
+		return fmt.Sprintf("Monthly on day %d at %s", s.GetDayOfMonth(), formatTimeOfDay(s.GetScheduleAt()))
+	default:
+		return x.GetScheduleType()
+	}
+}
+
+// formatTimeOfDay returns the given time of day as "HH:MM <zone>".
+func formatTimeOfDay(x *backup.TimeOfDay) string {
+	tz := x.GetTimeZone()
+	if tz == "" {
+		tz = "UTC"
+	}
+	return fmt.Sprintf("%02d:%02d %s", x.GetHours(), x.GetMinutes(), tz)
+}
@@ -0,0 +1,55 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Ewout Prangsma
+//
+
+package format
+
+import (
+	"strings"
+
+	backup "github.com/arangodb-managed/apis/backup/v1"
+)
+
+// BackupPruneItem is a backup with the action taken by a prune.
+type BackupPruneItem struct {
+	// The backup
+	Backup *backup.Backup
+	// Action for the backup (keep|delete|expire|deleted|expired)
+	Action string
+	// Reasons for keeping the backup
+	Reasons []string
+}
+
+// BackupPrunePlan returns a list of backups with their prune actions formatted for humans.
+func BackupPrunePlan(list []BackupPruneItem, opts Options) (string, error) {
+	return formatList(opts, list, func(i int) []kv {
+		x := list[i]
+		return []kv{
+			{"id", x.Backup.GetId()},
+			{"name", x.Backup.GetName()},
+			{"backup-policy-id", x.Backup.GetBackupPolicyId()},
+			{"created-at", formatTime(opts, x.Backup.GetCreatedAt())},
+			{"auto-deleted-at", formatTime(opts, x.Backup.GetAutoDeletedAt(), "-")},
+			{"action", x.Action},
+			{"reason", strings.Join(x.Reasons, ", ")},
+		}
+	}, true)
+}
@@ -27,7 +27,7 @@ import (
 )
 
 // CACertificate returns a single ca certificate formatted for humans.
-func CACertificate(x *crypto.CACertificate, opts Options) string {
+func CACertificate(x *crypto.CACertificate, opts Options) (string, error) {
 	return formatObject(opts,
 		kv{"id", x.GetId()},
 		kv{"name", x.GetName()},
@@ -41,7 +41,7 @@ func CACertificate(x *crypto.CACertificate, opts Options) string {
 }
 
 // CACertificateList returns a list of ca certificates formatted for humans.
-func CACertificateList(list []*crypto.CACertificate, opts Options) string {
+func CACertificateList(list []*crypto.CACertificate, opts Options) (string, error) {
 	return formatList(opts, list, func(i int) []kv {
 		x := list[i]
 		return []kv{
@@ -27,7 +27,7 @@ import (
 )
 
 // CPUSizeList returns a list of CPU sizes.
-func CPUSizeList(list []*data.CPUSize, opts Options) string {
+func CPUSizeList(list []*data.CPUSize, opts Options) (string, error) {
 	return formatList(opts, list, func(i int) []kv {
 		x := list[i]
 		return []kv{
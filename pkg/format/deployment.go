@@ -29,7 +29,7 @@ import (
 )
 
 // Deployment returns a single deployment formatted for humans.
-func Deployment(x *data.Deployment, creds *data.DeploymentCredentials, opts Options, showRootpassword bool) string {
+func Deployment(x *data.Deployment, creds *data.DeploymentCredentials, opts Options, showRootpassword bool) (string, error) {
 	pwd := func(creds *data.DeploymentCredentials) string {
 		if showRootpassword {
 			return creds.GetPassword()
@@ -63,21 +63,21 @@ func Deployment(x *data.Deployment, creds *data.DeploymentCredentials, opts Opti
 		{"endpoint-url", x.GetStatus().GetEndpoint()},
 		{"root-password", pwd(creds)},
 
-		{"model", x.Model.Model},
+		{"model", x.GetModel().GetModel()},
 		{"is-clone", x.GetIsClone()},
 		{"clone-backup-id", x.GetCloneBackupId()},
 	}
-	if x.Model.Model != data.ModelFlexible {
+	if x.GetModel().GetModel() != data.ModelFlexible {
 		d = append(d,
-			kv{"node-count", fmt.Sprintf("%d", x.Model.NodeCount)},
-			kv{"node-disk-size", fmt.Sprintf("%d%s", x.Model.NodeDiskSize, "GB")},
-			kv{"node-size-id", x.Model.NodeSizeId})
+			kv{"node-count", fmt.Sprintf("%d", x.GetModel().GetNodeCount())},
+			kv{"node-disk-size", fmt.Sprintf("%d%s", x.GetModel().GetNodeDiskSize(), "GB")},
+			kv{"node-size-id", x.GetModel().GetNodeSizeId()})
 	}
 	return formatObject(opts, d...)
 }
 
 // DeploymentList returns a list of deployments formatted for humans.
-func DeploymentList(list []*data.Deployment, opts Options) string {
+func DeploymentList(list []*data.Deployment, opts Options) (string, error) {
 	return formatList(opts, list, func(i int) []kv {
 		x := list[i]
 		d := []kv{
@@ -90,13 +90,13 @@ func DeploymentList(list []*data.Deployment, opts Options) string {
 			{"url", x.GetUrl()},
 			{"paused", formatBool(opts, x.GetIsPaused())},
 			{"created-at", formatTime(opts, x.GetCreatedAt())},
-			{"model", x.Model.Model},
+			{"model", x.GetModel().GetModel()},
 		}
-		if x.Model.Model != data.ModelFlexible {
+		if x.GetModel().GetModel() != data.ModelFlexible {
 			d = append(d,
-				kv{"node-count", fmt.Sprintf("%d", x.Model.NodeCount)},
-				kv{"node-disk-size", fmt.Sprintf("%d%s", x.Model.NodeDiskSize, "GB")},
-				kv{"node-size-id", x.Model.NodeSizeId})
+				kv{"node-count", fmt.Sprintf("%d", x.GetModel().GetNodeCount())},
+				kv{"node-disk-size", fmt.Sprintf("%d%s", x.GetModel().GetNodeDiskSize(), "GB")},
+				kv{"node-size-id", x.GetModel().GetNodeSizeId()})
 		}
 		return d
 	}, false)
@@ -27,7 +27,7 @@ import (
 )
 
 // Example returns a single example dataset formatted for humans.
-func Example(x *example.ExampleDataset, opts Options) string {
+func Example(x *example.ExampleDataset, opts Options) (string, error) {
 	return formatObject(opts,
 		kv{"id", x.Id},
 		kv{"name", x.Name},
@@ -39,7 +39,7 @@ func Example(x *example.ExampleDataset, opts Options) string {
 }
 
 // ExampleList returns a list of example datasets.
-func ExampleList(list []*example.ExampleDataset, opts Options) string {
+func ExampleList(list []*example.ExampleDataset, opts Options) (string, error) {
 	return formatList(opts, list, func(i int) []kv {
 		x := list[i]
 		return []kv{
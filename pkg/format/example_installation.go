@@ -27,7 +27,7 @@ import (
 )
 
 // ExampleDatasetInstallation returns a single installation formatted for humans.
-func ExampleDatasetInstallation(x *example.ExampleDatasetInstallation, opts Options) string {
+func ExampleDatasetInstallation(x *example.ExampleDatasetInstallation, opts Options) (string, error) {
 	data := []kv{
 		{"id", x.Id},
 		{"deleted", x.IsDeleted},
@@ -46,7 +46,7 @@ func ExampleDatasetInstallation(x *example.ExampleDatasetInstallation, opts Opti
 }
 
 // ExampleDatasetInstallationList returns a list of installations formatted for humans.
-func ExampleDatasetInstallationList(list []*example.ExampleDatasetInstallation, opts Options) string {
+func ExampleDatasetInstallationList(list []*example.ExampleDatasetInstallation, opts Options) (string, error) {
 	return formatList(opts, list, func(i int) []kv {
 		x := list[i]
 		data := []kv{
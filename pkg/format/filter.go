@@ -0,0 +1,278 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Ewout Prangsma
+//
+
+package format
+
+import (
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// filter is a parsed filter expression that decides if a row (item) of a list
+// must be included in the output.
+type filter func(data []kv) bool
+
+// filterOperators contains all comparison operators, longest first.
+var filterOperators = []string{"==", "!=", "<=", ">=", "=", "<", ">"}
+
+// parseFilter parses a filter expression like `paused=true && version<3.7`.
+// Supported are:
+// - comparisons `<field><op><value>` with op one of =, ==, !=, <, <=, >, >=
+// - a bare `<field>`, meaning `<field>=true`
+// - `!`, `&&`, `||` and parentheses.
+// A value containing `*`, `?` or `[` is a glob pattern when used with = or !=.
+// Values can be quoted using single or double quotes.
+func parseFilter(expr string) (filter, error) {
+	tokens, err := tokenizeFilter(expr)
+	if err != nil {
+		return nil, err
+	}
+	p := &filterParser{tokens: tokens}
+	f, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("unexpected '%s'", p.tokens[p.pos].text)
+	}
+	return f, nil
+}
+
+// filterToken is a single token of a filter expression.
+type filterToken struct {
+	text string
+	// Set for words & quoted strings, unset for operators.
+	isValue bool
+}
+
+// tokenizeFilter splits the given filter expression into tokens.
+func tokenizeFilter(expr string) ([]filterToken, error) {
+	var result []filterToken
+	for i := 0; i < len(expr); {
+		c := expr[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n':
+			i++
+		case strings.HasPrefix(expr[i:], "&&"), strings.HasPrefix(expr[i:], "||"):
+			result = append(result, filterToken{text: expr[i : i+2]})
+			i += 2
+		case c == '(' || c == ')':
+			result = append(result, filterToken{text: string(c)})
+			i++
+		case c == '\'' || c == '"':
+			end := strings.IndexByte(expr[i+1:], c)
+			if end < 0 {
+				return nil, fmt.Errorf("missing closing %c", c)
+			}
+			result = append(result, filterToken{text: expr[i+1 : i+1+end], isValue: true})
+			i += end + 2
+		case strings.IndexByte("!=<>", c) >= 0:
+			op := "!"
+			for _, x := range filterOperators {
+				if strings.HasPrefix(expr[i:], x) {
+					op = x
+					break
+				}
+			}
+			result = append(result, filterToken{text: op})
+			i += len(op)
+		default:
+			start := i
+			for i < len(expr) && strings.IndexByte(" \t\n&|()!=<>'\"", expr[i]) < 0 {
+				i++
+			}
+			if i == start {
+				return nil, fmt.Errorf("unexpected '%c'", c)
+			}
+			result = append(result, filterToken{text: expr[start:i], isValue: true})
+		}
+	}
+	return result, nil
+}
+
+// filterParser is a recursive descent parser for filter expressions.
+type filterParser struct {
+	tokens []filterToken
+	pos    int
+}
+
+// peek returns the current token, or an empty token at the end.
+func (p *filterParser) peek() filterToken {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return filterToken{}
+}
+
+// parseOr parses `and ('||' and)*`.
+func (p *filterParser) parseOr() (filter, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for t := p.peek(); !t.isValue && t.text == "||"; t = p.peek() {
+		p.pos++
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		l := left
+		left = func(data []kv) bool { return l(data) || right(data) }
+	}
+	return left, nil
+}
+
+// parseAnd parses `unary ('&&' unary)*`.
+func (p *filterParser) parseAnd() (filter, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for t := p.peek(); !t.isValue && t.text == "&&"; t = p.peek() {
+		p.pos++
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		l := left
+		left = func(data []kv) bool { return l(data) && right(data) }
+	}
+	return left, nil
+}
+
+// parseUnary parses `'!' unary`, `'(' or ')'` or a comparison.
+func (p *filterParser) parseUnary() (filter, error) {
+	t := p.peek()
+	switch {
+	case t.isValue:
+		return p.parseComparison()
+	case t.text == "!":
+		p.pos++
+		f, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return func(data []kv) bool { return !f(data) }, nil
+	case t.text == "(":
+		p.pos++
+		f, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if t := p.peek(); t.isValue || t.text != ")" {
+			return nil, fmt.Errorf("missing ')'")
+		}
+		p.pos++
+		return f, nil
+	case t.text == "":
+		return nil, fmt.Errorf("unexpected end of expression")
+	}
+	return nil, fmt.Errorf("unexpected '%s'", t.text)
+}
+
+// parseComparison parses `field op value` or `field`.
+func (p *filterParser) parseComparison() (filter, error) {
+	key := p.peek().text
+	p.pos++
+	op := p.peek()
+	if op.isValue || !isComparisonOperator(op.text) {
+		// Bare field
+		return func(data []kv) bool { return matchFilterValue(data, key, "=", "true") }, nil
+	}
+	p.pos++
+	value := p.peek()
+	if !value.isValue {
+		return nil, fmt.Errorf("missing value after '%s%s'", key, op.text)
+	}
+	p.pos++
+	return func(data []kv) bool { return matchFilterValue(data, key, op.text, value.text) }, nil
+}
+
+// isComparisonOperator returns true if the given text is a comparison operator.
+func isComparisonOperator(text string) bool {
+	for _, x := range filterOperators {
+		if text == x {
+			return true
+		}
+	}
+	return false
+}
+
+// matchFilterValue returns true if the field with given key in the given data
+// matches the given value using the given operator.
+// Rows without such field never match.
+func matchFilterValue(data []kv, key, op, value string) bool {
+	var actual interface{}
+	found := false
+	for _, x := range data {
+		if x.Key == key {
+			actual, found = x.Value, true
+			break
+		}
+	}
+	if !found {
+		return false
+	}
+	if op == "=" || op == "==" || op == "!=" {
+		if strings.ContainsAny(value, "*?[") {
+			matched, _ := path.Match(value, toString(actual))
+			return matched == (op != "!=")
+		}
+	}
+	cmp := compareValues(actual, filterValueLike(actual, value))
+	switch op {
+	case "=", "==":
+		return cmp == 0
+	case "!=":
+		return cmp != 0
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	}
+	return false
+}
+
+// filterValueLike converts the given filter value into the type of the given
+// actual value, so they can be compared.
+func filterValueLike(actual interface{}, value string) interface{} {
+	switch actual.(type) {
+	case boolValue:
+		if b, err := strconv.ParseBool(value); err == nil {
+			return boolValue{value, b}
+		}
+	case timeValue:
+		for _, layout := range []string{time.RFC3339, "2006-01-02"} {
+			if t, err := time.Parse(layout, value); err == nil {
+				return timeValue{value, t}
+			}
+		}
+	}
+	return value
+}
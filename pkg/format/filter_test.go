@@ -0,0 +1,123 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Ewout Prangsma
+//
+
+package format
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	rm "github.com/arangodb-managed/apis/resourcemanager/v1"
+)
+
+func TestFilter(t *testing.T) {
+	data := []kv{
+		{"name", "prod-db"},
+		{"version", "3.6.4"},
+		{"paused", boolValue{"true", true}},
+		{"size", "32GB"},
+		{"created-at", timeValue{"2020-05-01T12:00:00Z", time.Date(2020, 5, 1, 12, 0, 0, 0, time.UTC)}},
+		{"description", "Main database"},
+	}
+	tests := []struct {
+		expr    string
+		matches bool
+	}{
+		{"paused", true},
+		{"!paused", false},
+		{"paused=true", true},
+		{"paused=false", false},
+		{"name=prod-db", true},
+		{"name==prod-db", true},
+		{"name!=prod-db", false},
+		{"name=prod-*", true},
+		{"name!=test-*", true},
+		{"name=?rod-db", true},
+		{"version<3.7", true},
+		{"version<3.6.1", false},
+		{"version>=3.6.4", true},
+		{"size>4GB", true},
+		{"size<=1TB", true},
+		{"created-at<2020-05-02", true},
+		{"created-at>2020-05-01T13:00:00Z", false},
+		{"description='Main database'", true},
+		{`description="Main database"`, true},
+		{"missing=x", false},
+		{"missing!=x", false},
+		{"paused && version<3.7", true},
+		{"paused && version>3.7", false},
+		{"!paused || version<3.7", true},
+		{"(name=x || name=prod-db) && paused", true},
+		{"!(name=x || name=prod-db)", false},
+	}
+	for _, test := range tests {
+		f, err := parseFilter(test.expr)
+		if err != nil {
+			t.Errorf("parseFilter(%q) failed: %v", test.expr, err)
+		} else if f(data) != test.matches {
+			t.Errorf("parseFilter(%q) matches = %v; expected %v", test.expr, !test.matches, test.matches)
+		}
+	}
+}
+
+func TestFilterErrors(t *testing.T) {
+	tests := map[string]string{
+		"":               "unexpected end of expression",
+		"paused &&":      "unexpected end of expression",
+		"(paused":        "missing ')'",
+		"paused)":        "unexpected ')'",
+		"name='prod":     "missing closing '",
+		"version<":       "missing value after 'version<'",
+		"name=x && && y": "unexpected '&&'",
+		"name=x ; y":     "unexpected ';'",
+		"= x":            "unexpected '='",
+	}
+	for expr, expected := range tests {
+		if _, err := parseFilter(expr); err == nil || !strings.Contains(err.Error(), expected) {
+			t.Errorf("parseFilter(%q): expected error containing %q, got %v", expr, expected, err)
+		}
+	}
+}
+
+func TestEmptyList(t *testing.T) {
+	list := []*rm.Project{{Id: "p1", Name: "one"}}
+	tests := []struct {
+		opts     Options
+		expected string
+	}{
+		{Options{Format: formatTable}, "None"},
+		{Options{Format: formatTable, Filter: "name=two"}, "None"},
+		{Options{Format: formatTable, Filter: "name=two", NoHeaders: true}, ""},
+		{Options{Format: formatTable, Filter: "name=two", NoHeaders: true, Watch: NewWatch(false)}, ""},
+		{Options{Format: formatTable, Filter: "name=two", Watch: NewWatch(false)}, "None"},
+	}
+	for _, test := range tests {
+		input := list
+		if test.opts.Filter == "" {
+			input = nil
+		}
+		if output, err := ProjectList(input, test.opts); err != nil || output != test.expected {
+			t.Errorf("ProjectList(%+v) = %q, %v; expected %q", test.opts, output, err, test.expected)
+		}
+	}
+}
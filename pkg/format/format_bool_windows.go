@@ -27,12 +27,12 @@ import (
 )
 
 // formatBool returns a human readable checkmark for the given boolean
-func formatBool(opts Options, x bool) string {
-	if opts.Format == formatJSON {
-		return strconv.FormatBool(x)
+func formatBool(opts Options, x bool) boolValue {
+	if !opts.isHumanReadable() {
+		return boolValue{strconv.FormatBool(x), x}
 	}
 	if x {
-		return "x"
+		return boolValue{"x", x}
 	}
-	return "-"
+	return boolValue{"-", x}
 }
@@ -23,6 +23,7 @@
 package format
 
 import (
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
 	"reflect"
@@ -33,6 +34,7 @@ import (
 	"github.com/dustin/go-humanize"
 	"github.com/gogo/protobuf/types"
 	"github.com/ryanuber/columnize"
+	"gopkg.in/yaml.v2"
 )
 
 type kv struct {
@@ -53,12 +55,27 @@ var (
 		Prefix: "",
 		Empty:  "",
 	}
+	// now returns the reference time for relative (humanized) timestamps.
+	now = time.Now
 )
 
 // formatObject returns a formatted representation of the given
 // data which is a map from field-name to value.
-func formatObject(opts Options, data ...kv) string {
-	if opts.Format == formatJSON {
+func formatObject(opts Options, data ...kv) (string, error) {
+	if len(opts.Columns) > 0 {
+		data = selectColumns(data, opts.Columns)
+	}
+
+	var changed func(row int, key string) bool
+	if opts.Watch != nil {
+		if opts.name() == formatJSON {
+			return opts.Watch.formatChangedJSON([][]kv{data}), nil
+		}
+		changed, _ = opts.Watch.update([][]kv{data})
+	}
+
+	switch opts.name() {
+	case formatJSON:
 		m := make(map[string]interface{}, len(data))
 		for _, kv := range data {
 			m[kv.Key] = kv.Value
@@ -67,28 +84,68 @@ func formatObject(opts Options, data ...kv) string {
 		if err != nil {
 			panic(err)
 		}
-		return string(encoded)
+		return string(encoded), nil
+	case formatYAML:
+		return formatYAMLValue(toMapSlice(data)), nil
+	case formatCSV, formatTSV:
+		return formatSeparated(opts, [][]kv{data}, true), nil
+	case formatGoTemplate, formatTemplate, formatJSONPath:
+		return formatTemplateRows(opts, [][]kv{data})
 	}
 
 	// Table
 	lines := make([]string, 0, len(data))
 	for _, kv := range data {
 		title := strings.Title(kv.Key)
-		lines = append(lines, fmt.Sprintf("%s |^| %v", title, kv.Value))
+		value := fmt.Sprintf("%v", kv.Value)
+		if changed != nil {
+			value = opts.Watch.wrap(value, changed(0, kv.Key))
+		}
+		lines = append(lines, fmt.Sprintf("%s |^| %s", title, value))
 	}
-	return columnize.Format(lines, singleConfig)
+	return columnize.Format(lines, singleConfig), nil
 }
 
 // formatList returns a formatted representation of the given
 // list.
-func formatList(opts Options, list interface{}, getData func(int) []kv, noSort bool) string {
+func formatList(opts Options, list interface{}, getData func(int) []kv, noSort bool) (string, error) {
 	listv := reflect.ValueOf(list)
 	length := listv.Len()
+	rows := make([][]kv, 0, length)
+	for i := 0; i < length; i++ {
+		rows = append(rows, getData(i))
+	}
+	if opts.Filter != "" {
+		// The filter has been validated in Options.Validate
+		if match, err := parseFilter(opts.Filter); err == nil {
+			filtered := rows[:0]
+			for _, data := range rows {
+				if match(data) {
+					filtered = append(filtered, data)
+				}
+			}
+			rows = filtered
+		}
+	}
+	length = len(rows)
+	if opts.SortBy != "" {
+		sortRows(rows, opts.SortBy)
+		noSort = true
+	}
+	if len(opts.Columns) > 0 {
+		for i, data := range rows {
+			rows[i] = selectColumns(data, opts.Columns)
+		}
+	}
 
-	if opts.Format == formatJSON {
+	if opts.Watch != nil && opts.name() == formatJSON {
+		return opts.Watch.formatChangedJSON(rows), nil
+	}
+
+	switch opts.name() {
+	case formatJSON:
 		l := make([]map[string]interface{}, length)
-		for i := 0; i < length; i++ {
-			data := getData(i)
+		for i, data := range rows {
 			m := make(map[string]interface{})
 			l[i] = m
 			for _, kv := range data {
@@ -99,18 +156,33 @@ func formatList(opts Options, list interface{}, getData func(int) []kv, noSort b
 		if err != nil {
 			panic(err)
 		}
-		return string(encoded)
+		return string(encoded), nil
+	case formatYAML:
+		l := make([]yaml.MapSlice, length)
+		for i, data := range rows {
+			l[i] = toMapSlice(data)
+		}
+		return formatYAMLValue(l), nil
+	case formatCSV, formatTSV:
+		return formatSeparated(opts, rows, noSort), nil
+	case formatGoTemplate, formatTemplate, formatJSONPath:
+		return formatTemplateRows(opts, rows)
 	}
 
 	// Table
+	if opts.Watch != nil {
+		return formatWatchTable(opts, rows, noSort), nil
+	}
 	if length == 0 {
-		return "None"
+		if opts.NoHeaders {
+			return "", nil
+		}
+		return "None", nil
 	}
 	lines := make([]string, 0, length+2)
-	for i := 0; i < length; i++ {
-		data := getData(i)
+	for i, data := range rows {
 		row := make([]string, 0, len(data))
-		if i == 0 {
+		if i == 0 && !opts.NoHeaders {
 			for _, kv := range data {
 				row = append(row, strings.Title(kv.Key))
 			}
@@ -123,24 +195,118 @@ func formatList(opts Options, list interface{}, getData func(int) []kv, noSort b
 		lines = append(lines, strings.Join(row, "|^|"))
 	}
 	if !noSort {
-		sort.Strings(lines[1:])
+		if opts.NoHeaders {
+			sort.Strings(lines)
+		} else {
+			sort.Strings(lines[1:])
+		}
+	}
+	return columnize.Format(lines, listConfig), nil
+}
+
+// toMapSlice converts the given data into an ordered YAML map.
+func toMapSlice(data []kv) yaml.MapSlice {
+	result := make(yaml.MapSlice, 0, len(data))
+	for _, kv := range data {
+		result = append(result, yaml.MapItem{Key: kv.Key, Value: kv.Value})
+	}
+	return result
+}
+
+// formatYAMLValue returns the YAML representation of the given value.
+func formatYAMLValue(v interface{}) string {
+	encoded, err := yaml.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+	return strings.TrimSuffix(string(encoded), "\n")
+}
+
+// formatSeparated returns a comma (csv) or tab (tsv) separated representation
+// of the given rows, starting with a header row.
+// The columns are ordered in the order in which the keys first appear in the rows.
+func formatSeparated(opts Options, rows [][]kv, noSort bool) string {
+	var columns []string
+	columnIndex := make(map[string]int)
+	for _, data := range rows {
+		for _, kv := range data {
+			if _, found := columnIndex[kv.Key]; !found {
+				columnIndex[kv.Key] = len(columns)
+				columns = append(columns, kv.Key)
+			}
+		}
+	}
+	records := make([][]string, 0, len(rows))
+	for _, data := range rows {
+		record := make([]string, len(columns))
+		for _, kv := range data {
+			record[columnIndex[kv.Key]] = fmt.Sprintf("%v", kv.Value)
+		}
+		records = append(records, record)
+	}
+	if !noSort {
+		sort.SliceStable(records, func(i, j int) bool {
+			return strings.Join(records[i], "\x00") < strings.Join(records[j], "\x00")
+		})
+	}
+
+	var sb strings.Builder
+	w := csv.NewWriter(&sb)
+	if opts.Format == formatTSV {
+		w.Comma = '\t'
 	}
-	return columnize.Format(lines, listConfig)
+	if !opts.NoHeaders {
+		w.Write(columns)
+	}
+	w.WriteAll(records)
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+// boolValue is a formatted boolean that remembers the actual value,
+// so values can be filtered & sorted by value.
+type boolValue struct {
+	formatted string
+	b         bool
 }
 
+// String returns the formatted boolean.
+func (v boolValue) String() string { return v.formatted }
+
+// MarshalJSON encodes the formatted boolean.
+func (v boolValue) MarshalJSON() ([]byte, error) { return json.Marshal(v.formatted) }
+
+// MarshalYAML encodes the formatted boolean.
+func (v boolValue) MarshalYAML() (interface{}, error) { return v.formatted, nil }
+
+// timeValue is a formatted timestamp that remembers the actual time,
+// so values can be sorted by time.
+type timeValue struct {
+	formatted string
+	t         time.Time
+}
+
+// String returns the formatted timestamp.
+func (v timeValue) String() string { return v.formatted }
+
+// MarshalJSON encodes the formatted timestamp.
+func (v timeValue) MarshalJSON() ([]byte, error) { return json.Marshal(v.formatted) }
+
+// MarshalYAML encodes the formatted timestamp.
+func (v timeValue) MarshalYAML() (interface{}, error) { return v.formatted, nil }
+
 // formatTime returns a human readable version of the given timestamp.
-func formatTime(opts Options, x *types.Timestamp, nilValue ...string) string {
+func formatTime(opts Options, x *types.Timestamp, nilValue ...string) timeValue {
 	if x == nil {
 		if len(nilValue) > 0 {
-			return nilValue[0]
+			return timeValue{formatted: nilValue[0]}
 		}
-		return ""
+		return timeValue{}
 	}
 	t, _ := types.TimestampFromProto(x)
-	if opts.Format == formatJSON {
-		return t.Format(time.RFC3339)
+	if !opts.isHumanReadable() {
+		return timeValue{formatted: t.Format(time.RFC3339), t: t}
 	}
-	return humanize.Time(t)
+	return timeValue{formatted: humanize.RelTime(t, now(), "ago", "from now"), t: t}
 }
 
 // formatDuration returns a human readable version of the given duration.
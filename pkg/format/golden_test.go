@@ -0,0 +1,437 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Ewout Prangsma
+//
+
+// The golden files contain the checkmarks that format_bool.go uses for
+// booleans in tables, while on Windows format_bool_windows.go uses x and -.
+// Windows checkouts may also convert the golden files to CRLF line endings,
+// so these tests only run on other platforms.
+
+//go:build !windows
+// +build !windows
+
+package format
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+	"google.golang.org/grpc"
+
+	backup "github.com/arangodb-managed/apis/backup/v1"
+	common "github.com/arangodb-managed/apis/common/v1"
+	crypto "github.com/arangodb-managed/apis/crypto/v1"
+	data "github.com/arangodb-managed/apis/data/v1"
+	example "github.com/arangodb-managed/apis/example/v1"
+	iam "github.com/arangodb-managed/apis/iam/v1"
+	platform "github.com/arangodb-managed/apis/platform/v1"
+	rm "github.com/arangodb-managed/apis/resourcemanager/v1"
+	security "github.com/arangodb-managed/apis/security/v1"
+)
+
+var update = flag.Bool("update", false, "update the golden files in testdata/golden")
+
+var (
+	// Reference time used for relative timestamps in table output.
+	goldenNow = time.Date(2020, 3, 1, 12, 0, 0, 0, time.UTC)
+	createdAt = &types.Timestamp{Seconds: goldenNow.Add(-72 * time.Hour).Unix()}
+	deletedAt = &types.Timestamp{Seconds: goldenNow.Add(-2 * time.Hour).Unix()}
+	expiresAt = &types.Timestamp{Seconds: goldenNow.Add(14 * 24 * time.Hour).Unix()}
+)
+
+// fakeIAMClient implements the parts of iam.IAMServiceClient that
+// formatters use to resolve users and roles.
+type fakeIAMClient struct {
+	iam.IAMServiceClient
+	users map[string]*iam.User
+	roles map[string]*iam.Role
+}
+
+func (c fakeIAMClient) GetUser(ctx context.Context, in *common.IDOptions, opts ...grpc.CallOption) (*iam.User, error) {
+	if u, found := c.users[in.GetId()]; found {
+		return u, nil
+	}
+	return nil, fmt.Errorf("user %q not found", in.GetId())
+}
+
+func (c fakeIAMClient) GetRole(ctx context.Context, in *common.IDOptions, opts ...grpc.CallOption) (*iam.Role, error) {
+	if r, found := c.roles[in.GetId()]; found {
+		return r, nil
+	}
+	return nil, fmt.Errorf("role %q not found", in.GetId())
+}
+
+// goldenFixture renders a formatter for a specific set of input data.
+type goldenFixture struct {
+	name   string
+	render func(opts Options) (string, error)
+}
+
+func goldenFixtures() []goldenFixture {
+	ctx := context.Background()
+	user := &iam.User{
+		Id:          "u1",
+		Email:       "alice@example.com",
+		Name:        "Alice",
+		CreatedAt:   createdAt,
+		LastLoginAt: deletedAt,
+		LastIp:      "10.0.0.1",
+	}
+	iamc := fakeIAMClient{
+		users: map[string]*iam.User{user.GetId(): user},
+		roles: map[string]*iam.Role{
+			"r1": {Id: "r1", Name: "Deployment viewer", Permissions: []string{"data.deployment.list", "data.deployment.get"}},
+		},
+	}
+	servers := &data.Deployment_ServersSpec{
+		Coordinators:          3,
+		CoordinatorMemorySize: 4,
+		Dbservers:             3,
+		DbserverMemorySize:    8,
+		DbserverDiskSize:      32,
+	}
+	deployment := &data.Deployment{
+		Id:            "d1",
+		Url:           "/Organization/o1/Project/p1/Deployment/d1",
+		Name:          "production",
+		Description:   "Main database",
+		RegionId:      "gcp-europe-west4",
+		Version:       "3.6.4",
+		IpwhitelistId: "w1",
+		CreatedAt:     createdAt,
+		Expiration:    &data.Deployment_Expiration{ExpiresAt: expiresAt},
+		Servers:       servers,
+		Model:         &data.Deployment_ModelSpec{Model: "oneshard", NodeSizeId: "a8", NodeCount: 3, NodeDiskSize: 32},
+		Status: &data.Deployment_Status{
+			Endpoint:       "https://d1.example.com:8529",
+			Created:        true,
+			Ready:          true,
+			Bootstrapped:   true,
+			BootstrappedAt: createdAt,
+		},
+	}
+	flexible := &data.Deployment{
+		Id:        "d2",
+		Name:      "staging",
+		RegionId:  "aws-us-east-2",
+		Version:   "3.7.0",
+		IsPaused:  true,
+		CreatedAt: deletedAt,
+		Servers:   servers,
+		Model:     &data.Deployment_ModelSpec{Model: data.ModelFlexible},
+	}
+	creds := &data.DeploymentCredentials{Username: "root", Password: "secret"}
+	bck := &backup.Backup{
+		Id:             "b1",
+		Url:            "/Organization/o1/Project/p1/Deployment/d1/Backup/b1",
+		Name:           "nightly",
+		Description:    "Nightly backup",
+		DeploymentId:   "d1",
+		BackupPolicyId: "bp1",
+		Upload:         true,
+		CreatedAt:      createdAt,
+		AutoDeletedAt:  expiresAt,
+		DeploymentInfo: &backup.Backup_DeploymentInfo{Version: "3.6.4", Servers: servers},
+		Status: &backup.Backup_Status{
+			State:        "Ready",
+			Available:    true,
+			Dbservers:    3,
+			UploadStatus: &backup.Backup_UploadStatus{Uploaded: true, UploadedAt: deletedAt},
+		},
+	}
+	downloadedBackup := &backup.Backup{
+		Id:           "b1",
+		Name:         "nightly",
+		DeploymentId: "d1",
+		Download:     &backup.Backup_DownloadSpec{Revision: 2, LastUpdatedAt: deletedAt},
+		Status: &backup.Backup_Status{
+			State:          "Ready",
+			Progress:       "100%",
+			Available:      true,
+			DownloadStatus: &backup.Backup_DownloadStatus{Revision: 2, Downloaded: true, DownloadedAt: deletedAt},
+		},
+	}
+	backupPolicy := &backup.BackupPolicy{
+		Id:           "bp1",
+		Url:          "/Organization/o1/Project/p1/Deployment/d1/BackupPolicy/bp1",
+		Name:         "nightly",
+		Description:  "Nightly backups",
+		DeploymentId: "d1",
+		CreatedAt:    createdAt,
+		Schedule: &backup.BackupPolicy_Schedule{
+			ScheduleType: "Daily",
+			DailySchedule: &backup.BackupPolicy_DailySchedule{
+				Monday:     true,
+				Friday:     true,
+				ScheduleAt: &backup.TimeOfDay{Hours: 2, Minutes: 30},
+			},
+		},
+		Upload:            true,
+		RetentionPeriod:   &types.Duration{Seconds: int64(7 * 24 * time.Hour / time.Second)},
+		EmailNotification: "FailureOnly",
+		Status:            &backup.BackupPolicy_Status{NextBackup: expiresAt, Message: "Scheduled"},
+	}
+	backupPolicies := []*backup.BackupPolicy{
+		backupPolicy,
+		{Id: "bp2", Name: "hourly", DeploymentId: "d1", IsPaused: true, CreatedAt: createdAt, Schedule: &backup.BackupPolicy_Schedule{
+			ScheduleType:   "Hourly",
+			HourlySchedule: &backup.BackupPolicy_HourlySchedule{ScheduleEveryIntervalHours: 6},
+		}},
+		{Id: "bp3", Name: "monthly", DeploymentId: "d1", CreatedAt: createdAt, Schedule: &backup.BackupPolicy_Schedule{
+			ScheduleType:    "Monthly",
+			MonthlySchedule: &backup.BackupPolicy_MonthlySchedule{DayOfMonth: 1, ScheduleAt: &backup.TimeOfDay{Hours: 23, TimeZone: "CET"}},
+		}},
+	}
+	apiKey := &iam.APIKey{Id: "k1", Url: "/ApiKey/k1", UserId: "u1", OrganizationId: "o1", IsReadonly: true, CreatedAt: createdAt, ExpiresAt: expiresAt}
+	caCert := &crypto.CACertificate{
+		Id:          "c1",
+		Url:         "/Organization/o1/Project/p1/CACertificate/c1",
+		Name:        "default",
+		Description: "Default certificate",
+		ProjectId:   "p1",
+		Lifetime:    &types.Duration{Seconds: int64(365 * 24 * time.Hour / time.Second)},
+		CreatedAt:   createdAt,
+		ExpiresAt:   expiresAt,
+		IsDefault:   true,
+	}
+	cpuSizes := []*data.CPUSize{{Id: "standard", Name: "Standard"}, {Id: "high", Name: "High"}}
+	exampleDataset := &example.ExampleDataset{Id: "e1", Url: "/ExampleDataset/e1", Name: "Flights", Description: "Flight data", Guide: "# Flights", CreatedAt: createdAt}
+	installation := &example.ExampleDatasetInstallation{
+		Id:               "i1",
+		Url:              "/ExampleDatasetInstallation/i1",
+		DeploymentId:     "d1",
+		ExampledatasetId: "e1",
+		CreatedAt:        createdAt,
+		Status:           &example.ExampleDatasetInstallation_Status{DatabaseName: "flights", State: "Ready", IsAvailable: true},
+	}
+	group := &iam.Group{Id: "g1", OrganizationId: "o1", Name: "Operators", Description: "On-call team", CreatedAt: createdAt, Url: "/Organization/o1/Group/g1"}
+	ipWhitelist := &security.IPWhitelist{Id: "w1", Url: "/IPWhitelist/w1", Name: "office", Description: "Office network", ProjectId: "p1", CidrRanges: []string{"10.0.0.0/8", "192.168.1.0/24"}, CreatedAt: createdAt}
+	nodeSizes := []*data.NodeSize{
+		{Id: "a4", Name: "A4", MemorySize: 4, MinDiskSize: 8, MaxDiskSize: 128, CpuSize: "standard"},
+		{Id: "a8", Name: "A8", MemorySize: 8, MinDiskSize: 16, MaxDiskSize: 256, CpuSize: "high"},
+	}
+	organization := &rm.Organization{Id: "o1", Url: "/Organization/o1", Name: "Acme", Description: "Acme Inc.", CreatedAt: createdAt, Tier: &rm.Tier{Id: "professional", Name: "Professional"}}
+	invite := &rm.OrganizationInvite{Id: "inv1", Url: "/Organization/o1/OrganizationInvite/inv1", OrganizationId: "o1", Email: "bob@example.com", CreatedAt: createdAt, AcceptedAt: deletedAt, UserId: "u1", OrganizationName: "Acme", CreatedByName: "Alice"}
+	member := &rm.Member{UserId: "u1", Owner: true}
+	policy := &iam.Policy{ResourceUrl: "/Organization/o1", Bindings: []*iam.RoleBinding{
+		{Id: "rb1", MemberId: "user-u1", RoleId: "r1"},
+		{Id: "rb2", MemberId: "group-g1", RoleId: "r2", DeleteNotAllowed: true},
+	}}
+	project := &rm.Project{Id: "p1", Url: "/Organization/o1/Project/p1", Name: "Shop", Description: "Web shop", OrganizationId: "o1", CreatedAt: createdAt}
+	provider := &platform.Provider{Id: "gcp", Name: "Google Cloud Platform"}
+	region := &platform.Region{Id: "gcp-europe-west4", ProviderId: "gcp", Location: "Netherlands", Available: true}
+	role := &iam.Role{Id: "r1", OrganizationId: "o1", Name: "Deployment viewer", Description: "View deployments", Permissions: []string{"data.deployment.list", "data.deployment.get"}, CreatedAt: createdAt, Url: "/Organization/o1/Role/r1"}
+	limits := &data.ServersSpecLimits{
+		Coordinators:          &data.ServersSpecLimits_Limits{Min: 2, Max: 8},
+		CoordinatorMemorySize: &data.ServersSpecLimits_Limits{Min: 2, Max: 16},
+		Dbservers:             &data.ServersSpecLimits_Limits{Min: 3, Max: 8},
+		DbserverMemorySize:    &data.ServersSpecLimits_Limits{Min: 4, Max: 64},
+		DbserverDiskSize:      &data.ServersSpecLimits_Limits{Min: 8, Max: 512},
+	}
+	serverStatus := []*data.Deployment_ServerStatus{
+		{Id: "crdn-1", Type: "Coordinator", Description: "Coordinator 1", CreatedAt: createdAt, Ready: true, MemberOfCluster: true, Ok: true, Version: "3.6.4", LastStartedAt: deletedAt},
+		{Id: "prmr-1", Type: "DBServer", Description: "DBServer 1", CreatedAt: createdAt, Failed: true, Creating: true, Version: "3.6.4"},
+	}
+	versions := []*data.Version{{Version: "3.5.5"}, {Version: "3.6.4"}, {Version: "3.7.0"}}
+
+	return []goldenFixture{
+		{"apikey", func(opts Options) (string, error) { return APIKey(apiKey, opts) }},
+		{"apikey-sparse", func(opts Options) (string, error) { return APIKey(&iam.APIKey{}, opts) }},
+		{"apikey-list", func(opts Options) (string, error) { return APIKeyList([]*iam.APIKey{apiKey}, opts) }},
+		{"apikey-list-sparse", func(opts Options) (string, error) { return APIKeyList([]*iam.APIKey{{}}, opts) }},
+		{"apikey-secret", func(opts Options) (string, error) {
+			return APIKeySecret(&iam.APIKeySecret{Id: "k1", Secret: "s3cr3t"}, opts)
+		}},
+		{"apikey-secret-sparse", func(opts Options) (string, error) { return APIKeySecret(&iam.APIKeySecret{}, opts) }},
+		{"backup", func(opts Options) (string, error) { return Backup(bck, opts) }},
+		{"backup-sparse", func(opts Options) (string, error) { return Backup(&backup.Backup{}, opts) }},
+		{"backup-list", func(opts Options) (string, error) { return BackupList([]*backup.Backup{bck}, opts) }},
+		{"backup-list-sparse", func(opts Options) (string, error) { return BackupList([]*backup.Backup{{}}, opts) }},
+		{"backup-download", func(opts Options) (string, error) { return BackupDownload(downloadedBackup, opts) }},
+		{"backup-download-sparse", func(opts Options) (string, error) { return BackupDownload(&backup.Backup{}, opts) }},
+		{"backup-prune-plan", func(opts Options) (string, error) {
+			return BackupPrunePlan([]BackupPruneItem{
+				{Backup: bck, Action: "keep", Reasons: []string{"last", "daily"}},
+				{Backup: &backup.Backup{Id: "b0", Name: "old", CreatedAt: createdAt}, Action: "delete"},
+			}, opts)
+		}},
+		{"backup-prune-plan-sparse", func(opts Options) (string, error) {
+			return BackupPrunePlan([]BackupPruneItem{{Backup: &backup.Backup{}}}, opts)
+		}},
+		{"backup-policy", func(opts Options) (string, error) { return BackupPolicy(backupPolicy, opts) }},
+		{"backup-policy-sparse", func(opts Options) (string, error) { return BackupPolicy(&backup.BackupPolicy{}, opts) }},
+		{"backup-policy-list", func(opts Options) (string, error) { return BackupPolicyList(backupPolicies, opts) }},
+		{"backup-policy-list-sparse", func(opts Options) (string, error) { return BackupPolicyList([]*backup.BackupPolicy{{}}, opts) }},
+		{"cacertificate", func(opts Options) (string, error) { return CACertificate(caCert, opts) }},
+		{"cacertificate-sparse", func(opts Options) (string, error) { return CACertificate(&crypto.CACertificate{}, opts) }},
+		{"cacertificate-list", func(opts Options) (string, error) { return CACertificateList([]*crypto.CACertificate{caCert}, opts) }},
+		{"cacertificate-list-sparse", func(opts Options) (string, error) { return CACertificateList([]*crypto.CACertificate{{}}, opts) }},
+		{"cli-version", func(opts Options) (string, error) { return CLIVersion("v1.2.3", opts) }},
+		{"cpu-size-list", func(opts Options) (string, error) { return CPUSizeList(cpuSizes, opts) }},
+		{"cpu-size-list-sparse", func(opts Options) (string, error) { return CPUSizeList([]*data.CPUSize{{}}, opts) }},
+		{"deployment", func(opts Options) (string, error) { return Deployment(deployment, creds, opts, false) }},
+		{"deployment-root-password", func(opts Options) (string, error) { return Deployment(deployment, creds, opts, true) }},
+		{"deployment-flexible", func(opts Options) (string, error) { return Deployment(flexible, nil, opts, false) }},
+		{"deployment-sparse", func(opts Options) (string, error) { return Deployment(&data.Deployment{}, nil, opts, true) }},
+		{"deployment-list", func(opts Options) (string, error) {
+			return DeploymentList([]*data.Deployment{deployment, flexible}, opts)
+		}},
+		{"deployment-list-sparse", func(opts Options) (string, error) { return DeploymentList([]*data.Deployment{{}}, opts) }},
+		{"example", func(opts Options) (string, error) { return Example(exampleDataset, opts) }},
+		{"example-sparse", func(opts Options) (string, error) { return Example(&example.ExampleDataset{}, opts) }},
+		{"example-list", func(opts Options) (string, error) {
+			return ExampleList([]*example.ExampleDataset{exampleDataset}, opts)
+		}},
+		{"example-list-sparse", func(opts Options) (string, error) { return ExampleList([]*example.ExampleDataset{{}}, opts) }},
+		{"example-installation", func(opts Options) (string, error) { return ExampleDatasetInstallation(installation, opts) }},
+		{"example-installation-sparse", func(opts Options) (string, error) {
+			return ExampleDatasetInstallation(&example.ExampleDatasetInstallation{}, opts)
+		}},
+		{"example-installation-list", func(opts Options) (string, error) {
+			return ExampleDatasetInstallationList([]*example.ExampleDatasetInstallation{installation}, opts)
+		}},
+		{"example-installation-list-sparse", func(opts Options) (string, error) {
+			return ExampleDatasetInstallationList([]*example.ExampleDatasetInstallation{{}}, opts)
+		}},
+		{"group", func(opts Options) (string, error) { return Group(group, opts) }},
+		{"group-sparse", func(opts Options) (string, error) { return Group(&iam.Group{}, opts) }},
+		{"group-list", func(opts Options) (string, error) { return GroupList([]*iam.Group{group}, opts) }},
+		{"group-list-sparse", func(opts Options) (string, error) { return GroupList([]*iam.Group{{}}, opts) }},
+		{"group-member", func(opts Options) (string, error) { return GroupMember(ctx, "u1", iamc, opts) }},
+		{"group-member-unknown", func(opts Options) (string, error) { return GroupMember(ctx, "unknown", iamc, opts) }},
+		{"group-member-list", func(opts Options) (string, error) { return GroupMemberList(ctx, []string{"u1", "unknown"}, iamc, opts) }},
+		{"group-member-list-sparse", func(opts Options) (string, error) { return GroupMemberList(ctx, nil, iamc, opts) }},
+		{"ipwhitelist", func(opts Options) (string, error) { return IPWhitelist(ipWhitelist, opts) }},
+		{"ipwhitelist-sparse", func(opts Options) (string, error) { return IPWhitelist(&security.IPWhitelist{}, opts) }},
+		{"ipwhitelist-list", func(opts Options) (string, error) { return IPWhitelistList([]*security.IPWhitelist{ipWhitelist}, opts) }},
+		{"ipwhitelist-list-sparse", func(opts Options) (string, error) { return IPWhitelistList([]*security.IPWhitelist{{}}, opts) }},
+		{"node-size-list", func(opts Options) (string, error) { return NodeSizeList(nodeSizes, cpuSizes, opts) }},
+		{"node-size-list-sparse", func(opts Options) (string, error) { return NodeSizeList([]*data.NodeSize{{}}, nil, opts) }},
+		{"organization", func(opts Options) (string, error) { return Organization(organization, opts) }},
+		{"organization-sparse", func(opts Options) (string, error) { return Organization(&rm.Organization{}, opts) }},
+		{"organization-list", func(opts Options) (string, error) { return OrganizationList([]*rm.Organization{organization}, opts) }},
+		{"organization-list-sparse", func(opts Options) (string, error) { return OrganizationList([]*rm.Organization{{}}, opts) }},
+		{"organization-invite", func(opts Options) (string, error) { return OrganizationInvite(ctx, invite, iamc, opts) }},
+		{"organization-invite-sparse", func(opts Options) (string, error) {
+			return OrganizationInvite(ctx, &rm.OrganizationInvite{}, iamc, opts)
+		}},
+		{"organization-invite-list", func(opts Options) (string, error) {
+			return OrganizationInviteList(ctx, []*rm.OrganizationInvite{invite}, iamc, opts)
+		}},
+		{"organization-invite-list-sparse", func(opts Options) (string, error) {
+			return OrganizationInviteList(ctx, []*rm.OrganizationInvite{{}}, iamc, opts)
+		}},
+		{"organization-member", func(opts Options) (string, error) { return OrganizationMember(ctx, member, iamc, opts) }},
+		{"organization-member-sparse", func(opts Options) (string, error) { return OrganizationMember(ctx, &rm.Member{}, iamc, opts) }},
+		{"organization-member-list", func(opts Options) (string, error) {
+			return OrganizationMemberList(ctx, []*rm.Member{member, {UserId: "unknown"}}, iamc, opts)
+		}},
+		{"organization-member-list-sparse", func(opts Options) (string, error) { return OrganizationMemberList(ctx, []*rm.Member{{}}, iamc, opts) }},
+		{"permission-list", func(opts Options) (string, error) {
+			return PermissionList([]string{"data.deployment.get", "data.deployment.list"}, opts)
+		}},
+		{"permission-list-sparse", func(opts Options) (string, error) { return PermissionList(nil, opts) }},
+		{"policy", func(opts Options) (string, error) { return Policy(ctx, policy, iamc, opts) }},
+		{"policy-sparse", func(opts Options) (string, error) { return Policy(ctx, &iam.Policy{}, iamc, opts) }},
+		{"project", func(opts Options) (string, error) { return Project(project, opts) }},
+		{"project-sparse", func(opts Options) (string, error) { return Project(&rm.Project{}, opts) }},
+		{"project-list", func(opts Options) (string, error) { return ProjectList([]*rm.Project{project}, opts) }},
+		{"project-list-sparse", func(opts Options) (string, error) { return ProjectList([]*rm.Project{{}}, opts) }},
+		{"provider", func(opts Options) (string, error) { return Provider(provider, opts) }},
+		{"provider-sparse", func(opts Options) (string, error) { return Provider(&platform.Provider{}, opts) }},
+		{"provider-list", func(opts Options) (string, error) { return ProviderList([]*platform.Provider{provider}, opts) }},
+		{"provider-list-sparse", func(opts Options) (string, error) { return ProviderList([]*platform.Provider{{}}, opts) }},
+		{"region", func(opts Options) (string, error) { return Region(region, opts) }},
+		{"region-sparse", func(opts Options) (string, error) { return Region(&platform.Region{}, opts) }},
+		{"region-list", func(opts Options) (string, error) { return RegionList([]*platform.Region{region}, opts) }},
+		{"region-list-sparse", func(opts Options) (string, error) { return RegionList([]*platform.Region{{}}, opts) }},
+		{"role", func(opts Options) (string, error) { return Role(role, opts) }},
+		{"role-sparse", func(opts Options) (string, error) { return Role(&iam.Role{}, opts) }},
+		{"role-list", func(opts Options) (string, error) { return RoleList([]*iam.Role{role}, opts) }},
+		{"role-list-sparse", func(opts Options) (string, error) { return RoleList([]*iam.Role{{}}, opts) }},
+		{"servers-spec-limits", func(opts Options) (string, error) { return ServersSpecLimits(limits, opts) }},
+		{"servers-spec-limits-sparse", func(opts Options) (string, error) { return ServersSpecLimits(&data.ServersSpecLimits{}, opts) }},
+		{"server-status-list", func(opts Options) (string, error) { return ServerStatusList(serverStatus, opts) }},
+		{"server-status-list-sparse", func(opts Options) (string, error) {
+			return ServerStatusList([]*data.Deployment_ServerStatus{{}}, opts)
+		}},
+		{"user", func(opts Options) (string, error) { return User(user, opts) }},
+		{"user-sparse", func(opts Options) (string, error) { return User(&iam.User{}, opts) }},
+		{"user-list", func(opts Options) (string, error) { return UserList([]*iam.User{user}, opts) }},
+		{"user-list-sparse", func(opts Options) (string, error) { return UserList([]*iam.User{{}}, opts) }},
+		{"version", func(opts Options) (string, error) { return Version(versions[1], opts) }},
+		{"version-sparse", func(opts Options) (string, error) { return Version(&data.Version{}, opts) }},
+		{"version-list", func(opts Options) (string, error) { return VersionList(versions, versions[1], opts) }},
+		{"version-list-sparse", func(opts Options) (string, error) { return VersionList([]*data.Version{{}}, nil, opts) }},
+		{"wait-result-list", func(opts Options) (string, error) {
+			return WaitResultList([]WaitResult{
+				{ID: "d1", Name: "production", Conditions: []string{"ready", "servers-ok"}, Reached: true, Duration: 95500 * time.Millisecond, Status: "Deployment is ready"},
+				{ID: "d2", Name: "staging", Conditions: []string{"ready", "servers-ok"}, Duration: 20 * time.Minute, Status: "Bootstrapping"},
+			}, opts)
+		}},
+		{"wait-result-list-sparse", func(opts Options) (string, error) { return WaitResultList([]WaitResult{{}}, opts) }},
+	}
+}
+
+// TestGolden renders every formatter in table and json format and
+// compares the result with the files in testdata/golden.
+// Run `go test ./pkg/format -update` to regenerate them.
+func TestGolden(t *testing.T) {
+	defer func(orig func() time.Time) { now = orig }(now)
+	now = func() time.Time { return goldenNow }
+
+	for _, fixture := range goldenFixtures() {
+		for _, format := range []string{formatTable, formatJSON} {
+			fixture, format := fixture, format
+			t.Run(fixture.name+"/"+format, func(t *testing.T) {
+				rendered, err := fixture.render(Options{Format: format})
+				if err != nil {
+					t.Fatalf("Failed to render: %v", err)
+				}
+				actual := rendered + "\n"
+				path := filepath.Join("testdata", "golden", fixture.name+"."+format)
+				if *update {
+					if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+						t.Fatalf("Failed to create golden directory: %v", err)
+					}
+					if err := ioutil.WriteFile(path, []byte(actual), 0644); err != nil {
+						t.Fatalf("Failed to write golden file: %v", err)
+					}
+					return
+				}
+				expected, err := ioutil.ReadFile(path)
+				if err != nil {
+					t.Fatalf("Failed to read golden file (run with -update to create it): %v", err)
+				}
+				if actual != string(expected) {
+					t.Errorf("Output does not match %s\n--- expected\n%s\n--- actual\n%s", path, expected, actual)
+				}
+			})
+		}
+	}
+}
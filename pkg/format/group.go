@@ -27,7 +27,7 @@ import (
 )
 
 // Group returns a single group formatted for humans.
-func Group(x *iam.Group, opts Options) string {
+func Group(x *iam.Group, opts Options) (string, error) {
 	return formatObject(opts,
 		kv{"id", x.GetId()},
 		kv{"name", x.GetName()},
@@ -39,7 +39,7 @@ func Group(x *iam.Group, opts Options) string {
 }
 
 // GroupList returns a list of groups formatted for humans.
-func GroupList(list []*iam.Group, opts Options) string {
+func GroupList(list []*iam.Group, opts Options) (string, error) {
 	return formatList(opts, list, func(i int) []kv {
 		x := list[i]
 		return []kv{
@@ -30,7 +30,7 @@ import (
 )
 
 // GroupMember returns a single organization member formatted for humans.
-func GroupMember(ctx context.Context, x string, iamc iam.IAMServiceClient, opts Options) string {
+func GroupMember(ctx context.Context, x string, iamc iam.IAMServiceClient, opts Options) (string, error) {
 	userName := "?"
 	userEmail := "?"
 	user, err := iamc.GetUser(ctx, &common.IDOptions{Id: x})
@@ -46,7 +46,7 @@ func GroupMember(ctx context.Context, x string, iamc iam.IAMServiceClient, opts
 }
 
 // GroupMemberList returns a list of group members formatted for humans.
-func GroupMemberList(ctx context.Context, list []string, iamc iam.IAMServiceClient, opts Options) string {
+func GroupMemberList(ctx context.Context, list []string, iamc iam.IAMServiceClient, opts Options) (string, error) {
 	return formatList(opts, list, func(i int) []kv {
 		x := list[i]
 		userName := "?"
@@ -29,7 +29,7 @@ import (
 )
 
 // IPWhitelist returns a single IP whitelist formatted for humans.
-func IPWhitelist(x *security.IPWhitelist, opts Options) string {
+func IPWhitelist(x *security.IPWhitelist, opts Options) (string, error) {
 	return formatObject(opts,
 		kv{"id", x.GetId()},
 		kv{"name", x.GetName()},
@@ -41,7 +41,7 @@ func IPWhitelist(x *security.IPWhitelist, opts Options) string {
 }
 
 // IPWhitelistList returns a list of IP whitelists formatted for humans.
-func IPWhitelistList(list []*security.IPWhitelist, opts Options) string {
+func IPWhitelistList(list []*security.IPWhitelist, opts Options) (string, error) {
 	return formatList(opts, list, func(i int) []kv {
 		x := list[i]
 		return []kv{
@@ -31,7 +31,7 @@ import (
 )
 
 // NodeSizeList returns a list of node sizes.
-func NodeSizeList(list []*data.NodeSize, cpuList []*data.CPUSize, opts Options) string {
+func NodeSizeList(list []*data.NodeSize, cpuList []*data.CPUSize, opts Options) (string, error) {
 	return formatList(opts, list, func(i int) []kv {
 		x := list[i]
 		return []kv{
@@ -22,15 +22,111 @@
 
 package format
 
+import (
+	"fmt"
+	"strings"
+)
+
 const (
 	// DefaultFormat specifies default value for Options.Format
 	DefaultFormat = formatTable
 
 	formatTable = "table"
 	formatJSON  = "json"
+	formatYAML  = "yaml"
+	formatCSV   = "csv"
+	formatTSV   = "tsv"
+
+	// Formats that take an argument (e.g. jsonpath={.id})
+	formatGoTemplate = "go-template"
+	formatTemplate   = "template"
+	formatJSONPath   = "jsonpath"
 )
 
+// Formats returns the names of all supported formats.
+func Formats() []string {
+	return []string{formatTable, formatJSON, formatYAML, formatCSV, formatTSV,
+		formatGoTemplate + "=...", formatTemplate + "=...", formatJSONPath + "=..."}
+}
+
 // Options that control the formatter.
 type Options struct {
 	Format string
+	// If set, only these columns (fields) are shown, in this order.
+	Columns []string
+	// If set, no header is shown for lists.
+	NoHeaders bool
+	// If set, lists are sorted by the value of this column.
+	SortBy string
+	// If set, lists only contain items that match this filter expression.
+	Filter string
+	// If set, output is formatted for watch mode (repeated polls).
+	Watch *Watch
+}
+
+// Validate returns an error if the options are invalid.
+// The error mentions the (command line) option that is invalid.
+func (o Options) Validate() error {
+	if err := o.validateFormat(); err != nil {
+		return fmt.Errorf("Invalid --format: %s", err)
+	}
+	for _, c := range o.Columns {
+		if err := validateColumnName(c); err != nil {
+			return fmt.Errorf("Invalid --columns: %s", err)
+		}
+	}
+	if o.SortBy != "" {
+		if err := validateColumnName(o.SortBy); err != nil {
+			return fmt.Errorf("Invalid --sort-by: %s", err)
+		}
+	}
+	if o.Filter != "" {
+		if _, err := parseFilter(o.Filter); err != nil {
+			return fmt.Errorf("Invalid --filter: %s", err)
+		}
+	}
+	return nil
+}
+
+// validateFormat returns an error if the format (including its argument) is invalid.
+func (o Options) validateFormat() error {
+	switch o.name() {
+	case formatTable, formatJSON, formatYAML, formatCSV, formatTSV:
+		if o.Format == o.name() {
+			return nil
+		}
+	case formatGoTemplate, formatTemplate:
+		if _, err := parseGoTemplate(o.argument()); err != nil {
+			return fmt.Errorf("invalid template: %s", err)
+		}
+		return nil
+	case formatJSONPath:
+		if _, err := evalJSONPath(o.argument(), nil); err != nil {
+			return err
+		}
+		return nil
+	}
+	return fmt.Errorf("unknown format '%s', expected one of: %s", o.Format, strings.Join(Formats(), ", "))
+}
+
+// name returns the name of the format, without its argument.
+func (o Options) name() string {
+	if idx := strings.Index(o.Format, "="); idx >= 0 {
+		return o.Format[:idx]
+	}
+	return o.Format
+}
+
+// argument returns the argument of the format (the part after '=').
+func (o Options) argument() string {
+	if idx := strings.Index(o.Format, "="); idx >= 0 {
+		return o.Format[idx+1:]
+	}
+	return ""
+}
+
+// isHumanReadable returns true if values must be formatted for humans
+// instead of for machines.
+func (o Options) isHumanReadable() bool {
+	return o.Format == formatTable
 }
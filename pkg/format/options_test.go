@@ -0,0 +1,63 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Ewout Prangsma
+//
+
+package format
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestOptionsValidate(t *testing.T) {
+	tests := []struct {
+		name  string
+		opts  Options
+		error string
+	}{
+		{"table", Options{Format: formatTable}, ""},
+		{"yaml", Options{Format: formatYAML}, ""},
+		{"csv", Options{Format: formatCSV}, ""},
+		{"tsv", Options{Format: formatTSV}, ""},
+		{"unknown format", Options{Format: "xml"}, "Invalid --format: unknown format 'xml'"},
+		{"argument for plain format", Options{Format: "json=x"}, "Invalid --format: unknown format"},
+		{"template", Options{Format: "template={{.id}}"}, ""},
+		{"invalid template", Options{Format: "go-template={{.id"}, "Invalid --format: invalid template"},
+		{"jsonpath", Options{Format: "jsonpath={.id}"}, ""},
+		{"invalid jsonpath", Options{Format: "jsonpath={.id"}, "Invalid --format: unclosed expression"},
+		{"columns", Options{Format: formatTable, Columns: []string{"id", "created-at"}}, ""},
+		{"empty column", Options{Format: formatTable, Columns: []string{"id", ""}}, "Invalid --columns: empty column name"},
+		{"sort-by", Options{Format: formatTable, SortBy: "created-at"}, ""},
+		{"invalid sort-by", Options{Format: formatTable, SortBy: "id,name"}, "Invalid --sort-by: 'id,name'"},
+		{"filter", Options{Format: formatTable, Filter: "paused"}, ""},
+		{"invalid filter", Options{Format: formatTable, Filter: "paused &&"}, "Invalid --filter: unexpected end"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.opts.Validate()
+			if test.error == "" && err != nil {
+				t.Errorf("Expected options to be valid, got %v", err)
+			} else if test.error != "" && (err == nil || !strings.HasPrefix(err.Error(), test.error)) {
+				t.Errorf("Expected error starting with %q, got %v", test.error, err)
+			}
+		})
+	}
+}
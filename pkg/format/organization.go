@@ -27,7 +27,7 @@ import (
 )
 
 // Organization returns a single organization formatted for humans.
-func Organization(x *rm.Organization, opts Options) string {
+func Organization(x *rm.Organization, opts Options) (string, error) {
 	return formatObject(opts,
 		kv{"id", x.GetId()},
 		kv{"name", x.GetName()},
@@ -39,7 +39,7 @@ func Organization(x *rm.Organization, opts Options) string {
 }
 
 // OrganizationList returns a list of organizations formatted for humans.
-func OrganizationList(list []*rm.Organization, opts Options) string {
+func OrganizationList(list []*rm.Organization, opts Options) (string, error) {
 	return formatList(opts, list, func(i int) []kv {
 		x := list[i]
 		return []kv{
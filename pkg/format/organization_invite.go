@@ -31,7 +31,7 @@ import (
 )
 
 // OrganizationInvite returns a single organization member formatted for humans.
-func OrganizationInvite(ctx context.Context, x *rm.OrganizationInvite, iamc iam.IAMServiceClient, opts Options) string {
+func OrganizationInvite(ctx context.Context, x *rm.OrganizationInvite, iamc iam.IAMServiceClient, opts Options) (string, error) {
 	userName := "-"
 	if x.GetUserId() != "" {
 		if user, err := iamc.GetUser(ctx, &common.IDOptions{Id: x.GetUserId()}); err == nil {
@@ -52,7 +52,7 @@ func OrganizationInvite(ctx context.Context, x *rm.OrganizationInvite, iamc iam.
 }
 
 // OrganizationInviteList returns a list of organization members formatted for humans.
-func OrganizationInviteList(ctx context.Context, list []*rm.OrganizationInvite, iamc iam.IAMServiceClient, opts Options) string {
+func OrganizationInviteList(ctx context.Context, list []*rm.OrganizationInvite, iamc iam.IAMServiceClient, opts Options) (string, error) {
 	return formatList(opts, list, func(i int) []kv {
 		x := list[i]
 		userName := "-"
@@ -31,11 +31,10 @@ import (
 )
 
 // OrganizationMember returns a single organization member formatted for humans.
-func OrganizationMember(ctx context.Context, x *rm.Member, iamc iam.IAMServiceClient, opts Options) string {
+func OrganizationMember(ctx context.Context, x *rm.Member, iamc iam.IAMServiceClient, opts Options) (string, error) {
 	userName := "?"
 	userEmail := "?"
-	userCreatedAt := "?"
-	userLastLoginAt := "?"
+	var userCreatedAt, userLastLoginAt interface{} = "?", "?"
 	userLastIP := "?"
 	user, err := iamc.GetUser(ctx, &common.IDOptions{Id: x.GetUserId()})
 	if err == nil {
@@ -57,13 +56,12 @@ func OrganizationMember(ctx context.Context, x *rm.Member, iamc iam.IAMServiceCl
 }
 
 // OrganizationMemberList returns a list of organization members formatted for humans.
-func OrganizationMemberList(ctx context.Context, list []*rm.Member, iamc iam.IAMServiceClient, opts Options) string {
+func OrganizationMemberList(ctx context.Context, list []*rm.Member, iamc iam.IAMServiceClient, opts Options) (string, error) {
 	return formatList(opts, list, func(i int) []kv {
 		x := list[i]
 		userName := "?"
 		userEmail := "?"
-		userCreatedAt := "?"
-		userLastLoginAt := "?"
+		var userCreatedAt, userLastLoginAt interface{} = "?", "?"
 		userLastIP := "?"
 		user, err := iamc.GetUser(ctx, &common.IDOptions{Id: x.GetUserId()})
 		if err == nil {
@@ -36,7 +36,7 @@ type permissionRow struct {
 }
 
 // PermissionList returns a list of permissions formatted for humans.
-func PermissionList(list []string, opts Options) string {
+func PermissionList(list []string, opts Options) (string, error) {
 	var rows []permissionRow
 	for _, p := range list {
 		api, kind, verb, err := auth.ParsePermission(p)
@@ -32,7 +32,7 @@ import (
 )
 
 // Policy returns a single policy formatted for humans.
-func Policy(ctx context.Context, x *iam.Policy, iamc iam.IAMServiceClient, opts Options) string {
+func Policy(ctx context.Context, x *iam.Policy, iamc iam.IAMServiceClient, opts Options) (string, error) {
 	list := x.GetBindings()
 	return formatList(opts, list, func(i int) []kv {
 		x := list[i]
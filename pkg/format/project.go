@@ -27,7 +27,7 @@ import (
 )
 
 // Project returns a single project formatted for humans.
-func Project(x *rm.Project, opts Options) string {
+func Project(x *rm.Project, opts Options) (string, error) {
 	return formatObject(opts,
 		kv{"id", x.GetId()},
 		kv{"name", x.GetName()},
@@ -39,7 +39,7 @@ func Project(x *rm.Project, opts Options) string {
 }
 
 // ProjectList returns a list of projects formatted for humans.
-func ProjectList(list []*rm.Project, opts Options) string {
+func ProjectList(list []*rm.Project, opts Options) (string, error) {
 	return formatList(opts, list, func(i int) []kv {
 		x := list[i]
 		return []kv{
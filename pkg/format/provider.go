@@ -27,7 +27,7 @@ import (
 )
 
 // Provider returns a single provider formatted for humans.
-func Provider(x *platform.Provider, opts Options) string {
+func Provider(x *platform.Provider, opts Options) (string, error) {
 	return formatObject(opts,
 		kv{"id", x.GetId()},
 		kv{"name", x.GetName()},
@@ -35,7 +35,7 @@ func Provider(x *platform.Provider, opts Options) string {
 }
 
 // ProviderList returns a list of providers formatted for humans.
-func ProviderList(list []*platform.Provider, opts Options) string {
+func ProviderList(list []*platform.Provider, opts Options) (string, error) {
 	return formatList(opts, list, func(i int) []kv {
 		x := list[i]
 		return []kv{
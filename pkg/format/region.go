@@ -27,7 +27,7 @@ import (
 )
 
 // Region returns a single region formatted for humans.
-func Region(x *platform.Region, opts Options) string {
+func Region(x *platform.Region, opts Options) (string, error) {
 	return formatObject(opts,
 		kv{"id", x.GetId()},
 		kv{"provider-id", x.GetProviderId()},
@@ -37,7 +37,7 @@ func Region(x *platform.Region, opts Options) string {
 }
 
 // RegionList returns a list of regions formatted for humans.
-func RegionList(list []*platform.Region, opts Options) string {
+func RegionList(list []*platform.Region, opts Options) (string, error) {
 	return formatList(opts, list, func(i int) []kv {
 		x := list[i]
 		return []kv{
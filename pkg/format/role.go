@@ -29,7 +29,7 @@ import (
 )
 
 // Role returns a single role formatted for humans.
-func Role(x *iam.Role, opts Options) string {
+func Role(x *iam.Role, opts Options) (string, error) {
 	return formatObject(opts,
 		kv{"id", x.GetId()},
 		kv{"name", x.GetName()},
@@ -43,7 +43,7 @@ func Role(x *iam.Role, opts Options) string {
 }
 
 // RoleList returns a list of roles formatted for humans.
-func RoleList(list []*iam.Role, opts Options) string {
+func RoleList(list []*iam.Role, opts Options) (string, error) {
 	return formatList(opts, list, func(i int) []kv {
 		x := list[i]
 		return []kv{
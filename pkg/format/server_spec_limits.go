@@ -30,7 +30,7 @@ import (
 )
 
 // ServersSpecLimits returns a single server specification limts formatted for humans.
-func ServersSpecLimits(x *data.ServersSpecLimits, opts Options) string {
+func ServersSpecLimits(x *data.ServersSpecLimits, opts Options) (string, error) {
 	return formatObject(opts,
 		kv{"coordinators", serversSpecLimitsLimits(x.GetCoordinators(), "")},
 		kv{"coordinator-memory-size", serversSpecLimitsLimits(x.GetCoordinatorMemorySize(), "GB")},
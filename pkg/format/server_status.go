@@ -27,7 +27,7 @@ import (
 )
 
 // DeploymentList returns a list of deployments formatted for humans.
-func ServerStatusList(list []*data.Deployment_ServerStatus, opts Options) string {
+func ServerStatusList(list []*data.Deployment_ServerStatus, opts Options) (string, error) {
 	return formatList(opts, list, func(i int) []kv {
 		x := list[i]
 		d := []kv{
@@ -0,0 +1,230 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Ewout Prangsma
+//
+
+package format
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// numberWithUnitPattern matches values like "3", "1.5" or "32GB"
+	numberWithUnitPattern = regexp.MustCompile(`^(-?[0-9]+(?:\.[0-9]+)?)\s*([A-Za-z]*)$`)
+	// columnNamePattern matches valid column (field) names like "id" or "created-at"
+	columnNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+	// versionPattern matches versions like "3.6.2"
+	versionPattern = regexp.MustCompile(`^v?[0-9]+(\.[0-9]+)+$`)
+	// unitMultipliers contains the multipliers of size units
+	unitMultipliers = map[string]float64{
+		"":    1,
+		"B":   1,
+		"KB":  1e3,
+		"MB":  1e6,
+		"GB":  1e9,
+		"TB":  1e12,
+		"KIB": 1 << 10,
+		"MIB": 1 << 20,
+		"GIB": 1 << 30,
+		"TIB": 1 << 40,
+	}
+)
+
+// validateColumnName returns an error if the given name cannot be the name of a column.
+func validateColumnName(name string) error {
+	if name == "" {
+		return fmt.Errorf("empty column name")
+	}
+	if !columnNamePattern.MatchString(name) {
+		return fmt.Errorf("'%s' is not a valid column name, expected a lower-case field name like created-at", name)
+	}
+	return nil
+}
+
+// selectColumns returns the fields of the given data with given keys, in
+// the order of the given keys. Missing fields have an empty value.
+func selectColumns(data []kv, columns []string) []kv {
+	result := make([]kv, 0, len(columns))
+	for _, c := range columns {
+		var value interface{} = ""
+		for _, x := range data {
+			if x.Key == c {
+				value = x.Value
+				break
+			}
+		}
+		result = append(result, kv{c, value})
+	}
+	return result
+}
+
+// sortRows sorts the given rows by the value of the given column.
+// Rows without such column are sorted first.
+func sortRows(rows [][]kv, column string) {
+	valueOf := func(data []kv) interface{} {
+		for _, x := range data {
+			if x.Key == column {
+				return x.Value
+			}
+		}
+		return nil
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		return compareValues(valueOf(rows[i]), valueOf(rows[j])) < 0
+	})
+}
+
+// compareValues compares the given values in a type aware way.
+// Times are compared as times, booleans as booleans,
+// versions (e.g. 3.6.2) part by part, numbers (with optional size unit) as numbers,
+// everything else as text.
+// Returns -1 if a < b, 0 if a == b and 1 if a > b.
+func compareValues(a, b interface{}) int {
+	if a == nil || b == nil {
+		switch {
+		case a == nil && b == nil:
+			return 0
+		case a == nil:
+			return -1
+		default:
+			return 1
+		}
+	}
+	if ta, ok := a.(timeValue); ok {
+		if tb, ok := b.(timeValue); ok {
+			return compareTimes(ta.t, tb.t)
+		}
+	}
+	if ba, ok := a.(boolValue); ok {
+		if bb, ok := b.(boolValue); ok {
+			switch {
+			case ba.b == bb.b:
+				return 0
+			case bb.b:
+				return -1
+			}
+			return 1
+		}
+	}
+	if va, ok := a.(string); ok && versionPattern.MatchString(va) {
+		if vb, ok := b.(string); ok && versionPattern.MatchString(vb) {
+			return compareVersions(va, vb)
+		}
+	}
+	if na, ok := toNumber(a); ok {
+		if nb, ok := toNumber(b); ok {
+			switch {
+			case na < nb:
+				return -1
+			case na > nb:
+				return 1
+			}
+			return 0
+		}
+	}
+	sa, sb := toString(a), toString(b)
+	if ta, err := time.Parse(time.RFC3339, sa); err == nil {
+		if tb, err := time.Parse(time.RFC3339, sb); err == nil {
+			return compareTimes(ta, tb)
+		}
+	}
+	return strings.Compare(sa, sb)
+}
+
+// compareVersions compares the given versions (e.g. 3.6.2) part by part.
+// Missing parts are considered 0.
+func compareVersions(a, b string) int {
+	pa := strings.Split(strings.TrimPrefix(a, "v"), ".")
+	pb := strings.Split(strings.TrimPrefix(b, "v"), ".")
+	for i := 0; i < len(pa) || i < len(pb); i++ {
+		var na, nb int
+		if i < len(pa) {
+			na, _ = strconv.Atoi(pa[i])
+		}
+		if i < len(pb) {
+			nb, _ = strconv.Atoi(pb[i])
+		}
+		switch {
+		case na < nb:
+			return -1
+		case na > nb:
+			return 1
+		}
+	}
+	return 0
+}
+
+// compareTimes compares the given times.
+func compareTimes(a, b time.Time) int {
+	switch {
+	case a.Before(b):
+		return -1
+	case a.After(b):
+		return 1
+	}
+	return 0
+}
+
+// toNumber tries to convert the given value to a number.
+// Strings with a size unit (e.g. 32GB) are converted to the number of bytes.
+func toNumber(v interface{}) (float64, bool) {
+	switch x := v.(type) {
+	case int:
+		return float64(x), true
+	case int32:
+		return float64(x), true
+	case int64:
+		return float64(x), true
+	case uint32:
+		return float64(x), true
+	case uint64:
+		return float64(x), true
+	case float32:
+		return float64(x), true
+	case float64:
+		return x, true
+	case string:
+		m := numberWithUnitPattern.FindStringSubmatch(strings.TrimSpace(x))
+		if m == nil {
+			return 0, false
+		}
+		multiplier, found := unitMultipliers[strings.ToUpper(m[2])]
+		if !found {
+			return 0, false
+		}
+		f, err := strconv.ParseFloat(m[1], 64)
+		if err != nil {
+			return 0, false
+		}
+		return f * multiplier, true
+	}
+	return 0, false
+}
+
+// toString returns the textual representation of the given value.
+func toString(v interface{}) string {
+	return fmt.Sprintf("%v", v)
+}
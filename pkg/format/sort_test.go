@@ -0,0 +1,83 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Ewout Prangsma
+//
+
+package format
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestValidateColumnName(t *testing.T) {
+	for _, name := range []string{"id", "created-at", "last_login_at", "node-count"} {
+		if err := validateColumnName(name); err != nil {
+			t.Errorf("validateColumnName(%q) failed: %v", name, err)
+		}
+	}
+	for _, name := range []string{"", "Name", "id,name", " id", "-id", "model.node-count"} {
+		if err := validateColumnName(name); err == nil {
+			t.Errorf("validateColumnName(%q) expected to fail", name)
+		}
+	}
+}
+
+func TestSortRows(t *testing.T) {
+	older := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	newer := older.Add(time.Hour)
+	tests := []struct {
+		name     string
+		values   []interface{}
+		expected []interface{}
+	}{
+		{"text", []interface{}{"b", "c", "a"}, []interface{}{"a", "b", "c"}},
+		{"numbers", []interface{}{"10", "9", "100"}, []interface{}{"9", "10", "100"}},
+		{"sizes", []interface{}{"1TB", "32GB", "512MB"}, []interface{}{"512MB", "32GB", "1TB"}},
+		{"versions", []interface{}{"3.10.0", "3.6.4", "3.7.1"}, []interface{}{"3.6.4", "3.7.1", "3.10.0"}},
+		{"times", []interface{}{timeValue{"newer", newer}, timeValue{"older", older}}, []interface{}{timeValue{"older", older}, timeValue{"newer", newer}}},
+		{"missing first", []interface{}{"a", nil}, []interface{}{nil, "a"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rows := make([][]kv, 0, len(test.values))
+			for _, v := range test.values {
+				if v == nil {
+					rows = append(rows, []kv{{"other", "x"}})
+				} else {
+					rows = append(rows, []kv{{"value", v}})
+				}
+			}
+			sortRows(rows, "value")
+			var result []interface{}
+			for _, row := range rows {
+				if row[0].Key == "value" {
+					result = append(result, row[0].Value)
+				} else {
+					result = append(result, nil)
+				}
+			}
+			if !reflect.DeepEqual(result, test.expected) {
+				t.Errorf("Expected %v, got %v", test.expected, result)
+			}
+		})
+	}
+}
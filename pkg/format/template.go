@@ -0,0 +1,139 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Ewout Prangsma
+//
+
+package format
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// toMap converts the given data into a map from field-name to value.
+func toMap(data []kv) map[string]interface{} {
+	m := make(map[string]interface{}, len(data))
+	for _, kv := range data {
+		m[kv.Key] = kv.Value
+	}
+	return m
+}
+
+// formatTemplateRows returns the result of the template (given in the
+// options) applied to each of the given rows, one line per row.
+// An error is returned when the template cannot be parsed or fails to
+// execute on one of the rows (e.g. {{.name.foo}} on a string field).
+func formatTemplateRows(opts Options, rows [][]kv) (string, error) {
+	eval := func(data map[string]interface{}) (string, error) {
+		return evalJSONPath(opts.argument(), data)
+	}
+	if opts.name() != formatJSONPath {
+		t, err := parseGoTemplate(opts.argument())
+		if err != nil {
+			return "", fmt.Errorf("Invalid --format: %s", err)
+		}
+		eval = func(data map[string]interface{}) (string, error) {
+			var buf bytes.Buffer
+			if err := t.Execute(&buf, data); err != nil {
+				return "", err
+			}
+			return buf.String(), nil
+		}
+	}
+	lines := make([]string, 0, len(rows))
+	for _, data := range rows {
+		line, err := eval(toMap(data))
+		if err != nil {
+			return "", fmt.Errorf("Failed to apply --format template: %s", err)
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// parseGoTemplate parses the given Go template.
+func parseGoTemplate(text string) (*template.Template, error) {
+	return template.New("format").Option("missingkey=zero").Parse(text)
+}
+
+// evalJSONPath applies the given JSONPath template to the given data.
+// Supported are literal text, field expressions like {.name} or {.['endpoint-url']},
+// {.} for the entire object and quoted string literals like {"\n"}.
+func evalJSONPath(text string, data map[string]interface{}) (string, error) {
+	var sb strings.Builder
+	for len(text) > 0 {
+		open := strings.Index(text, "{")
+		if open < 0 {
+			sb.WriteString(text)
+			break
+		}
+		sb.WriteString(text[:open])
+		close := strings.Index(text[open:], "}")
+		if close < 0 {
+			return "", fmt.Errorf("unclosed expression in jsonpath template at '%s'", text[open:])
+		}
+		expr := strings.TrimSpace(text[open+1 : open+close])
+		text = text[open+close+1:]
+		value, err := evalJSONPathExpression(expr, data)
+		if err != nil {
+			return "", err
+		}
+		sb.WriteString(value)
+	}
+	return sb.String(), nil
+}
+
+// evalJSONPathExpression evaluates a single expression (without the braces).
+func evalJSONPathExpression(expr string, data map[string]interface{}) (string, error) {
+	if strings.HasPrefix(expr, "\"") {
+		s, err := strconv.Unquote(expr)
+		if err != nil {
+			return "", fmt.Errorf("invalid string literal %s in jsonpath template", expr)
+		}
+		return s, nil
+	}
+	expr = strings.TrimPrefix(expr, "$")
+	if expr == "." || expr == "" {
+		encoded, err := json.Marshal(data)
+		if err != nil {
+			return "", err
+		}
+		return string(encoded), nil
+	}
+	if !strings.HasPrefix(expr, ".") {
+		return "", fmt.Errorf("invalid expression '%s' in jsonpath template, expected it to start with '.'", expr)
+	}
+	key := strings.TrimPrefix(expr, ".")
+	if strings.HasPrefix(key, "[") && strings.HasSuffix(key, "]") {
+		key = strings.Trim(key[1:len(key)-1], "'\"")
+	}
+	if strings.ContainsAny(key, ".[]") {
+		return "", fmt.Errorf("unsupported expression '%s' in jsonpath template, only top-level fields are supported", expr)
+	}
+	value, found := data[key]
+	if !found {
+		return "", nil
+	}
+	return fmt.Sprintf("%v", value), nil
+}
@@ -0,0 +1,94 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Ewout Prangsma
+//
+
+package format
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestJSONPath(t *testing.T) {
+	data := map[string]interface{}{"id": "d1", "endpoint-url": "https://example.com:8529", "paused": false}
+	tests := []struct {
+		template string
+		expected string
+		error    string
+	}{
+		{"{.id}", "d1", ""},
+		{"{$.id}", "d1", ""},
+		{"{ .id }", "d1", ""},
+		{"{.['endpoint-url']}", "https://example.com:8529", ""},
+		{"id={.id} paused={.paused}", "id=d1 paused=false", ""},
+		{`{.id}{"\t"}{.endpoint-url}`, "d1\thttps://example.com:8529", ""},
+		{"{.missing}", "", ""},
+		{"{.}", `{"endpoint-url":"https://example.com:8529","id":"d1","paused":false}`, ""},
+		{"no expressions", "no expressions", ""},
+		{"{.id", "", "unclosed expression"},
+		{"{id}", "", "invalid expression"},
+		{`{"\x"}`, "", "invalid string literal"},
+		{"{.model.node-count}", "", "unsupported expression"},
+	}
+	for _, test := range tests {
+		result, err := evalJSONPath(test.template, data)
+		if test.error != "" {
+			if err == nil || !strings.Contains(err.Error(), test.error) {
+				t.Errorf("evalJSONPath(%q): expected error containing %q, got %v", test.template, test.error, err)
+			}
+		} else if err != nil || result != test.expected {
+			t.Errorf("evalJSONPath(%q) = %q, %v; expected %q", test.template, result, err, test.expected)
+		}
+	}
+}
+
+func TestGoTemplate(t *testing.T) {
+	data := []kv{{"id", "d1"}, {"name", "main"}}
+	tests := []struct {
+		format   string
+		expected string
+	}{
+		{"template={{.id}}", "d1"},
+		{"go-template={{.name}} ({{.id}})", "main (d1)"},
+		{"template={{.missing}}", "<no value>"},
+		{"jsonpath={.name}", "main"},
+	}
+	for _, test := range tests {
+		opts := Options{Format: test.format}
+		if err := opts.Validate(); err != nil {
+			t.Fatalf("Validate(%q) failed: %v", test.format, err)
+		}
+		if result, err := formatTemplateRows(opts, [][]kv{data, data}); err != nil || result != test.expected+"\n"+test.expected {
+			t.Errorf("formatTemplateRows(%q) = %q, %v; expected %q twice", test.format, result, err, test.expected)
+		}
+	}
+	for _, format := range []string{"go-template={{.name.foo}}", "template={{index .id 5}}", "jsonpath={.id"} {
+		opts := Options{Format: format}
+		if _, err := formatTemplateRows(opts, [][]kv{data}); err == nil {
+			t.Errorf("formatTemplateRows(%q) expected to fail", format)
+		}
+	}
+	for _, text := range []string{"{{.id", "{{end}}", "{{.id | nosuchfunc}}"} {
+		if _, err := parseGoTemplate(text); err == nil {
+			t.Errorf("parseGoTemplate(%q) expected to fail", text)
+		}
+	}
+}
@@ -23,7 +23,7 @@
 package format
 
 // CLIVersion returns a single version formatted for humans.
-func CLIVersion(version string, opts Options) string {
+func CLIVersion(version string, opts Options) (string, error) {
 	return formatObject(opts,
 		kv{"version", version},
 	)
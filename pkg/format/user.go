@@ -27,7 +27,7 @@ import (
 )
 
 // User returns a single user formatted for humans.
-func User(x *iam.User, opts Options) string {
+func User(x *iam.User, opts Options) (string, error) {
 	return formatObject(opts,
 		kv{"id", x.GetId()},
 		kv{"name", x.GetName()},
@@ -39,7 +39,7 @@ func User(x *iam.User, opts Options) string {
 }
 
 // UserList returns a list of users formatted for humans.
-func UserList(list []*iam.User, opts Options) string {
+func UserList(list []*iam.User, opts Options) (string, error) {
 	return formatList(opts, list, func(i int) []kv {
 		x := list[i]
 		return []kv{
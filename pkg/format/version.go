@@ -27,14 +27,14 @@ import (
 )
 
 // Version returns a single version formatted for humans.
-func Version(x *data.Version, opts Options) string {
+func Version(x *data.Version, opts Options) (string, error) {
 	return formatObject(opts,
 		kv{"version", x.GetVersion()},
 	)
 }
 
 // VersionList returns a list of versions formatted for humans.
-func VersionList(list []*data.Version, defaultVersion *data.Version, opts Options) string {
+func VersionList(list []*data.Version, defaultVersion *data.Version, opts Options) (string, error) {
 	return formatList(opts, list, func(i int) []kv {
 		x := list[i]
 		return []kv{
@@ -0,0 +1,61 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Ewout Prangsma
+//
+
+package format
+
+import (
+	"strings"
+	"time"
+)
+
+// WaitResult is the outcome of waiting for an object to reach
+// a set of conditions.
+type WaitResult struct {
+	// Identifier of the object
+	ID string
+	// Name of the object
+	Name string
+	// Conditions that were waited for
+	Conditions []string
+	// Set if all conditions have been reached
+	Reached bool
+	// How long it took to reach the conditions (or how long was waited)
+	Duration time.Duration
+	// Last known status of the object
+	Status string
+}
+
+// WaitResultList returns a list of wait results formatted for humans.
+func WaitResultList(list []WaitResult, opts Options) (string, error) {
+	return formatList(opts, list, func(i int) []kv {
+		x := list[i]
+		return []kv{
+			{"id", x.ID},
+			{"name", x.Name},
+			{"for", strings.Join(x.Conditions, ",")},
+			{"reached", formatBool(opts, x.Reached)},
+			{"duration", x.Duration.Round(time.Second).String()},
+			{"duration-seconds", float64(x.Duration.Milliseconds()) / 1000},
+			{"status", x.Status},
+		}
+	}, true)
+}
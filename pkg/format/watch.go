@@ -0,0 +1,197 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Ewout Prangsma
+//
+
+package format
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ryanuber/columnize"
+)
+
+const (
+	// Terminal escape codes used to highlight changed values.
+	// Unchanged values are wrapped in codes of the same length, so
+	// columns stay aligned.
+	highlightStart = "\x1b[1;33m"
+	plainStart     = "\x1b[0;39m"
+	escapeEnd      = "\x1b[0m"
+)
+
+// Watch keeps track of the output of repeated polls of the same objects
+// (watch mode), such that only changed objects are emitted (in json format)
+// or changed values are highlighted (in table format).
+type Watch struct {
+	highlight bool
+	// Values of the previous poll: object key -> field -> value
+	previous map[string]map[string]string
+}
+
+// NewWatch returns a new Watch.
+// If highlight is set, changed values in tables are highlighted using
+// terminal escape codes.
+func NewWatch(highlight bool) *Watch {
+	return &Watch{highlight: highlight}
+}
+
+// update stores the given rows as the latest poll and returns a function
+// that reports whether the field with given key in the given row has changed
+// compared to the previous poll.
+// Nothing is reported as changed on the first poll, which is reported by
+// the second return value.
+func (w *Watch) update(rows [][]kv) (func(row int, key string) bool, bool) {
+	previous := w.previous
+	current := make(map[string]map[string]string, len(rows))
+	keys := make([]string, len(rows))
+	for i, data := range rows {
+		keys[i] = watchKey(data)
+		values := make(map[string]string, len(data))
+		for _, kv := range data {
+			values[kv.Key] = watchValue(kv.Value)
+		}
+		current[keys[i]] = values
+	}
+	w.previous = current
+	first := previous == nil
+	return func(row int, key string) bool {
+		if first {
+			return false
+		}
+		prev, found := previous[keys[row]]
+		if !found {
+			return true
+		}
+		value, found := prev[key]
+		return !found || value != current[keys[row]][key]
+	}, first
+}
+
+// formatChangedJSON returns the rows that are new or changed since the
+// previous poll as newline delimited JSON.
+func (w *Watch) formatChangedJSON(rows [][]kv) string {
+	changed, first := w.update(rows)
+	lines := make([]string, 0, len(rows))
+	for i, data := range rows {
+		rowChanged := first
+		m := make(map[string]interface{}, len(data))
+		for _, kv := range data {
+			m[kv.Key] = kv.Value
+			if changed(i, kv.Key) {
+				rowChanged = true
+			}
+		}
+		if !rowChanged {
+			continue
+		}
+		encoded, err := json.Marshal(m)
+		if err != nil {
+			panic(err)
+		}
+		lines = append(lines, string(encoded))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// formatWatchTable returns the given rows as a table in which the values
+// that changed since the previous poll are highlighted.
+func formatWatchTable(opts Options, rows [][]kv, noSort bool) string {
+	if !noSort {
+		// Sort before adding escape codes, so they do not affect the order
+		sort.SliceStable(rows, func(i, j int) bool {
+			return strings.Join(tableRow(rows[i]), "|^|") < strings.Join(tableRow(rows[j]), "|^|")
+		})
+	}
+	changed, _ := opts.Watch.update(rows)
+	if len(rows) == 0 {
+		if opts.NoHeaders {
+			return ""
+		}
+		return "None"
+	}
+	w := opts.Watch
+	lines := make([]string, 0, len(rows)+1)
+	for i, data := range rows {
+		if i == 0 && !opts.NoHeaders {
+			row := make([]string, 0, len(data))
+			for _, kv := range data {
+				row = append(row, w.wrap(strings.Title(kv.Key), false))
+			}
+			lines = append(lines, strings.Join(row, "|^|"))
+		}
+		row := tableRow(data)
+		for j, kv := range data {
+			row[j] = w.wrap(row[j], changed(i, kv.Key))
+		}
+		lines = append(lines, strings.Join(row, "|^|"))
+	}
+	return columnize.Format(lines, listConfig)
+}
+
+// tableRow returns the values of the given data as shown in a table.
+func tableRow(data []kv) []string {
+	row := make([]string, 0, len(data))
+	for _, kv := range data {
+		row = append(row, fmt.Sprintf("%v", kv.Value))
+	}
+	return row
+}
+
+// wrap returns the given value wrapped in escape codes, highlighted
+// if changed is set.
+func (w *Watch) wrap(value string, changed bool) string {
+	if !w.highlight {
+		return value
+	}
+	if changed {
+		return highlightStart + value + escapeEnd
+	}
+	return plainStart + value + escapeEnd
+}
+
+// watchKey returns the key that identifies the object with given data
+// between polls.
+func watchKey(data []kv) string {
+	for _, kv := range data {
+		if kv.Key == "id" {
+			if id := watchValue(kv.Value); id != "" {
+				return id
+			}
+		}
+	}
+	values := make([]string, 0, len(data))
+	for _, kv := range data {
+		values = append(values, watchValue(kv.Value))
+	}
+	return strings.Join(values, "\x00")
+}
+
+// watchValue returns the value used to detect changes.
+// Times are compared by their actual value, not their (relative) formatting.
+func watchValue(v interface{}) string {
+	if t, ok := v.(timeValue); ok && !t.t.IsZero() {
+		return t.t.String()
+	}
+	return fmt.Sprintf("%v", v)
+}
@@ -0,0 +1,64 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Ewout Prangsma
+//
+
+package format
+
+import (
+	"strings"
+	"testing"
+
+	rm "github.com/arangodb-managed/apis/resourcemanager/v1"
+)
+
+func TestWatchJSON(t *testing.T) {
+	opts := Options{Format: formatJSON, Watch: NewWatch(false)}
+	list := []*rm.Project{{Id: "p1", Name: "one"}, {Id: "p2", Name: "two"}}
+	output, _ := ProjectList(list, opts)
+	if lines := strings.Split(output, "\n"); len(lines) != 2 {
+		t.Errorf("Expected all projects on first poll, got %v", lines)
+	}
+	if output, _ := ProjectList(list, opts); output != "" {
+		t.Errorf("Expected no output for unchanged poll, got %q", output)
+	}
+	list = []*rm.Project{{Id: "p1", Name: "one"}, {Id: "p2", Name: "changed"}, {Id: "p3", Name: "three"}}
+	expected := `{"created-at":"","description":"","id":"p2","name":"changed","url":""}` + "\n" +
+		`{"created-at":"","description":"","id":"p3","name":"three","url":""}`
+	if output, _ := ProjectList(list, opts); output != expected {
+		t.Errorf("Expected changed & new projects, got %q", output)
+	}
+}
+
+func TestWatchTable(t *testing.T) {
+	opts := Options{Format: formatTable, Watch: NewWatch(true)}
+	ProjectList([]*rm.Project{{Id: "p1", Name: "one"}, {Id: "p2", Name: "two"}}, opts)
+	output, _ := ProjectList([]*rm.Project{{Id: "p1", Name: "one"}, {Id: "p2", Name: "2"}}, opts)
+	if !strings.Contains(output, highlightStart+"2"+escapeEnd) {
+		t.Errorf("Expected changed name to be highlighted, got %q", output)
+	}
+	if strings.Count(output, highlightStart) != 1 {
+		t.Errorf("Expected only one highlighted value, got %q", output)
+	}
+	lines := strings.Split(output, "\n")
+	if len(lines) != 3 || len(lines[1]) != len(lines[2]) {
+		t.Errorf("Expected aligned columns, got %q", output)
+	}
+}
@@ -0,0 +1,109 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Ewout Prangsma
+//
+
+package manifest
+
+import (
+	"fmt"
+	"strings"
+
+	data "github.com/arangodb-managed/apis/data/v1"
+)
+
+const (
+	colorRed    = "\033[31m"
+	colorGreen  = "\033[32m"
+	colorYellow = "\033[33m"
+	colorReset  = "\033[0m"
+)
+
+// Diff returns a unified field level representation of the changes in the plan.
+// If color is set, the lines are colored using ANSI escape codes.
+func (p *Plan) Diff(color bool) string {
+	var sb strings.Builder
+	for _, a := range p.Actions {
+		a.writeDiff(&sb, color)
+	}
+	return sb.String()
+}
+
+// Diff returns a unified field level representation of the changes of the action.
+func (a *Action) Diff(color bool) string {
+	var sb strings.Builder
+	a.writeDiff(&sb, color)
+	return sb.String()
+}
+
+// writeDiff writes the changes of the action to the given builder.
+func (a *Action) writeDiff(sb *strings.Builder, color bool) {
+	line := func(c, prefix, text string) {
+		if color {
+			fmt.Fprintf(sb, "%s%s %s%s\n", c, prefix, text, colorReset)
+		} else {
+			fmt.Fprintf(sb, "%s %s\n", prefix, text)
+		}
+	}
+	switch a.Type {
+	case ActionCreate:
+		line(colorGreen, "+", fmt.Sprintf("%s %s", a.Kind, a.Name))
+		for _, ch := range a.Changes {
+			line(colorGreen, "+", fmt.Sprintf("    %s: %s", ch.Field, ch.New))
+		}
+	default:
+		line(colorYellow, "~", fmt.Sprintf("%s %s", a.Kind, a.Name))
+		for _, ch := range a.Changes {
+			line(colorRed, "-", fmt.Sprintf("    %s: %s", ch.Field, ch.Old))
+			line(colorGreen, "+", fmt.Sprintf("    %s: %s", ch.Field, ch.New))
+		}
+	}
+}
+
+// NewDeploymentUpdate returns an (unapplicable) update action describing
+// the changes between the given current and desired deployment.
+// IP whitelist and CA certificate are compared by identifier.
+func NewDeploymentUpdate(current, desired *data.Deployment) *Action {
+	a := &Action{Type: ActionUpdate, Kind: "deployment", Name: current.GetName()}
+	cmp := func(field, old, new string) {
+		if old != new {
+			a.Changes = append(a.Changes, Change{Field: field, Old: old, New: new})
+		}
+	}
+	cmpInt := func(field string, old, new int32) {
+		cmp(field, fmt.Sprint(old), fmt.Sprint(new))
+	}
+	cmp("name", current.GetName(), desired.GetName())
+	cmp("description", current.GetDescription(), desired.GetDescription())
+	cmp("version", current.GetVersion(), desired.GetVersion())
+	cmp("cacertificate", current.GetCertificates().GetCaCertificateId(), desired.GetCertificates().GetCaCertificateId())
+	cmp("ipwhitelist", current.GetIpwhitelistId(), desired.GetIpwhitelistId())
+	cmp("model", current.GetModel().GetModel(), desired.GetModel().GetModel())
+	cmp("node-size-id", current.GetModel().GetNodeSizeId(), desired.GetModel().GetNodeSizeId())
+	cmpInt("node-count", current.GetModel().GetNodeCount(), desired.GetModel().GetNodeCount())
+	cmpInt("node-disk-size", current.GetModel().GetNodeDiskSize(), desired.GetModel().GetNodeDiskSize())
+	cs, ds := current.GetServers(), desired.GetServers()
+	cmpInt("servers.coordinators", cs.GetCoordinators(), ds.GetCoordinators())
+	cmpInt("servers.coordinator-memory-size", cs.GetCoordinatorMemorySize(), ds.GetCoordinatorMemorySize())
+	cmpInt("servers.dbservers", cs.GetDbservers(), ds.GetDbservers())
+	cmpInt("servers.dbserver-memory-size", cs.GetDbserverMemorySize(), ds.GetDbserverMemorySize())
+	cmpInt("servers.dbserver-disk-size", cs.GetDbserverDiskSize(), ds.GetDbserverDiskSize())
+	return a
+}
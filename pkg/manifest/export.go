@@ -0,0 +1,275 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Ewout Prangsma
+//
+
+package manifest
+
+import (
+	"context"
+	"strings"
+
+	"github.com/gogo/protobuf/types"
+	"github.com/rs/zerolog"
+
+	common "github.com/arangodb-managed/apis/common/v1"
+	data "github.com/arangodb-managed/apis/data/v1"
+	iam "github.com/arangodb-managed/apis/iam/v1"
+
+	"github.com/arangodb-managed/oasisctl/pkg/selection"
+	"github.com/arangodb-managed/oasisctl/pkg/util"
+)
+
+// exporter holds the state of an export of an organization.
+type exporter struct {
+	c Clients
+	// Names per identifier
+	groupNames map[string]string
+	roleNames  map[string]string
+	// Email address per user identifier
+	userEmails map[string]string
+}
+
+// Export builds a manifest that describes the current state of the organization
+// with given ID or name. Identifiers are left out, references between resources
+// are expressed by name.
+// Predefined roles, virtual groups and role bindings that cannot be deleted are
+// not exported, since they are managed by the platform.
+func Export(ctx context.Context, log zerolog.Logger, orgID string, c Clients) (*Manifest, error) {
+	org, err := selection.SelectOrganization(ctx, log, orgID, c.ResourceManager)
+	if err != nil {
+		return nil, err
+	}
+	e := &exporter{
+		c:          c,
+		groupNames: make(map[string]string),
+		roleNames:  make(map[string]string),
+		userEmails: make(map[string]string),
+	}
+	m := &Manifest{Organization: org.GetName()}
+
+	// Groups
+	groups, err := c.IAM.ListGroups(ctx, &common.ListOptions{ContextId: org.GetId()})
+	if err != nil {
+		return nil, err
+	}
+	for _, x := range groups.GetItems() {
+		e.groupNames[x.GetId()] = x.GetName()
+		if x.GetIsDeleted() || x.GetIsVirtual() {
+			continue
+		}
+		g := &Group{Name: x.GetName(), Description: x.GetDescription()}
+		members, err := c.IAM.ListGroupMembers(ctx, &common.ListOptions{ContextId: x.GetId()})
+		if err != nil {
+			return nil, err
+		}
+		for _, userID := range members.GetItems() {
+			email, err := e.userEmail(ctx, userID)
+			if err != nil {
+				return nil, err
+			}
+			g.Members = append(g.Members, email)
+		}
+		m.Groups = append(m.Groups, g)
+	}
+
+	// Roles
+	roles, err := c.IAM.ListRoles(ctx, &common.ListOptions{ContextId: org.GetId()})
+	if err != nil {
+		return nil, err
+	}
+	for _, x := range roles.GetItems() {
+		e.roleNames[x.GetId()] = x.GetName()
+		if x.GetIsDeleted() || x.GetIsPredefined() {
+			continue
+		}
+		m.Roles = append(m.Roles, &Role{
+			Name:        x.GetName(),
+			Description: x.GetDescription(),
+			Permissions: sortedCopy(x.GetPermissions()),
+		})
+	}
+
+	// Organization policy
+	if m.Policy, err = e.exportPolicy(ctx, org.GetUrl()); err != nil {
+		return nil, err
+	}
+
+	// Projects
+	projects, err := c.ResourceManager.ListProjects(ctx, &common.ListOptions{ContextId: org.GetId()})
+	if err != nil {
+		return nil, err
+	}
+	for _, x := range projects.GetItems() {
+		if x.GetIsDeleted() {
+			continue
+		}
+		p, err := e.exportProject(ctx, x.GetId(), x.GetName(), x.GetDescription(), x.GetUrl())
+		if err != nil {
+			return nil, err
+		}
+		m.Projects = append(m.Projects, p)
+	}
+	return m, nil
+}
+
+// exportProject builds the manifest of a project and its resources.
+func (e *exporter) exportProject(ctx context.Context, id, name, description, url string) (*Project, error) {
+	p := &Project{Name: name, Description: description}
+	var err error
+	if p.Policy, err = e.exportPolicy(ctx, url); err != nil {
+		return nil, err
+	}
+
+	// CA certificates
+	cacertNames := make(map[string]string)
+	cacerts, err := e.c.Crypto.ListCACertificates(ctx, &common.ListOptions{ContextId: id})
+	if err != nil {
+		return nil, err
+	}
+	for _, x := range cacerts.GetItems() {
+		cacertNames[x.GetId()] = x.GetName()
+		if x.GetIsDeleted() {
+			continue
+		}
+		useWellKnownCertificate := x.GetUseWellKnownCertificate()
+		cert := &CACertificate{
+			Name:                    x.GetName(),
+			Description:             x.GetDescription(),
+			UseWellKnownCertificate: &useWellKnownCertificate,
+		}
+		if lifetime := x.GetLifetime(); lifetime != nil {
+			d, err := types.DurationFromProto(lifetime)
+			if err != nil {
+				return nil, err
+			}
+			cert.Lifetime = util.FormatDuration(d)
+		}
+		p.CACertificates = append(p.CACertificates, cert)
+	}
+
+	// IP whitelists
+	ipwhitelistNames := make(map[string]string)
+	ipwhitelists, err := e.c.Security.ListIPWhitelists(ctx, &common.ListOptions{ContextId: id})
+	if err != nil {
+		return nil, err
+	}
+	for _, x := range ipwhitelists.GetItems() {
+		ipwhitelistNames[x.GetId()] = x.GetName()
+		if x.GetIsDeleted() {
+			continue
+		}
+		p.IPWhitelists = append(p.IPWhitelists, &IPWhitelist{
+			Name:        x.GetName(),
+			Description: x.GetDescription(),
+			CIDRRanges:  sortedCopy(x.GetCidrRanges()),
+		})
+	}
+
+	// Deployments
+	deployments, err := e.c.Data.ListDeployments(ctx, &common.ListOptions{ContextId: id})
+	if err != nil {
+		return nil, err
+	}
+	for _, x := range deployments.GetItems() {
+		if x.GetIsDeleted() {
+			continue
+		}
+		d := &Deployment{
+			Name:          x.GetName(),
+			Description:   x.GetDescription(),
+			Region:        x.GetRegionId(),
+			Version:       x.GetVersion(),
+			CACertificate: cacertNames[x.GetCertificates().GetCaCertificateId()],
+			IPWhitelist:   ipwhitelistNames[x.GetIpwhitelistId()],
+			Model:         x.GetModel().GetModel(),
+		}
+		if d.Model == data.ModelFlexible {
+			s := x.GetServers()
+			d.Servers = &Servers{
+				Coordinators:          s.GetCoordinators(),
+				CoordinatorMemorySize: s.GetCoordinatorMemorySize(),
+				DBServers:             s.GetDbservers(),
+				DBServerMemorySize:    s.GetDbserverMemorySize(),
+				DBServerDiskSize:      s.GetDbserverDiskSize(),
+			}
+		} else {
+			d.NodeSizeID = x.GetModel().GetNodeSizeId()
+			d.NodeCount = x.GetModel().GetNodeCount()
+			d.NodeDiskSize = x.GetModel().GetNodeDiskSize()
+		}
+		p.Deployments = append(p.Deployments, d)
+	}
+	return p, nil
+}
+
+// exportPolicy builds the manifest of the role bindings on the resource with given URL.
+// Returns nil if there are no bindings to export.
+func (e *exporter) exportPolicy(ctx context.Context, url string) (*Policy, error) {
+	policy, err := e.c.IAM.GetPolicy(ctx, &common.URLOptions{Url: url})
+	if err != nil {
+		return nil, err
+	}
+	result := &Policy{}
+	for _, b := range policy.GetBindings() {
+		if b.GetDeleteNotAllowed() {
+			continue
+		}
+		rb := &RoleBinding{Role: e.roleNames[b.GetRoleId()]}
+		if rb.Role == "" {
+			rb.Role = b.GetRoleId()
+		}
+		memberID := b.GetMemberId()
+		switch {
+		case strings.HasPrefix(memberID, iam.CreateMemberIDFromUserID("")):
+			email, err := e.userEmail(ctx, strings.TrimPrefix(memberID, iam.CreateMemberIDFromUserID("")))
+			if err != nil {
+				return nil, err
+			}
+			rb.User = email
+		case strings.HasPrefix(memberID, iam.CreateMemberIDFromGroupID("")):
+			groupID := strings.TrimPrefix(memberID, iam.CreateMemberIDFromGroupID(""))
+			rb.Group = e.groupNames[groupID]
+			if rb.Group == "" {
+				rb.Group = groupID
+			}
+		default:
+			continue
+		}
+		result.Bindings = append(result.Bindings, rb)
+	}
+	if len(result.Bindings) == 0 {
+		return nil, nil
+	}
+	return result, nil
+}
+
+// userEmail returns the email address of the user with given ID.
+func (e *exporter) userEmail(ctx context.Context, userID string) (string, error) {
+	if email, found := e.userEmails[userID]; found {
+		return email, nil
+	}
+	u, err := e.c.IAM.GetUser(ctx, &common.IDOptions{Id: userID})
+	if err != nil {
+		return "", err
+	}
+	e.userEmails[userID] = u.GetEmail()
+	return u.GetEmail(), nil
+}
@@ -0,0 +1,285 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Ewout Prangsma
+//
+
+package manifest
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/rs/zerolog"
+
+	common "github.com/arangodb-managed/apis/common/v1"
+	iam "github.com/arangodb-managed/apis/iam/v1"
+	rm "github.com/arangodb-managed/apis/resourcemanager/v1"
+
+	"github.com/arangodb-managed/oasisctl/pkg/selection"
+)
+
+// organizationState holds the identifiers of the groups & roles of an organization.
+// Identifiers of resources that are created by the plan are filled in while applying it.
+type organizationState struct {
+	org    *rm.Organization
+	groups map[string]string
+	roles  map[string]*iam.Role
+}
+
+// newOrganizationState fetches the groups & roles of the given organization.
+func newOrganizationState(ctx context.Context, org *rm.Organization, c Clients) (*organizationState, error) {
+	orgState := &organizationState{
+		org:    org,
+		groups: make(map[string]string),
+		roles:  make(map[string]*iam.Role),
+	}
+	groups, err := c.IAM.ListGroups(ctx, &common.ListOptions{ContextId: org.GetId()})
+	if err != nil {
+		return nil, err
+	}
+	for _, x := range groups.GetItems() {
+		if x.GetIsDeleted() {
+			continue
+		}
+		orgState.groups[x.GetName()] = x.GetId()
+	}
+	roles, err := c.IAM.ListRoles(ctx, &common.ListOptions{ContextId: org.GetId()})
+	if err != nil {
+		return nil, err
+	}
+	for _, x := range roles.GetItems() {
+		if x.GetIsDeleted() {
+			continue
+		}
+		orgState.roles[x.GetName()] = x
+	}
+	return orgState, nil
+}
+
+// addGroup adds the action (if any) for the given group.
+func (plan *Plan) addGroup(ctx context.Context, log zerolog.Logger, orgState *organizationState, x *Group, c Clients) error {
+	// Resolve members
+	var memberIDs []string
+	for _, email := range x.Members {
+		u, err := selection.SelectMember(ctx, log, email, orgState.org.GetId(), c.IAM, c.ResourceManager)
+		if err != nil {
+			return fmt.Errorf("Failed to find member '%s' of group '%s': %s", email, x.Name, err)
+		}
+		memberIDs = append(memberIDs, u.GetId())
+	}
+	addMembers := func(ctx context.Context, groupID string, userIDs []string) error {
+		if len(userIDs) == 0 {
+			return nil
+		}
+		_, err := c.IAM.AddGroupMembers(ctx, &iam.GroupMembersRequest{GroupId: groupID, UserIds: userIDs})
+		return err
+	}
+
+	id, found := orgState.groups[x.Name]
+	if !found {
+		a := &Action{Type: ActionCreate, Kind: "group", Name: x.Name}
+		a.compareString("description", "", x.Description)
+		a.compareStrings("members", nil, x.Members)
+		a.apply = func(ctx context.Context) error {
+			result, err := c.IAM.CreateGroup(ctx, &iam.Group{
+				OrganizationId: orgState.org.GetId(),
+				Name:           x.Name,
+				Description:    x.Description,
+			})
+			if err != nil {
+				return err
+			}
+			orgState.groups[x.Name] = result.GetId()
+			return addMembers(ctx, result.GetId(), memberIDs)
+		}
+		plan.Actions = append(plan.Actions, a)
+		return nil
+	}
+	current, err := c.IAM.GetGroup(ctx, &common.IDOptions{Id: id})
+	if err != nil {
+		return err
+	}
+	members, err := c.IAM.ListGroupMembers(ctx, &common.ListOptions{ContextId: id})
+	if err != nil {
+		return err
+	}
+	isMember := make(map[string]bool)
+	for _, userID := range members.GetItems() {
+		isMember[userID] = true
+	}
+	var missingIDs, missingEmails []string
+	for i, userID := range memberIDs {
+		if !isMember[userID] {
+			missingIDs = append(missingIDs, userID)
+			missingEmails = append(missingEmails, x.Members[i])
+		}
+	}
+	a := &Action{Type: ActionUpdate, Kind: "group", Name: x.Name}
+	a.compareString("description", current.GetDescription(), x.Description)
+	if len(missingEmails) > 0 {
+		a.Changes = append(a.Changes, Change{Field: "members", New: "+" + strings.Join(missingEmails, ",+")})
+	}
+	if len(a.Changes) > 0 {
+		a.apply = func(ctx context.Context) error {
+			if x.Description != "" && x.Description != current.GetDescription() {
+				current.Description = x.Description
+				if _, err := c.IAM.UpdateGroup(ctx, current); err != nil {
+					return err
+				}
+			}
+			return addMembers(ctx, id, missingIDs)
+		}
+		plan.Actions = append(plan.Actions, a)
+	}
+	return nil
+}
+
+// addRole adds the action (if any) for the given custom role.
+func (plan *Plan) addRole(orgState *organizationState, x *Role, c Clients) error {
+	current, found := orgState.roles[x.Name]
+	if !found {
+		a := &Action{Type: ActionCreate, Kind: "role", Name: x.Name}
+		a.compareString("description", "", x.Description)
+		a.compareStrings("permissions", nil, x.Permissions)
+		a.apply = func(ctx context.Context) error {
+			result, err := c.IAM.CreateRole(ctx, &iam.Role{
+				OrganizationId: orgState.org.GetId(),
+				Name:           x.Name,
+				Description:    x.Description,
+				Permissions:    sortedCopy(x.Permissions),
+			})
+			if err != nil {
+				return err
+			}
+			orgState.roles[x.Name] = result
+			return nil
+		}
+		plan.Actions = append(plan.Actions, a)
+		return nil
+	}
+	a := &Action{Type: ActionUpdate, Kind: "role", Name: x.Name}
+	a.compareString("description", current.GetDescription(), x.Description)
+	a.compareStrings("permissions", current.GetPermissions(), x.Permissions)
+	if len(a.Changes) == 0 {
+		return nil
+	}
+	if current.GetIsPredefined() {
+		return fmt.Errorf("Cannot change predefined role '%s'", x.Name)
+	}
+	a.apply = func(ctx context.Context) error {
+		if x.Description != "" {
+			current.Description = x.Description
+		}
+		if x.Permissions != nil {
+			current.Permissions = sortedCopy(x.Permissions)
+		}
+		_, err := c.IAM.UpdateRole(ctx, current)
+		return err
+	}
+	plan.Actions = append(plan.Actions, a)
+	return nil
+}
+
+// addPolicy adds the action (if any) for the role bindings of the given policy.
+// The URL of the resource is resolved when needed, since the resource may be
+// created by the plan.
+func (plan *Plan) addPolicy(ctx context.Context, log zerolog.Logger, orgState *organizationState, kind, name string, resourceURL func() string, x *Policy, c Clients) error {
+	if x == nil || len(x.Bindings) == 0 {
+		return nil
+	}
+	// Fetch existing bindings (if resource exists)
+	existing := make(map[string]bool)
+	if url := resourceURL(); url != "" {
+		policy, err := c.IAM.GetPolicy(ctx, &common.URLOptions{Url: url})
+		if err != nil {
+			return err
+		}
+		for _, b := range policy.GetBindings() {
+			existing[b.GetMemberId()+"/"+b.GetRoleId()] = true
+		}
+	}
+	// Resolve users (groups & roles are resolved when applying)
+	userIDs := make(map[string]string)
+	for _, b := range x.Bindings {
+		if b.User != "" {
+			u, err := selection.SelectMember(ctx, log, b.User, orgState.org.GetId(), c.IAM, c.ResourceManager)
+			if err != nil {
+				return fmt.Errorf("Failed to find user '%s' of role binding: %s", b.User, err)
+			}
+			userIDs[b.User] = u.GetId()
+		}
+	}
+	memberID := func(b *RoleBinding) string {
+		if b.User != "" {
+			return iam.CreateMemberIDFromUserID(userIDs[b.User])
+		}
+		if id, found := orgState.groups[b.Group]; found {
+			return iam.CreateMemberIDFromGroupID(id)
+		}
+		return ""
+	}
+	roleID := func(b *RoleBinding) string {
+		if r, found := orgState.roles[b.Role]; found {
+			return r.GetId()
+		}
+		return ""
+	}
+
+	var missing []*RoleBinding
+	var descriptions []string
+	for _, b := range x.Bindings {
+		mID, rID := memberID(b), roleID(b)
+		if mID == "" || rID == "" || !existing[mID+"/"+rID] {
+			missing = append(missing, b)
+			descriptions = append(descriptions, b.String())
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	a := &Action{Type: ActionUpdate, Kind: "policy", Name: kind + " " + name}
+	a.Changes = append(a.Changes, Change{Field: "bindings", New: "+" + strings.Join(descriptions, ",+")})
+	a.apply = func(ctx context.Context) error {
+		req := &iam.RoleBindingsRequest{ResourceUrl: resourceURL()}
+		for _, b := range missing {
+			mID, rID := memberID(b), roleID(b)
+			if mID == "" {
+				return fmt.Errorf("Unknown group '%s'", b.Group)
+			}
+			if rID == "" {
+				return fmt.Errorf("Unknown role '%s'", b.Role)
+			}
+			req.Bindings = append(req.Bindings, &iam.RoleBinding{MemberId: mID, RoleId: rID})
+		}
+		_, err := c.IAM.AddRoleBindings(ctx, req)
+		return err
+	}
+	plan.Actions = append(plan.Actions, a)
+	return nil
+}
+
+// String returns a human readable representation of the binding.
+func (b *RoleBinding) String() string {
+	if b.User != "" {
+		return fmt.Sprintf("%s(user %s)", b.Role, b.User)
+	}
+	return fmt.Sprintf("%s(group %s)", b.Role, b.Group)
+}
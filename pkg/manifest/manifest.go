@@ -0,0 +1,260 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Ewout Prangsma
+//
+
+package manifest
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Manifest describes the desired state of resources in an organization.
+// Resources are identified by name, references between resources are
+// expressed by name as well.
+type Manifest struct {
+	// Identifier or name of the organization. Defaults to the selected organization.
+	Organization string `yaml:"organization,omitempty"`
+	// Groups in the organization
+	Groups []*Group `yaml:"groups,omitempty"`
+	// Custom roles in the organization
+	Roles []*Role `yaml:"roles,omitempty"`
+	// Role bindings on the organization
+	Policy *Policy `yaml:"policy,omitempty"`
+	// Projects in the organization
+	Projects []*Project `yaml:"projects,omitempty"`
+}
+
+// Group describes the desired state of a group.
+type Group struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description,omitempty"`
+	// Email addresses of users that must be a member of the group.
+	// Members that are not listed are left untouched.
+	Members []string `yaml:"members,omitempty"`
+}
+
+// Role describes the desired state of a custom role.
+type Role struct {
+	Name        string   `yaml:"name"`
+	Description string   `yaml:"description,omitempty"`
+	Permissions []string `yaml:"permissions,omitempty"`
+}
+
+// Policy describes role bindings that must exist on a resource.
+// Bindings that are not listed are left untouched.
+type Policy struct {
+	Bindings []*RoleBinding `yaml:"bindings,omitempty"`
+}
+
+// RoleBinding binds a role to a user or group.
+type RoleBinding struct {
+	// Name of the role
+	Role string `yaml:"role"`
+	// Email address of the user (if binding to a user)
+	User string `yaml:"user,omitempty"`
+	// Name of the group (if binding to a group)
+	Group string `yaml:"group,omitempty"`
+}
+
+// Project describes the desired state of a project and the resources in it.
+type Project struct {
+	Name           string           `yaml:"name"`
+	Description    string           `yaml:"description,omitempty"`
+	Policy         *Policy          `yaml:"policy,omitempty"`
+	CACertificates []*CACertificate `yaml:"cacertificates,omitempty"`
+	IPWhitelists   []*IPWhitelist   `yaml:"ipwhitelists,omitempty"`
+	Deployments    []*Deployment    `yaml:"deployments,omitempty"`
+}
+
+// CACertificate describes the desired state of a CA certificate.
+type CACertificate struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description,omitempty"`
+	// Lifetime of the certificate (e.g. 8760h or 365d). Only used on creation.
+	Lifetime string `yaml:"lifetime,omitempty"`
+	// When not set, the current setting of an existing certificate is kept.
+	UseWellKnownCertificate *bool `yaml:"use-well-known-certificate,omitempty"`
+}
+
+// IPWhitelist describes the desired state of an IP whitelist.
+type IPWhitelist struct {
+	Name        string   `yaml:"name"`
+	Description string   `yaml:"description,omitempty"`
+	CIDRRanges  []string `yaml:"cidr-ranges,omitempty"`
+}
+
+// Deployment describes the desired state of a deployment.
+type Deployment struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description,omitempty"`
+	// Identifier of the region. Cannot be changed after creation.
+	Region  string `yaml:"region,omitempty"`
+	Version string `yaml:"version,omitempty"`
+	// Name of the CA certificate. Defaults to the default CA certificate of the project.
+	CACertificate string `yaml:"cacertificate,omitempty"`
+	// Name of the IP whitelist
+	IPWhitelist  string   `yaml:"ipwhitelist,omitempty"`
+	Model        string   `yaml:"model,omitempty"`
+	NodeSizeID   string   `yaml:"node-size-id,omitempty"`
+	NodeCount    int32    `yaml:"node-count,omitempty"`
+	NodeDiskSize int32    `yaml:"node-disk-size,omitempty"`
+	Servers      *Servers `yaml:"servers,omitempty"`
+}
+
+// Servers describes the servers of a flexible deployment.
+type Servers struct {
+	Coordinators          int32 `yaml:"coordinators,omitempty"`
+	CoordinatorMemorySize int32 `yaml:"coordinator-memory-size,omitempty"`
+	DBServers             int32 `yaml:"dbservers,omitempty"`
+	DBServerMemorySize    int32 `yaml:"dbserver-memory-size,omitempty"`
+	DBServerDiskSize      int32 `yaml:"dbserver-disk-size,omitempty"`
+}
+
+// Load reads the manifest (YAML or JSON) from the file with given path.
+// A path of "-" reads from stdin.
+func Load(path string) (*Manifest, error) {
+	var raw []byte
+	var err error
+	if path == "-" {
+		raw, err = ioutil.ReadAll(os.Stdin)
+	} else {
+		raw, err = ioutil.ReadFile(path)
+	}
+	if err != nil {
+		return nil, err
+	}
+	m := &Manifest{}
+	if err := yaml.UnmarshalStrict(raw, m); err != nil {
+		return nil, fmt.Errorf("Failed to parse %s: %s", path, err)
+	}
+	return m, nil
+}
+
+// LoadAll reads the manifests from the files with given paths and
+// merges them into a single manifest.
+func LoadAll(paths []string) (*Manifest, error) {
+	result := &Manifest{}
+	for _, p := range paths {
+		m, err := Load(p)
+		if err != nil {
+			return nil, err
+		}
+		if m.Organization != "" {
+			if result.Organization != "" && result.Organization != m.Organization {
+				return nil, fmt.Errorf("Manifest %s uses organization '%s', expected '%s'", p, m.Organization, result.Organization)
+			}
+			result.Organization = m.Organization
+		}
+		result.Groups = append(result.Groups, m.Groups...)
+		result.Roles = append(result.Roles, m.Roles...)
+		if m.Policy != nil {
+			if result.Policy == nil {
+				result.Policy = &Policy{}
+			}
+			result.Policy.Bindings = append(result.Policy.Bindings, m.Policy.Bindings...)
+		}
+		result.Projects = append(result.Projects, m.Projects...)
+	}
+	if err := result.Validate(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Validate checks that all resources have a name that is unique
+// among resources of the same kind.
+func (m *Manifest) Validate() error {
+	groups := make(map[string]bool)
+	for _, x := range m.Groups {
+		if err := checkName("group", x.Name, groups); err != nil {
+			return err
+		}
+	}
+	roles := make(map[string]bool)
+	for _, x := range m.Roles {
+		if err := checkName("role", x.Name, roles); err != nil {
+			return err
+		}
+	}
+	if err := m.Policy.validate("organization"); err != nil {
+		return err
+	}
+	projects := make(map[string]bool)
+	for _, p := range m.Projects {
+		if err := checkName("project", p.Name, projects); err != nil {
+			return err
+		}
+		if err := p.Policy.validate("project " + p.Name); err != nil {
+			return err
+		}
+		cacerts := make(map[string]bool)
+		for _, x := range p.CACertificates {
+			if err := checkName("CA certificate in project "+p.Name, x.Name, cacerts); err != nil {
+				return err
+			}
+		}
+		ipwhitelists := make(map[string]bool)
+		for _, x := range p.IPWhitelists {
+			if err := checkName("IP whitelist in project "+p.Name, x.Name, ipwhitelists); err != nil {
+				return err
+			}
+		}
+		deployments := make(map[string]bool)
+		for _, x := range p.Deployments {
+			if err := checkName("deployment in project "+p.Name, x.Name, deployments); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// validate checks that all bindings have a role and exactly one member.
+func (p *Policy) validate(resource string) error {
+	if p == nil {
+		return nil
+	}
+	for _, b := range p.Bindings {
+		if b.Role == "" {
+			return fmt.Errorf("Found role binding without a role in policy of %s", resource)
+		}
+		if (b.User == "") == (b.Group == "") {
+			return fmt.Errorf("Role binding for role '%s' in policy of %s must have either a user or a group", b.Role, resource)
+		}
+	}
+	return nil
+}
+
+// checkName returns an error if the given name is empty or already in the given set.
+func checkName(kind, name string, names map[string]bool) error {
+	if name == "" {
+		return fmt.Errorf("Found %s without a name", kind)
+	}
+	if names[name] {
+		return fmt.Errorf("Found duplicate %s '%s'", kind, name)
+	}
+	names[name] = true
+	return nil
+}
@@ -0,0 +1,605 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Ewout Prangsma
+//
+
+package manifest
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gogo/protobuf/types"
+	"github.com/rs/zerolog"
+
+	common "github.com/arangodb-managed/apis/common/v1"
+	crypto "github.com/arangodb-managed/apis/crypto/v1"
+	data "github.com/arangodb-managed/apis/data/v1"
+	iam "github.com/arangodb-managed/apis/iam/v1"
+	rm "github.com/arangodb-managed/apis/resourcemanager/v1"
+	security "github.com/arangodb-managed/apis/security/v1"
+
+	"github.com/arangodb-managed/oasisctl/pkg/selection"
+	"github.com/arangodb-managed/oasisctl/pkg/util"
+)
+
+// Clients holds the API clients used to plan and apply a manifest.
+type Clients struct {
+	ResourceManager rm.ResourceManagerServiceClient
+	Crypto          crypto.CryptoServiceClient
+	Security        security.SecurityServiceClient
+	Data            data.DataServiceClient
+	IAM             iam.IAMServiceClient
+}
+
+// ActionType specifies what an action does with a resource.
+type ActionType string
+
+const (
+	// ActionCreate creates a new resource.
+	ActionCreate ActionType = "create"
+	// ActionUpdate updates an existing resource.
+	ActionUpdate ActionType = "update"
+)
+
+// Change describes the change of a single field of a resource.
+type Change struct {
+	Field string
+	Old   string
+	New   string
+}
+
+// Action is a single create or update of a resource.
+type Action struct {
+	Type ActionType
+	// Kind of the resource (group|role|policy|project|cacertificate|ipwhitelist|deployment)
+	Kind string
+	// Name of the resource, prefixed with the name of its project.
+	Name string
+	// Changed fields
+	Changes []Change
+
+	apply func(ctx context.Context) error
+}
+
+// Plan is an ordered list of actions that converge the account
+// to the state described in a manifest.
+type Plan struct {
+	Actions []*Action
+}
+
+// HasChanges returns true if the plan contains at least one action.
+func (p *Plan) HasChanges() bool {
+	return len(p.Actions) > 0
+}
+
+// Apply executes all actions of the plan in order.
+func (p *Plan) Apply(ctx context.Context, log zerolog.Logger) error {
+	for _, a := range p.Actions {
+		log.Debug().Str("kind", a.Kind).Str("name", a.Name).Msgf("Applying %s", a.Type)
+		if err := a.apply(ctx); err != nil {
+			return fmt.Errorf("Failed to %s %s '%s': %s", a.Type, a.Kind, a.Name, err)
+		}
+	}
+	return nil
+}
+
+// compareString adds a change when the desired value is set and differs from the current value.
+func (a *Action) compareString(field, current, desired string) {
+	if desired != "" && current != desired {
+		a.Changes = append(a.Changes, Change{Field: field, Old: current, New: desired})
+	}
+}
+
+// compareInt adds a change when the desired value is set and differs from the current value.
+func (a *Action) compareInt(field string, current, desired int32) {
+	if desired != 0 && current != desired {
+		a.Changes = append(a.Changes, Change{Field: field, Old: fmt.Sprint(current), New: fmt.Sprint(desired)})
+	}
+}
+
+// compareBool adds a change when the desired value differs from the current value.
+func (a *Action) compareBool(field string, current, desired bool) {
+	if current != desired {
+		a.Changes = append(a.Changes, Change{Field: field, Old: fmt.Sprint(current), New: fmt.Sprint(desired)})
+	}
+}
+
+// compareStrings adds a change when the desired list is set and contains
+// different elements than the current list (ignoring order).
+func (a *Action) compareStrings(field string, current, desired []string) {
+	if desired == nil {
+		return
+	}
+	c := sortedCopy(current)
+	d := sortedCopy(desired)
+	if strings.Join(c, ",") != strings.Join(d, ",") {
+		a.Changes = append(a.Changes, Change{Field: field, Old: strings.Join(c, ","), New: strings.Join(d, ",")})
+	}
+}
+
+// sortedCopy returns a sorted copy of the given list.
+func sortedCopy(list []string) []string {
+	result := append([]string{}, list...)
+	sort.Strings(result)
+	return result
+}
+
+// projectState holds the identifiers of a project and its resources,
+// as far as they are known. Identifiers of resources that are created
+// by the plan are filled in while applying it.
+type projectState struct {
+	id           string
+	url          string
+	cacerts      map[string]string
+	ipwhitelists map[string]string
+}
+
+// NewPlan compares the given manifest with the current state of the
+// organization (given manifest organization or the given default organization)
+// and returns the actions needed to converge to the manifest.
+func NewPlan(ctx context.Context, log zerolog.Logger, m *Manifest, orgID string, c Clients) (*Plan, error) {
+	if m.Organization != "" {
+		orgID = m.Organization
+	}
+	org, err := selection.SelectOrganization(ctx, log, orgID, c.ResourceManager)
+	if err != nil {
+		return nil, err
+	}
+	orgState, err := newOrganizationState(ctx, org, c)
+	if err != nil {
+		return nil, err
+	}
+	plan := &Plan{}
+	for _, x := range m.Groups {
+		if err := plan.addGroup(ctx, log, orgState, x, c); err != nil {
+			return nil, err
+		}
+	}
+	for _, x := range m.Roles {
+		if err := plan.addRole(orgState, x, c); err != nil {
+			return nil, err
+		}
+	}
+	if err := plan.addPolicy(ctx, log, orgState, "organization", org.GetName(), func() string { return org.GetUrl() }, m.Policy, c); err != nil {
+		return nil, err
+	}
+	for _, p := range m.Projects {
+		if err := plan.addProject(ctx, log, orgState, p, c); err != nil {
+			return nil, err
+		}
+	}
+	return plan, nil
+}
+
+// addProject adds the actions for the given project and its resources.
+func (plan *Plan) addProject(ctx context.Context, log zerolog.Logger, orgState *organizationState, p *Project, c Clients) error {
+	org := orgState.org
+	ps := &projectState{
+		cacerts:      make(map[string]string),
+		ipwhitelists: make(map[string]string),
+	}
+	current, err := selection.SelectProject(ctx, log, p.Name, org.GetId(), c.ResourceManager)
+	if common.IsNotFound(err) {
+		a := &Action{Type: ActionCreate, Kind: "project", Name: p.Name}
+		a.compareString("description", "", p.Description)
+		a.apply = func(ctx context.Context) error {
+			result, err := c.ResourceManager.CreateProject(ctx, &rm.Project{
+				OrganizationId: org.GetId(),
+				Name:           p.Name,
+				Description:    p.Description,
+			})
+			if err != nil {
+				return err
+			}
+			ps.id = result.GetId()
+			ps.url = result.GetUrl()
+			return nil
+		}
+		plan.Actions = append(plan.Actions, a)
+		current = nil
+	} else if err != nil {
+		return err
+	} else {
+		ps.id = current.GetId()
+		ps.url = current.GetUrl()
+		// Register all existing CA certificates & IP whitelists, so deployments
+		// can refer to them by name, even when they are not in the manifest.
+		cacerts, err := c.Crypto.ListCACertificates(ctx, &common.ListOptions{ContextId: ps.id})
+		if err != nil {
+			return err
+		}
+		for _, x := range cacerts.GetItems() {
+			ps.cacerts[x.GetName()] = x.GetId()
+		}
+		ipwhitelists, err := c.Security.ListIPWhitelists(ctx, &common.ListOptions{ContextId: ps.id})
+		if err != nil {
+			return err
+		}
+		for _, x := range ipwhitelists.GetItems() {
+			ps.ipwhitelists[x.GetName()] = x.GetId()
+		}
+		a := &Action{Type: ActionUpdate, Kind: "project", Name: p.Name}
+		a.compareString("description", current.GetDescription(), p.Description)
+		if len(a.Changes) > 0 {
+			a.apply = func(ctx context.Context) error {
+				current.Description = p.Description
+				_, err := c.ResourceManager.UpdateProject(ctx, current)
+				return err
+			}
+			plan.Actions = append(plan.Actions, a)
+		}
+	}
+
+	if err := plan.addPolicy(ctx, log, orgState, "project", p.Name, func() string { return ps.url }, p.Policy, c); err != nil {
+		return err
+	}
+	for _, x := range p.CACertificates {
+		if err := plan.addCACertificate(ctx, log, org, current, ps, p.Name, x, c); err != nil {
+			return err
+		}
+	}
+	for _, x := range p.IPWhitelists {
+		if err := plan.addIPWhitelist(ctx, log, org, current, ps, p.Name, x, c); err != nil {
+			return err
+		}
+	}
+	for _, x := range p.Deployments {
+		if err := plan.addDeployment(ctx, log, org, current, ps, p.Name, x, c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addCACertificate adds the action (if any) for the given CA certificate.
+// The given project is nil when it is created by the plan.
+func (plan *Plan) addCACertificate(ctx context.Context, log zerolog.Logger, org *rm.Organization, project *rm.Project, ps *projectState, projectName string, x *CACertificate, c Clients) error {
+	name := projectName + "/" + x.Name
+	var current *crypto.CACertificate
+	if project != nil {
+		var err error
+		current, err = selection.SelectCACertificate(ctx, log, x.Name, project.GetId(), org.GetId(), c.Crypto, c.ResourceManager)
+		if err != nil && !common.IsNotFound(err) {
+			return err
+		}
+	}
+	if current == nil {
+		var lifetime *types.Duration
+		if x.Lifetime != "" {
+			d, err := util.ParseDuration(x.Lifetime)
+			if err != nil {
+				return fmt.Errorf("Invalid lifetime of CA certificate '%s': %s", name, err)
+			}
+			lifetime = types.DurationProto(d)
+		}
+		a := &Action{Type: ActionCreate, Kind: "cacertificate", Name: name}
+		a.compareString("description", "", x.Description)
+		a.compareString("lifetime", "", x.Lifetime)
+		useWellKnownCertificate := x.UseWellKnownCertificate != nil && *x.UseWellKnownCertificate
+		a.compareBool("use-well-known-certificate", false, useWellKnownCertificate)
+		a.apply = func(ctx context.Context) error {
+			result, err := c.Crypto.CreateCACertificate(ctx, &crypto.CACertificate{
+				ProjectId:               ps.id,
+				Name:                    x.Name,
+				Description:             x.Description,
+				Lifetime:                lifetime,
+				UseWellKnownCertificate: useWellKnownCertificate,
+			})
+			if err != nil {
+				return err
+			}
+			ps.cacerts[x.Name] = result.GetId()
+			return nil
+		}
+		plan.Actions = append(plan.Actions, a)
+		return nil
+	}
+	ps.cacerts[x.Name] = current.GetId()
+	a := &Action{Type: ActionUpdate, Kind: "cacertificate", Name: name}
+	a.compareString("description", current.GetDescription(), x.Description)
+	if x.UseWellKnownCertificate != nil {
+		a.compareBool("use-well-known-certificate", current.GetUseWellKnownCertificate(), *x.UseWellKnownCertificate)
+	}
+	if len(a.Changes) > 0 {
+		a.apply = func(ctx context.Context) error {
+			if x.Description != "" {
+				current.Description = x.Description
+			}
+			if x.UseWellKnownCertificate != nil {
+				current.UseWellKnownCertificate = *x.UseWellKnownCertificate
+			}
+			_, err := c.Crypto.UpdateCACertificate(ctx, current)
+			return err
+		}
+		plan.Actions = append(plan.Actions, a)
+	}
+	return nil
+}
+
+// addIPWhitelist adds the action (if any) for the given IP whitelist.
+// The given project is nil when it is created by the plan.
+func (plan *Plan) addIPWhitelist(ctx context.Context, log zerolog.Logger, org *rm.Organization, project *rm.Project, ps *projectState, projectName string, x *IPWhitelist, c Clients) error {
+	name := projectName + "/" + x.Name
+	var current *security.IPWhitelist
+	if project != nil {
+		var err error
+		current, err = selection.SelectIPWhitelist(ctx, log, x.Name, project.GetId(), org.GetId(), c.Security, c.ResourceManager)
+		if err != nil && !common.IsNotFound(err) {
+			return err
+		}
+	}
+	if current == nil {
+		a := &Action{Type: ActionCreate, Kind: "ipwhitelist", Name: name}
+		a.compareString("description", "", x.Description)
+		a.compareStrings("cidr-ranges", nil, x.CIDRRanges)
+		a.apply = func(ctx context.Context) error {
+			result, err := c.Security.CreateIPWhitelist(ctx, &security.IPWhitelist{
+				ProjectId:   ps.id,
+				Name:        x.Name,
+				Description: x.Description,
+				CidrRanges:  sortedCopy(x.CIDRRanges),
+			})
+			if err != nil {
+				return err
+			}
+			ps.ipwhitelists[x.Name] = result.GetId()
+			return nil
+		}
+		plan.Actions = append(plan.Actions, a)
+		return nil
+	}
+	ps.ipwhitelists[x.Name] = current.GetId()
+	a := &Action{Type: ActionUpdate, Kind: "ipwhitelist", Name: name}
+	a.compareString("description", current.GetDescription(), x.Description)
+	a.compareStrings("cidr-ranges", current.GetCidrRanges(), x.CIDRRanges)
+	if len(a.Changes) > 0 {
+		a.apply = func(ctx context.Context) error {
+			if x.Description != "" {
+				current.Description = x.Description
+			}
+			if x.CIDRRanges != nil {
+				current.CidrRanges = sortedCopy(x.CIDRRanges)
+			}
+			_, err := c.Security.UpdateIPWhitelist(ctx, current)
+			return err
+		}
+		plan.Actions = append(plan.Actions, a)
+	}
+	return nil
+}
+
+// addDeployment adds the action (if any) for the given deployment.
+// The given project is nil when it is created by the plan.
+func (plan *Plan) addDeployment(ctx context.Context, log zerolog.Logger, org *rm.Organization, project *rm.Project, ps *projectState, projectName string, x *Deployment, c Clients) error {
+	name := projectName + "/" + x.Name
+	var current *data.Deployment
+	if project != nil {
+		var err error
+		current, err = selection.SelectDeployment(ctx, log, x.Name, project.GetId(), org.GetId(), c.Data, c.ResourceManager)
+		if err != nil && !common.IsNotFound(err) {
+			return err
+		}
+	}
+	if current == nil {
+		if x.Region == "" {
+			return fmt.Errorf("Deployment '%s' has no region", name)
+		}
+		a := &Action{Type: ActionCreate, Kind: "deployment", Name: name}
+		a.addDeploymentChanges(&data.Deployment{}, "", "", x)
+		a.compareString("region", "", x.Region)
+		a.apply = func(ctx context.Context) error {
+			desired, err := newDeployment(ctx, ps, x, c)
+			if err != nil {
+				return err
+			}
+			_, err = c.Data.CreateDeployment(ctx, desired)
+			return err
+		}
+		plan.Actions = append(plan.Actions, a)
+		return nil
+	}
+	if x.Region != "" && x.Region != current.GetRegionId() {
+		return fmt.Errorf("Cannot change region of deployment '%s' from '%s' to '%s'", name, current.GetRegionId(), x.Region)
+	}
+	a := &Action{Type: ActionUpdate, Kind: "deployment", Name: name}
+	a.addDeploymentChanges(current, nameOf(ps.cacerts, current.GetCertificates().GetCaCertificateId()), nameOf(ps.ipwhitelists, current.GetIpwhitelistId()), x)
+	if len(a.Changes) > 0 {
+		a.apply = func(ctx context.Context) error {
+			updateDeployment(current, ps, x)
+			_, err := c.Data.UpdateDeployment(ctx, current)
+			return err
+		}
+		plan.Actions = append(plan.Actions, a)
+	}
+	return nil
+}
+
+// addDeploymentChanges adds the changes between the given current deployment
+// (with names of its CA certificate & IP whitelist) and the desired deployment.
+func (a *Action) addDeploymentChanges(current *data.Deployment, cacertName, ipwhitelistName string, x *Deployment) {
+	a.compareString("description", current.GetDescription(), x.Description)
+	a.compareString("version", current.GetVersion(), x.Version)
+	a.compareString("cacertificate", cacertName, x.CACertificate)
+	a.compareString("ipwhitelist", ipwhitelistName, x.IPWhitelist)
+	a.compareString("model", current.GetModel().GetModel(), x.Model)
+	a.compareString("node-size-id", current.GetModel().GetNodeSizeId(), x.NodeSizeID)
+	a.compareInt("node-count", current.GetModel().GetNodeCount(), x.NodeCount)
+	a.compareInt("node-disk-size", current.GetModel().GetNodeDiskSize(), x.NodeDiskSize)
+	if s := x.Servers; s != nil {
+		cs := current.GetServers()
+		a.compareInt("servers.coordinators", cs.GetCoordinators(), s.Coordinators)
+		a.compareInt("servers.coordinator-memory-size", cs.GetCoordinatorMemorySize(), s.CoordinatorMemorySize)
+		a.compareInt("servers.dbservers", cs.GetDbservers(), s.DBServers)
+		a.compareInt("servers.dbserver-memory-size", cs.GetDbserverMemorySize(), s.DBServerMemorySize)
+		a.compareInt("servers.dbserver-disk-size", cs.GetDbserverDiskSize(), s.DBServerDiskSize)
+	}
+}
+
+// nameOf returns the name that maps to the given identifier in the given map.
+// If the identifier is not found, it is returned as is.
+func nameOf(names map[string]string, id string) string {
+	for name, x := range names {
+		if x == id {
+			return name
+		}
+	}
+	return id
+}
+
+// resolveID returns the identifier of the resource with given name in the given map.
+// If the name is not found, it is assumed to be an identifier.
+func resolveID(ids map[string]string, name string) string {
+	if id, found := ids[name]; found {
+		return id
+	}
+	return name
+}
+
+// newDeployment builds the deployment to create from the given desired state.
+func newDeployment(ctx context.Context, ps *projectState, x *Deployment, c Clients) (*data.Deployment, error) {
+	model := x.Model
+	if model == "" {
+		model = data.ModelOneShard
+	}
+	cacertID := resolveID(ps.cacerts, x.CACertificate)
+	if cacertID == "" {
+		// Use the default CA certificate of the project
+		list, err := c.Crypto.ListCACertificates(ctx, &common.ListOptions{ContextId: ps.id})
+		if err != nil {
+			return nil, err
+		}
+		for _, cert := range list.GetItems() {
+			if cert.GetIsDefault() {
+				cacertID = cert.GetId()
+			}
+		}
+	}
+	result := &data.Deployment{
+		ProjectId:   ps.id,
+		Name:        x.Name,
+		Description: x.Description,
+		RegionId:    x.Region,
+		Version:     x.Version,
+		Certificates: &data.Deployment_CertificateSpec{
+			CaCertificateId: cacertID,
+		},
+		IpwhitelistId: resolveID(ps.ipwhitelists, x.IPWhitelist),
+		Model: &data.Deployment_ModelSpec{
+			Model:        model,
+			NodeSizeId:   x.NodeSizeID,
+			NodeCount:    x.NodeCount,
+			NodeDiskSize: x.NodeDiskSize,
+		},
+	}
+	if model == data.ModelFlexible {
+		if s := x.Servers; s != nil {
+			result.Servers = &data.Deployment_ServersSpec{
+				Coordinators:          s.Coordinators,
+				CoordinatorMemorySize: s.CoordinatorMemorySize,
+				Dbservers:             s.DBServers,
+				DbserverMemorySize:    s.DBServerMemorySize,
+				DbserverDiskSize:      s.DBServerDiskSize,
+			}
+		}
+	} else {
+		if result.Model.NodeCount == 0 {
+			result.Model.NodeCount = 3
+		}
+		if result.Model.NodeSizeId == "" {
+			// Use the smallest node size
+			list, err := c.Data.ListNodeSizes(ctx, &data.NodeSizesRequest{
+				ProjectId: ps.id,
+				RegionId:  x.Region,
+			})
+			if err != nil {
+				return nil, err
+			}
+			if len(list.Items) < 1 {
+				return nil, fmt.Errorf("No available node sizes found")
+			}
+			sort.SliceStable(list.Items, func(i, j int) bool {
+				return list.Items[i].MemorySize < list.Items[j].MemorySize
+			})
+			result.Model.NodeSizeId = list.Items[0].Id
+			if result.Model.NodeDiskSize == 0 {
+				result.Model.NodeDiskSize = list.Items[0].MinDiskSize
+			}
+		}
+	}
+	return result, nil
+}
+
+// updateDeployment applies the desired state to the given current deployment.
+func updateDeployment(current *data.Deployment, ps *projectState, x *Deployment) {
+	if x.Description != "" {
+		current.Description = x.Description
+	}
+	if x.Version != "" {
+		current.Version = x.Version
+	}
+	if x.CACertificate != "" {
+		if current.Certificates == nil {
+			current.Certificates = &data.Deployment_CertificateSpec{}
+		}
+		current.Certificates.CaCertificateId = resolveID(ps.cacerts, x.CACertificate)
+	}
+	if x.IPWhitelist != "" {
+		current.IpwhitelistId = resolveID(ps.ipwhitelists, x.IPWhitelist)
+	}
+	if current.Model == nil {
+		current.Model = &data.Deployment_ModelSpec{}
+	}
+	if x.Model != "" {
+		current.Model.Model = x.Model
+	}
+	if x.NodeSizeID != "" {
+		current.Model.NodeSizeId = x.NodeSizeID
+	}
+	if x.NodeCount != 0 {
+		current.Model.NodeCount = x.NodeCount
+	}
+	if x.NodeDiskSize != 0 {
+		current.Model.NodeDiskSize = x.NodeDiskSize
+	}
+	if s := x.Servers; s != nil {
+		if current.Servers == nil {
+			current.Servers = &data.Deployment_ServersSpec{}
+		}
+		cs := current.Servers
+		if s.Coordinators != 0 {
+			cs.Coordinators = s.Coordinators
+		}
+		if s.CoordinatorMemorySize != 0 {
+			cs.CoordinatorMemorySize = s.CoordinatorMemorySize
+		}
+		if s.DBServers != 0 {
+			cs.Dbservers = s.DBServers
+		}
+		if s.DBServerMemorySize != 0 {
+			cs.DbserverMemorySize = s.DBServerMemorySize
+		}
+		if s.DBServerDiskSize != 0 {
+			cs.DbserverDiskSize = s.DBServerDiskSize
+		}
+	}
+}
@@ -32,17 +32,6 @@ import (
 	common "github.com/arangodb-managed/apis/common/v1"
 )
 
-// MustSelectBackup fetches a backup with given ID, name, or URL and fails if no backup is found.
-// If no ID is specified, all backups are fetched from the selected deployment
-// and if the list is exactly 1 long, that backup is returned.
-func MustSelectBackup(ctx context.Context, log zerolog.Logger, id string, backupc backup.BackupServiceClient) *backup.Backup {
-	backup, err := SelectBackup(ctx, log, id, backupc)
-	if err != nil {
-		log.Fatal().Err(err).Msg("Failed to list backup")
-	}
-	return backup
-}
-
 // SelectBackup fetches a backup with given ID, name, or URL or returns an error if not found.
 // If no ID is specified, all backups are fetched from the selected deployment
 // and if the list is exactly 1 long, that backup is returned.
@@ -33,17 +33,6 @@ import (
 	rm "github.com/arangodb-managed/apis/resourcemanager/v1"
 )
 
-// MustSelectCACertificate fetches the CA certificate with given ID, name, or URL and fails if no certificate is found.
-// If no ID is specified, all CA certificate are fetched from the selected project
-// and if the list is exactly 1 long, that CA certificate is returned.
-func MustSelectCACertificate(ctx context.Context, log zerolog.Logger, id, projectID, orgID string, cryptoc crypto.CryptoServiceClient, rmc rm.ResourceManagerServiceClient) *crypto.CACertificate {
-	cert, err := SelectCACertificate(ctx, log, id, projectID, orgID, cryptoc, rmc)
-	if err != nil {
-		log.Fatal().Err(err).Msg("Failed to list CA certificates")
-	}
-	return cert
-}
-
 // SelectCACertificate fetches the CA certificate with given ID, name, or URL and returns an error if not found..
 // If no ID is specified, all CA certificate are fetched from the selected project
 // and if the list is exactly 1 long, that CA certificate is returned.
@@ -33,17 +33,6 @@ import (
 	rm "github.com/arangodb-managed/apis/resourcemanager/v1"
 )
 
-// MustSelectDeployment fetches the deployment given ID, name, or URL and fails if no deployment is found.
-// If no ID is specified, all deployments are fetched from the selected project
-// and if the list is exactly 1 long, that deployment is returned.
-func MustSelectDeployment(ctx context.Context, log zerolog.Logger, id, projectID, orgID string, datac data.DataServiceClient, rmc rm.ResourceManagerServiceClient) *data.Deployment {
-	deployment, err := SelectDeployment(ctx, log, id, projectID, orgID, datac, rmc)
-	if err != nil {
-		log.Fatal().Err(err).Msg("Failed to list deployments")
-	}
-	return deployment
-}
-
 // SelectDeployment fetches the deployment given ID, name, or URL or returns an error if not found.
 // If no ID is specified, all deployments are fetched from the selected project
 // and if the list is exactly 1 long, that deployment is returned.
@@ -31,15 +31,6 @@ import (
 	example "github.com/arangodb-managed/apis/example/v1"
 )
 
-// MustSelectExampleDataset fetches an example dataset with given ID, name, or URL and fails if no such item is found.
-func MustSelectExampleDataset(ctx context.Context, log zerolog.Logger, id string, examplec example.ExampleDatasetServiceClient) *example.ExampleDataset {
-	result, err := SelectExampleDataset(ctx, log, id, examplec)
-	if err != nil {
-		log.Fatal().Err(err).Msg("Failed to select example dataset")
-	}
-	return result
-}
-
 // SelectExampleDataset fetches an example dataset with given ID, name, or URL or returns an error if not found.
 func SelectExampleDataset(ctx context.Context, log zerolog.Logger, id string, examplec example.ExampleDatasetServiceClient) (*example.ExampleDataset, error) {
 	result, err := examplec.GetExampleDataset(ctx, &common.IDOptions{Id: id})
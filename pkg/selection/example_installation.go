@@ -34,20 +34,6 @@ import (
 	rm "github.com/arangodb-managed/apis/resourcemanager/v1"
 )
 
-// MustSelectExampleDatasetInstallation fetches an example dataset installation with given ID, name, or URL and fails if no such item is found.
-// If no ID is specified, all installations are fetched from the selected deployment
-// and if the list is exactly 1 long, that backup is returned.
-func MustSelectExampleDatasetInstallation(ctx context.Context, log zerolog.Logger, id, deploymentID, projectID, organizationID string,
-	datac data.DataServiceClient,
-	examplec example.ExampleDatasetServiceClient,
-	rmc rm.ResourceManagerServiceClient) *example.ExampleDatasetInstallation {
-	result, err := SelectExampleDatasetInstallation(ctx, log, id, deploymentID, projectID, organizationID, datac, examplec, rmc)
-	if err != nil {
-		log.Fatal().Err(err).Msg("Failed to select example dataset installation")
-	}
-	return result
-}
-
 // SelectExampleDatasetInstallation fetches an example dataset with given ID, name, or URL or returns an error if not found.
 func SelectExampleDatasetInstallation(ctx context.Context, log zerolog.Logger, id, deploymentID, projectID, organizationID string,
 	datac data.DataServiceClient,
@@ -33,17 +33,6 @@ import (
 	rm "github.com/arangodb-managed/apis/resourcemanager/v1"
 )
 
-// MustSelectGroup fetches the group with given ID, name, or URL and fails if no group is found.
-// If no ID is specified, all groups are fetched from the selected organization
-// and if the list is exactly 1 long, that group is returned.
-func MustSelectGroup(ctx context.Context, log zerolog.Logger, id, orgID string, iamc iam.IAMServiceClient, rmc rm.ResourceManagerServiceClient) *iam.Group {
-	group, err := SelectGroup(ctx, log, id, orgID, iamc, rmc)
-	if err != nil {
-		log.Fatal().Err(err).Msg("Failed to list groups")
-	}
-	return group
-}
-
 // SelectGroup fetches the group  with given ID, name, or URL or returns an error if not found.
 // If no ID is specified, all groups are fetched from the selected organization
 // and if the list is exactly 1 long, that group is returned.
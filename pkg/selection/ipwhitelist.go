@@ -33,17 +33,6 @@ import (
 	security "github.com/arangodb-managed/apis/security/v1"
 )
 
-// MustSelectIPWhitelist fetches the IP whitelist with given ID, name, or URL and fails if no organization is found.
-// If no ID is specified, all IP whitelists are fetched from the selected project
-// and if the list is exactly 1 long, that IP whitelist is returned.
-func MustSelectIPWhitelist(ctx context.Context, log zerolog.Logger, id, projectID, orgID string, securityc security.SecurityServiceClient, rmc rm.ResourceManagerServiceClient) *security.IPWhitelist {
-	ipwhitelist, err := SelectIPWhitelist(ctx, log, id, projectID, orgID, securityc, rmc)
-	if err != nil {
-		log.Fatal().Err(err).Msg("Failed to list IP whitelists")
-	}
-	return ipwhitelist
-}
-
 // SelectIPWhitelist fetches the IP whitelist with given ID, name, or URL and fails if no whitelist is found.
 // If no ID is specified, all IP whitelists are fetched from the selected project
 // and if the list is exactly 1 long, that IP whitelist is returned.
@@ -33,17 +33,6 @@ import (
 	rm "github.com/arangodb-managed/apis/resourcemanager/v1"
 )
 
-// MustSelectMember fetches the member with given ID, name, or email and fails if no member is found.
-// If no ID is specified, all members are fetched from the selected organization
-// and if the list is exactly 1 long, that user is returned.
-func MustSelectMember(ctx context.Context, log zerolog.Logger, id, orgID string, iamc iam.IAMServiceClient, rmc rm.ResourceManagerServiceClient) *iam.User {
-	member, err := SelectMember(ctx, log, id, orgID, iamc, rmc)
-	if err != nil {
-		log.Fatal().Err(err).Msg("Failed to get member")
-	}
-	return member
-}
-
 // SelectMember fetches the member with given ID, name, or email or returns an error if not found.
 // If no ID is specified, all members are fetched from the selected organization
 // and if the list is exactly 1 long, that user is returned.
@@ -32,17 +32,6 @@ import (
 	rm "github.com/arangodb-managed/apis/resourcemanager/v1"
 )
 
-// MustSelectOrganization fetches the organization with given ID, name, or URL and fails if no organization is found.
-// If no ID is specified, all organizations are fetched and if the user
-// is member of exactly 1, that organization is returned.
-func MustSelectOrganization(ctx context.Context, log zerolog.Logger, id string, rmc rm.ResourceManagerServiceClient) *rm.Organization {
-	org, err := SelectOrganization(ctx, log, id, rmc)
-	if err != nil {
-		log.Fatal().Err(err).Msg("Failed to list organizations")
-	}
-	return org
-}
-
 // SelectOrganization fetches the organization with given ID, name, or URL or returns an error if not found.
 // If no ID is specified, all organizations are fetched and if the user
 // is member of exactly 1, that organization is returned.
@@ -32,17 +32,6 @@ import (
 	rm "github.com/arangodb-managed/apis/resourcemanager/v1"
 )
 
-// MustSelectOrganizationInvite fetches the organization invite with given ID, email, or URL and fails if no invite is found.
-// If no ID is specified, all invites are fetched from the selected organization
-// and if the list is exactly 1 long, that invite is returned.
-func MustSelectOrganizationInvite(ctx context.Context, log zerolog.Logger, id, orgID string, rmc rm.ResourceManagerServiceClient) *rm.OrganizationInvite {
-	invite, err := SelectOrganizationInvite(ctx, log, id, orgID, rmc)
-	if err != nil {
-		log.Fatal().Err(err).Msg("Failed to get organization invites")
-	}
-	return invite
-}
-
 // SelectOrganizationInvite fetches the organization invite with given ID, email, or URL or returns an error if not found.
 // If no ID is specified, all invites are fetched from the selected organization
 // and if the list is exactly 1 long, that invite is returned.
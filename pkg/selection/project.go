@@ -32,18 +32,7 @@ import (
 	rm "github.com/arangodb-managed/apis/resourcemanager/v1"
 )
 
-// MustSelectProject fetches the project with given ID, name, or URL and fails if no project is found.
-// If no ID is specified, all projects are fetched from the selected organization
-// and if the list is exactly 1 long, that project is returned.
-func MustSelectProject(ctx context.Context, log zerolog.Logger, id, orgID string, rmc rm.ResourceManagerServiceClient) *rm.Project {
-	project, err := SelectProject(ctx, log, id, orgID, rmc)
-	if err != nil {
-		log.Fatal().Err(err).Msg("Failed to get project")
-	}
-	return project
-}
-
-// MustSelectProject fetches the project with given ID, name, or URL or returns an error if not found.
+// SelectProject fetches the project with given ID, name, or URL or returns an error if not found.
 // If no ID is specified, all projects are fetched from the selected organization
 // and if the list is exactly 1 long, that project is returned.
 func SelectProject(ctx context.Context, log zerolog.Logger, id, orgID string, rmc rm.ResourceManagerServiceClient) (*rm.Project, error) {
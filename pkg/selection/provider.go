@@ -32,17 +32,6 @@ import (
 	platform "github.com/arangodb-managed/apis/platform/v1"
 )
 
-// MustSelectProvider fetches the provider with given ID, or name and fails if no provider is found.
-// If no ID is specified, all providers are fetched and if the user
-// is member of exactly 1, that provider is returned.
-func MustSelectProvider(ctx context.Context, log zerolog.Logger, id string, organizationID string, platformc platform.PlatformServiceClient) *platform.Provider {
-	provider, err := SelectProvider(ctx, log, id, organizationID, platformc)
-	if err != nil {
-		log.Fatal().Err(err).Msg("Failed to list providers")
-	}
-	return provider
-}
-
 // SelectProvider fetches the provider with given ID, or name or returns an error if not found.
 // If no ID is specified, all providers are fetched and if the user
 // is member of exactly 1, that provider is returned.
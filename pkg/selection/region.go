@@ -32,17 +32,6 @@ import (
 	platform "github.com/arangodb-managed/apis/platform/v1"
 )
 
-// MustSelectRegion fetches the region with given ID or location and fails if no region is found.
-// If no ID is specified, all regions are fetched from the selected provider
-// and if the list is exactly 1 long, that region is returned.
-func MustSelectRegion(ctx context.Context, log zerolog.Logger, id, providerID string, organizationID string, platformc platform.PlatformServiceClient) *platform.Region {
-	region, err := SelectRegion(ctx, log, id, providerID, organizationID, platformc)
-	if err != nil {
-		log.Fatal().Err(err).Msg("Failed to list regions")
-	}
-	return region
-}
-
 // SelectRegion fetches the region with given ID or location or returns an error if not found.
 // If no ID is specified, all regions are fetched from the selected provider
 // and if the list is exactly 1 long, that region is returned.
@@ -33,17 +33,6 @@ import (
 	rm "github.com/arangodb-managed/apis/resourcemanager/v1"
 )
 
-// MustSelectRole fetches the role with given ID, name, or URL and fails if no role is found.
-// If no ID is specified, all roles are fetched from the selected organization
-// and if the list is exactly 1 long, that role is returned.
-func MustSelectRole(ctx context.Context, log zerolog.Logger, id, orgID string, iamc iam.IAMServiceClient, rmc rm.ResourceManagerServiceClient) *iam.Role {
-	role, err := SelectRole(ctx, log, id, orgID, iamc, rmc)
-	if err != nil {
-		log.Fatal().Err(err).Msg("Failed to list roles")
-	}
-	return role
-}
-
 // SelectRole fetches the role with given ID, name, or URL or returns an error if not found.
 // If no ID is specified, all roles are fetched from the selected organization
 // and if the list is exactly 1 long, that role is returned.
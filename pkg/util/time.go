@@ -23,19 +23,118 @@
 package util
 
 import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/araddon/dateparse"
 )
 
-// ParseTimeFromNow parse a timestamp or duration before now.
+var (
+	// dayWeekUnitRegexp matches number with a day or week unit in a duration.
+	dayWeekUnitRegexp = regexp.MustCompile(`([0-9]*\.?[0-9]+)([dw])`)
+	// now returns the current time, replaceable for testing.
+	now = time.Now
+)
+
+// ParseTimeFromNow parses a timestamp or a duration before now.
+// See ParseTime for the supported formats.
 func ParseTimeFromNow(value string) (time.Time, error) {
-	if d, err := time.ParseDuration(value); err == nil {
-		return time.Now().UTC().Add(-d), nil
+	return ParseTime(value, false)
+}
+
+// ParseTimeAfterNow parses a timestamp or a duration after now.
+// See ParseTime for the supported formats.
+func ParseTimeAfterNow(value string) (time.Time, error) {
+	return ParseTime(value, true)
+}
+
+// ParseTime parses a time given as:
+//   - a duration relative to now (see ParseDuration), e.g. "2h" or "7d".
+//     Without a sign, the duration is before now, unless future is set.
+//     With a sign ("-2h" or "+2h") the duration is before resp. after now.
+//   - one of "now", "today", "yesterday" or "tomorrow" (the latter 3 at midnight UTC).
+//   - an RFC3339 timestamp, e.g. "2020-05-01T12:00:00Z".
+//   - a date, e.g. "2020-05-01", or any other timestamp format known to dateparse.
+//
+// Timestamps without a time zone are in UTC and the result is always in UTC.
+func ParseTime(value string, future bool) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	current := now().UTC()
+	if d, err := ParseDuration(value); err == nil {
+		if !strings.HasPrefix(value, "+") && !strings.HasPrefix(value, "-") && !future {
+			d = -d
+		}
+		return current.Add(d), nil
+	}
+	today := time.Date(current.Year(), current.Month(), current.Day(), 0, 0, 0, 0, current.Location())
+	switch strings.ToLower(value) {
+	case "now":
+		return current, nil
+	case "today":
+		return today, nil
+	case "yesterday":
+		return today.AddDate(0, 0, -1), nil
+	case "tomorrow":
+		return today.AddDate(0, 0, 1), nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t.UTC(), nil
 	}
-	ts, err := dateparse.ParseAny(value)
+	if value != "" {
+		if t, err := dateparse.ParseIn(value, time.UTC); err == nil {
+			return t.UTC(), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time '%s': expected a duration (e.g. 2h or 7d), a date (e.g. 2020-05-01), an RFC3339 timestamp, now, today, yesterday or tomorrow", value)
+}
+
+// ParseDuration parses a duration like time.ParseDuration, additionally
+// accepting days (d) and weeks (w) as units, e.g. "30d" or "1w12h".
+func ParseDuration(value string) (time.Duration, error) {
+	converted := dayWeekUnitRegexp.ReplaceAllStringFunc(strings.TrimSpace(value), func(part string) string {
+		m := dayWeekUnitRegexp.FindStringSubmatch(part)
+		n, _ := strconv.ParseFloat(m[1], 64)
+		hours := n * 24
+		if m[2] == "w" {
+			hours *= 7
+		}
+		return strconv.FormatFloat(hours, 'f', -1, 64) + "h"
+	})
+	d, err := time.ParseDuration(converted)
 	if err != nil {
-		return time.Time{}, err
+		return 0, fmt.Errorf("invalid duration '%s': expected e.g. 90s, 30m, 2h, 7d or 1w", value)
+	}
+	return d, nil
+}
+
+// FormatDuration formats a duration in the format accepted by ParseDuration,
+// using days for whole days and leaving out zero minutes & seconds,
+// e.g. "30d", "1d12h" or "90m" instead of "720h0m0s", "36h0m0s" or "1h30m0s".
+func FormatDuration(d time.Duration) string {
+	if d == 0 {
+		return "0s"
+	}
+	sign := ""
+	if d < 0 {
+		sign, d = "-", -d
+	}
+	result := ""
+	if days := d / (24 * time.Hour); days > 0 {
+		result = fmt.Sprintf("%dd", days)
+		d -= days * 24 * time.Hour
+	}
+	if d > 0 {
+		s := d.String()
+		if strings.HasSuffix(s, "m0s") {
+			s = strings.TrimSuffix(s, "0s")
+		}
+		if strings.HasSuffix(s, "h0m") {
+			s = strings.TrimSuffix(s, "0m")
+		}
+		result += s
 	}
-	return ts, nil
+	return sign + result
 }
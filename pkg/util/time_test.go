@@ -0,0 +1,115 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Ewout Prangsma
+//
+
+package util
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDuration(t *testing.T) {
+	tests := map[string]time.Duration{
+		"90s":   90 * time.Second,
+		"2h30m": 150 * time.Minute,
+		"7d":    7 * 24 * time.Hour,
+		"1.5d":  36 * time.Hour,
+		"1w12h": 180 * time.Hour,
+		"-2d":   -48 * time.Hour,
+	}
+	for input, expected := range tests {
+		if d, err := ParseDuration(input); err != nil || d != expected {
+			t.Errorf("ParseDuration(%q) = %s, %v; expected %s", input, d, err, expected)
+		}
+	}
+	for _, input := range []string{"", "7", "d", "2 days"} {
+		if _, err := ParseDuration(input); err == nil {
+			t.Errorf("ParseDuration(%q) expected to fail", input)
+		}
+	}
+}
+
+func TestParseTime(t *testing.T) {
+	current := time.Date(2020, 5, 10, 15, 30, 0, 0, time.UTC)
+	// The local time zone of the caller must not affect the result
+	now = func() time.Time { return current.In(time.FixedZone("CEST", 2*60*60)) }
+	defer func() { now = time.Now }()
+
+	tests := []struct {
+		input    string
+		future   bool
+		expected time.Time
+	}{
+		{"2h", false, current.Add(-2 * time.Hour)},
+		{"2h", true, current.Add(2 * time.Hour)},
+		{"+2h", false, current.Add(2 * time.Hour)},
+		{"-2h", true, current.Add(-2 * time.Hour)},
+		{"7d", false, current.AddDate(0, 0, -7)},
+		{"7d", true, current.AddDate(0, 0, 7)},
+		{"+1d12h", false, current.Add(36 * time.Hour)},
+		{"-1w", false, current.AddDate(0, 0, -7)},
+		{"-1w", true, current.AddDate(0, 0, -7)},
+		{"+90m", true, current.Add(90 * time.Minute)},
+		{"now", false, current},
+		{"today", false, time.Date(2020, 5, 10, 0, 0, 0, 0, time.UTC)},
+		{"Yesterday", false, time.Date(2020, 5, 9, 0, 0, 0, 0, time.UTC)},
+		{"tomorrow", true, time.Date(2020, 5, 11, 0, 0, 0, 0, time.UTC)},
+		{"2020-05-01T12:00:00Z", false, time.Date(2020, 5, 1, 12, 0, 0, 0, time.UTC)},
+		{"2020-05-01T12:00:00+02:00", false, time.Date(2020, 5, 1, 10, 0, 0, 0, time.UTC)},
+	}
+	for _, test := range tests {
+		if ts, err := ParseTime(test.input, test.future); err != nil || !ts.Equal(test.expected) || ts.Location() != time.UTC {
+			t.Errorf("ParseTime(%q, %v) = %s, %v; expected %s", test.input, test.future, ts, err, test.expected)
+		}
+	}
+	if ts, err := ParseTime("2020-05-01", false); err != nil || !ts.Equal(time.Date(2020, 5, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("ParseTime(2020-05-01) = %s, %v", ts, err)
+	}
+	for _, input := range []string{"", "soon", "2020-13-45"} {
+		if _, err := ParseTime(input, false); err == nil {
+			t.Errorf("ParseTime(%q) expected to fail", input)
+		}
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	tests := map[time.Duration]string{
+		0:                             "0s",
+		500 * time.Millisecond:        "500ms",
+		90 * time.Minute:              "1h30m",
+		2 * time.Hour:                 "2h",
+		36 * time.Hour:                "1d12h",
+		30 * 24 * time.Hour:           "30d",
+		8760 * time.Hour:              "365d",
+		-48 * time.Hour:               "-2d",
+		24*time.Hour + 90*time.Second: "1d1m30s",
+	}
+	for input, expected := range tests {
+		s := FormatDuration(input)
+		if s != expected {
+			t.Errorf("FormatDuration(%s) = %q; expected %q", input, s, expected)
+		}
+		if d, err := ParseDuration(s); err != nil || d != input {
+			t.Errorf("ParseDuration(FormatDuration(%s)) = %s, %v", input, d, err)
+		}
+	}
+}
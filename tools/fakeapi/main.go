@@ -0,0 +1,55 @@
+//
+// DISCLAIMER
+//
+// Copyright 2020 ArangoDB GmbH, Cologne, Germany
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Copyright holder is ArangoDB GmbH, Cologne, Germany
+//
+// Author Ewout Prangsma
+//
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/arangodb-managed/oasisctl/pkg/fakeapi"
+)
+
+// fakeapi runs an in-memory fake ArangoDB Oasis API, so oasisctl (and scripts using it)
+// can be tested offline, e.g.:
+//
+//	go run ./tools/fakeapi --listen 127.0.0.1:8443 &
+//	oasisctl --endpoint 127.0.0.1:8443 --plaintext --token fake-token list organizations
+func main() {
+	listen := flag.String("listen", "127.0.0.1:8443", "Address to listen on")
+	flag.Parse()
+
+	s := fakeapi.New()
+	if err := s.Start(*listen); err != nil {
+		log.Fatalf("Failed to start fake API: %v", err)
+	}
+	fmt.Printf("Fake ArangoDB Oasis API listening on %s (token: %s)\n", s.Address(), fakeapi.DefaultToken)
+
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, os.Interrupt, syscall.SIGTERM)
+	<-sigs
+	s.Stop()
+}